@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 
@@ -9,20 +10,27 @@ import (
 	"go.uber.org/fx/fxevent"
 
 	"cmt/internal/app/cli"
+	"cmt/internal/app/errors"
 	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
 // Run is the main entry point for the application
 func Run() int {
-	cfg, err := config.Load()
+	flags, args := config.ParseFlags(os.Args[1:])
+
+	cfg, err := config.Load(flags)
 	if err != nil {
-		return 1
+		if !repairsConfig(args) {
+			fmt.Fprintln(os.Stderr, errors.Format(err))
+			return 1
+		}
+		cfg = config.DefaultConfig()
 	}
 
 	ctx := context.Background()
 
-	fxApp, exitCode := createFxApp(ctx, cfg, Module)
+	fxApp, exitCode := createFxApp(ctx, cfg, config.Flags(flags), args, Module)
 
 	if err := fxApp.Start(ctx); err != nil {
 		return 1
@@ -35,15 +43,27 @@ func Run() int {
 	return exitCode
 }
 
-// createFxApp creates and configures the FX application
-func createFxApp(ctx context.Context, cfg *config.Config, module fx.Option) (*fx.App, int) {
-	args := os.Args[1:]
+// repairsConfig reports whether the command can inspect or fix a broken
+// configuration, so it runs with the defaults when loading fails
+func repairsConfig(args []string) bool {
+	if len(args) < 2 || args[0] != "config" {
+		return false
+	}
 
+	switch args[1] {
+	case "validate", "init", "set":
+		return true
+	}
+	return false
+}
+
+// createFxApp creates and configures the FX application
+func createFxApp(ctx context.Context, cfg *config.Config, flags config.Flags, args []string, module fx.Option) (*fx.App, int) {
 	var exitCode int
 
 	return fx.New(
 		fx.WithLogger(createFxLogger(cfg)),
-		fx.Supply(cfg, args),
+		fx.Supply(cfg, flags, args),
 		module,
 		fx.Invoke(func(cliInstance *cli.CLI) {
 			exitCode = cliInstance.Run(ctx, args)
@@ -89,11 +89,6 @@ func Test_CreateFxApp(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	oldArgs := os.Args
-	defer func() { os.Args = oldArgs }()
-
-	os.Args = []string{"cmt", "help"}
-
 	mockRunner := cli.NewMockRunner(ctrl)
 	mockCmd := commands.NewMockCommand(ctrl)
 
@@ -114,7 +109,7 @@ func Test_CreateFxApp(t *testing.T) {
 	cfg := config.DefaultConfig()
 	ctx := context.Background()
 
-	fxApp, exitCode := createFxApp(ctx, cfg, mockModule)
+	fxApp, exitCode := createFxApp(ctx, cfg, config.Flags{}, []string{"help"}, mockModule)
 
 	assert.NotNil(t, fxApp)
 	assert.Equal(t, 0, exitCode)
@@ -218,3 +213,48 @@ func Test_CreateFxLogger_ConsoleLogger_WritesToStdout(t *testing.T) {
 	assert.NotNil(t, consoleLogger)
 	assert.Equal(t, os.Stdout, consoleLogger.W)
 }
+
+func Test_RepairsConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{
+			name:     "Success with config validate",
+			args:     []string{"config", "validate"},
+			expected: true,
+		},
+		{
+			name:     "Success with config init",
+			args:     []string{"config", "init", "--global"},
+			expected: true,
+		},
+		{
+			name:     "Success with config set",
+			args:     []string{"config", "set", "model.temperature", "0.2"},
+			expected: true,
+		},
+		{
+			name:     "Failure with config show",
+			args:     []string{"config", "show"},
+			expected: false,
+		},
+		{
+			name:     "Failure with config without subcommand",
+			args:     []string{"config"},
+			expected: false,
+		},
+		{
+			name:     "Failure with other command",
+			args:     []string{"changelog"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repairsConfig(tt.args))
+		})
+	}
+}
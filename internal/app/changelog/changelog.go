@@ -0,0 +1,265 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cmt/internal/app/git"
+)
+
+// Unreleased is the version title used when the newest commit is not tagged
+const Unreleased = "Unreleased"
+
+// section is a changelog heading and the commit type listed under it
+type section struct {
+	Type  string
+	Title string
+}
+
+// sections lists the changelog headings in the order they are rendered
+var sections = []section{
+	{Type: "feat", Title: "Features"},
+	{Type: "fix", Title: "Fixes"},
+	{Type: "perf", Title: "Performance"},
+	{Type: "refactor", Title: "Refactor"},
+	{Type: "docs", Title: "Documentation"},
+	{Type: "style", Title: "Style"},
+	{Type: "test", Title: "Tests"},
+	{Type: "build", Title: "Build"},
+	{Type: "ci", Title: "CI"},
+	{Type: "chore", Title: "Chore"},
+	{Type: "revert", Title: "Reverts"},
+}
+
+// Options controls how a changelog is rendered
+type Options struct {
+	// Version is the title of the release section, detected from the tags of the newest commit when empty
+	Version string
+	// URL is the web address of the repository used to link commit hashes
+	URL string
+	// Package is the name of the monorepo package the changelog is built for
+	Package string
+	// TagPrefix selects the release tags of the package, such as api/ for api/v1.2.0
+	TagPrefix string
+}
+
+// Release is the changelog of a single version
+type Release struct {
+	Package  string    `json:"package,omitempty" yaml:"package,omitempty"`
+	Version  string    `json:"version" yaml:"version"`
+	Date     string    `json:"date,omitempty" yaml:"date,omitempty"`
+	Sections []Section `json:"sections" yaml:"sections"`
+	Breaking []Entry   `json:"breaking" yaml:"breaking"`
+}
+
+// Section groups the entries of one commit type
+type Section struct {
+	Type    string  `json:"type" yaml:"type"`
+	Title   string  `json:"title" yaml:"title"`
+	Entries []Entry `json:"entries" yaml:"entries"`
+}
+
+// Entry is a single commit of the changelog
+type Entry struct {
+	Hash         string `json:"hash" yaml:"hash"`
+	ShortHash    string `json:"short_hash" yaml:"short_hash"`
+	Type         string `json:"type,omitempty" yaml:"type,omitempty"`
+	Scope        string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Description  string `json:"description" yaml:"description"`
+	Breaking     bool   `json:"breaking" yaml:"breaking"`
+	BreakingNote string `json:"breaking_note,omitempty" yaml:"breaking_note,omitempty"`
+	Author       string `json:"author,omitempty" yaml:"author,omitempty"`
+	URL          string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// Render builds a Markdown changelog from conventional commits without calling the model.
+// Merge commits are skipped and commits not following the format are listed under Other
+func Render(commits []git.Commit, opts Options) string {
+	return Markdown([]Release{Build(commits, opts)})
+}
+
+// Build groups conventional commits by type into a release, ordering the
+// entries of each section by scope. Merge commits are skipped, commits not
+// following the format are listed under Other and breaking changes are
+// repeated in their own list
+func Build(commits []git.Commit, opts Options) Release {
+	release := Release{Package: opts.Package, Version: opts.Version, Breaking: []Entry{}, Sections: []Section{}}
+	if release.Version == "" {
+		release.Version = Version(commits, opts.TagPrefix)
+	}
+	if len(commits) > 0 && !commits[0].Date.IsZero() {
+		release.Date = commits[0].Date.Format(time.DateOnly)
+	}
+
+	grouped := make(map[string][]Entry)
+	var other []Entry
+
+	for _, c := range commits {
+		if c.IsMerge() {
+			continue
+		}
+
+		e := newEntry(c, opts.URL)
+		if e.Breaking {
+			release.Breaking = append(release.Breaking, e)
+		}
+
+		if !isKnownType(e.Type) {
+			other = append(other, e)
+			continue
+		}
+		grouped[e.Type] = append(grouped[e.Type], e)
+	}
+
+	for _, s := range sections {
+		if entries := grouped[s.Type]; len(entries) > 0 {
+			release.Sections = append(release.Sections, Section{Type: s.Type, Title: s.Title, Entries: sortByScope(entries)})
+		}
+	}
+	if len(other) > 0 {
+		release.Sections = append(release.Sections, Section{Type: "other", Title: "Other", Entries: sortByScope(other)})
+	}
+
+	return release
+}
+
+// Markdown renders releases in the format of the changelog prompt
+func Markdown(releases []Release) string {
+	var b strings.Builder
+	b.WriteString("# CHANGELOG\n")
+
+	for _, r := range releases {
+		fmt.Fprintf(&b, "\n## [%s]\n", r.Version)
+
+		for _, s := range r.Sections {
+			fmt.Fprintf(&b, "\n### %s\n\n", s.Title)
+			for _, e := range s.Entries {
+				if label := e.label(); label != "" {
+					fmt.Fprintf(&b, "- **%s:** %s %s\n", label, e.Description, e.link())
+					continue
+				}
+				fmt.Fprintf(&b, "- %s %s\n", e.Description, e.link())
+			}
+		}
+
+		if len(r.Breaking) > 0 {
+			b.WriteString("\n### Breaking Changes\n\n")
+			for _, e := range r.Breaking {
+				fmt.Fprintf(&b, "- **BREAKING CHANGES:** %s %s\n", e.note(), e.link())
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// Version returns the first tag of the newest commit starting with the
+// prefix, without the prefix and v, or Unreleased
+func Version(commits []git.Commit, prefix string) string {
+	if len(commits) == 0 {
+		return Unreleased
+	}
+
+	for _, tag := range commits[0].Tags() {
+		if name, ok := strings.CutPrefix(tag, prefix); ok {
+			return strings.TrimPrefix(name, "v")
+		}
+	}
+
+	return Unreleased
+}
+
+// RepositoryURL converts a git remote such as git@github.com:owner/repo.git to
+// its web address, returning an empty string for remotes without one
+func RepositoryURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	remote = strings.TrimSuffix(remote, ".git")
+
+	switch {
+	case strings.HasPrefix(remote, "https://"), strings.HasPrefix(remote, "http://"):
+		scheme, rest, _ := strings.Cut(remote, "://")
+		if _, host, ok := strings.Cut(rest, "@"); ok {
+			rest = host
+		}
+		return scheme + "://" + rest
+	case strings.HasPrefix(remote, "ssh://"):
+		rest := strings.TrimPrefix(remote, "ssh://")
+		if _, host, ok := strings.Cut(rest, "@"); ok {
+			rest = host
+		}
+		host, path, _ := strings.Cut(rest, "/")
+		host, _, _ = strings.Cut(host, ":")
+		return "https://" + host + "/" + path
+	case strings.Contains(remote, "@") && strings.Contains(remote, ":"):
+		_, rest, _ := strings.Cut(remote, "@")
+		host, path, _ := strings.Cut(rest, ":")
+		return "https://" + host + "/" + path
+	default:
+		return ""
+	}
+}
+
+// newEntry converts a commit into a changelog entry
+func newEntry(c git.Commit, url string) Entry {
+	cc := c.Conventional()
+
+	e := Entry{
+		Hash:         c.Hash,
+		ShortHash:    c.ShortHash(),
+		Type:         cc.Type,
+		Scope:        cc.Scope,
+		Description:  cc.Description,
+		Breaking:     cc.Breaking,
+		BreakingNote: cc.BreakingNote,
+		Author:       c.Author,
+	}
+	if url != "" {
+		e.URL = url + "/commit/" + c.Hash
+	}
+
+	return e
+}
+
+// sortByScope orders entries by scope, keeping the history order within a scope
+func sortByScope(entries []Entry) []Entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Scope < entries[j].Scope
+	})
+	return entries
+}
+
+// label returns the type and scope of the entry, such as feat(api)
+func (e Entry) label() string {
+	if e.Type == "" || e.Scope == "" {
+		return e.Type
+	}
+	return e.Type + "(" + e.Scope + ")"
+}
+
+// note returns the description of the breaking change
+func (e Entry) note() string {
+	if e.BreakingNote != "" {
+		return e.BreakingNote
+	}
+	return e.Description
+}
+
+// link formats the short hash, linked to the commit when its URL is known
+func (e Entry) link() string {
+	if e.URL == "" {
+		return "(" + e.ShortHash + ")"
+	}
+	return fmt.Sprintf("([%s](%s))", e.ShortHash, e.URL)
+}
+
+// isKnownType reports whether the commit type has its own section
+func isKnownType(commitType string) bool {
+	for _, s := range sections {
+		if s.Type == commitType {
+			return true
+		}
+	}
+	return false
+}
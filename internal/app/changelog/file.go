@@ -0,0 +1,343 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"cmt/internal/app/errors"
+)
+
+// DefaultFile is the changelog written by --write without a path
+const DefaultFile = "CHANGELOG.md"
+
+// keepAChangelogHeader opens a new changelog in the Keep a Changelog format
+const keepAChangelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).`
+
+// versionHeading matches a release heading such as "## [1.2.0] - 2024-06-01" or "## v1.2.0"
+var versionHeading = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?`)
+
+// WriteOptions controls how a generated section is merged into a changelog file
+type WriteOptions struct {
+	// KeepAChangelog keeps an Unreleased section on top and dates releases;
+	// it is enabled automatically when the file already has an Unreleased section
+	KeepAChangelog bool
+	// Date is the release date written next to versions in Keep a Changelog mode
+	Date time.Time
+}
+
+// document is a changelog split into its header and version sections
+type document struct {
+	header   string
+	sections []docSection
+}
+
+// docSection is a single version heading with its entries
+type docSection struct {
+	version string
+	text    string
+}
+
+// Update inserts the version sections of a generated changelog at the top of the
+// existing file content, preserving its header and older entries. A regenerated
+// Unreleased section replaces the previous one, a released version takes over the
+// entries written by hand under Unreleased, while a version already present in
+// the file is rejected
+func Update(existing, generated string, opts WriteOptions) (string, error) {
+	gen := parseDocument(generated)
+	if len(gen.sections) == 0 {
+		return "", errors.ErrNoChangelogSection
+	}
+
+	doc := parseDocument(existing)
+	keepAChangelog := opts.KeepAChangelog || doc.index(Unreleased) >= 0
+
+	if strings.TrimSpace(existing) == "" {
+		doc.header = gen.header
+		if keepAChangelog {
+			doc.header = keepAChangelogHeader
+		}
+	}
+
+	for i := len(gen.sections) - 1; i >= 0; i-- {
+		s := gen.sections[i]
+
+		if s.version == Unreleased {
+			if idx := doc.index(Unreleased); idx >= 0 {
+				doc.sections[idx] = s
+				continue
+			}
+			doc.sections = append([]docSection{s}, doc.sections...)
+			continue
+		}
+
+		if doc.index(s.version) >= 0 {
+			return "", fmt.Errorf("%w: %s", errors.ErrChangelogSectionExists, s.version)
+		}
+
+		if keepAChangelog {
+			s = s.dated(opts.Date)
+		}
+
+		idx := doc.index(Unreleased)
+		if idx < 0 {
+			doc.sections = append([]docSection{s}, doc.sections...)
+			continue
+		}
+
+		// The released entries leave the Unreleased section, which stays on top empty
+		s = s.merge(doc.sections[idx])
+		doc.sections[idx] = docSection{version: Unreleased, text: "## [" + Unreleased + "]"}
+		doc.sections = append(doc.sections[:idx+1], append([]docSection{s}, doc.sections[idx+1:]...)...)
+	}
+
+	if keepAChangelog && doc.index(Unreleased) < 0 {
+		doc.sections = append([]docSection{{version: Unreleased, text: "## [" + Unreleased + "]"}}, doc.sections...)
+	}
+
+	return doc.String(), nil
+}
+
+// Combine joins generated changelogs, each with its own version sections, into
+// one document under the header of the first
+func Combine(docs []string) string {
+	if len(docs) == 1 {
+		return docs[0]
+	}
+
+	var combined document
+	for i, content := range docs {
+		doc := parseDocument(content)
+		if i == 0 {
+			combined.header = doc.header
+		}
+		combined.sections = append(combined.sections, doc.sections...)
+	}
+
+	return strings.TrimRight(combined.String(), "\n")
+}
+
+// Sections returns the version sections of a generated changelog without its header
+func Sections(content string) string {
+	doc := parseDocument(content)
+	doc.header = ""
+	return strings.TrimRight(doc.String(), "\n")
+}
+
+// SetVersion replaces the version of the first section heading of a generated changelog
+func SetVersion(content, version string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			lines[i] = "## [" + version + "]"
+			return strings.Join(lines, "\n")
+		}
+	}
+	return content
+}
+
+// SetTitle replaces the top level heading of a generated changelog, adding one when missing
+func SetTitle(content, title string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			break
+		}
+		if strings.HasPrefix(line, "# ") {
+			lines[i] = "# " + title
+			return strings.Join(lines, "\n")
+		}
+	}
+	return "# " + title + "\n\n" + content
+}
+
+// parseDocument splits changelog content at its second level headings
+func parseDocument(content string) document {
+	var doc document
+	var header []string
+	var current []string
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		text := strings.TrimSpace(strings.Join(current, "\n"))
+		doc.sections = append(doc.sections, docSection{version: sectionVersion(current[0]), text: text})
+		current = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+			current = []string{line}
+			continue
+		}
+
+		if current != nil {
+			current = append(current, line)
+			continue
+		}
+		header = append(header, line)
+	}
+	flush()
+
+	doc.header = strings.TrimSpace(strings.Join(header, "\n"))
+	return doc
+}
+
+// sectionVersion returns the version of a heading without its v prefix
+func sectionVersion(heading string) string {
+	m := versionHeading.FindStringSubmatch(heading)
+	if m == nil {
+		return ""
+	}
+
+	if strings.EqualFold(m[1], Unreleased) {
+		return Unreleased
+	}
+	return strings.TrimPrefix(m[1], "v")
+}
+
+// index returns the position of the section with the given version, or -1
+func (d document) index(version string) int {
+	for i, s := range d.sections {
+		if s.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// String joins the header and sections back into Markdown
+func (d document) String() string {
+	parts := make([]string, 0, len(d.sections)+1)
+	if d.header != "" {
+		parts = append(parts, d.header)
+	}
+	for _, s := range d.sections {
+		parts = append(parts, s.text)
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// merge adds the entries of another section missing from this one, under the
+// subheading of the same name or in a new one at the end
+func (s docSection) merge(other docSection) docSection {
+	heading, body, _ := strings.Cut(s.text, "\n")
+	groups := parseGroups(body)
+
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		for _, entry := range g.entries {
+			seen[entry] = true
+		}
+	}
+
+	merged := false
+	_, otherBody, _ := strings.Cut(other.text, "\n")
+	for _, g := range parseGroups(otherBody) {
+		var missing []string
+		for _, entry := range g.entries {
+			if !seen[entry] {
+				missing = append(missing, entry)
+				seen[entry] = true
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		idx := slices.IndexFunc(groups, func(e entryGroup) bool { return strings.EqualFold(e.heading, g.heading) })
+		if idx < 0 {
+			groups = append(groups, entryGroup{heading: g.heading})
+			idx = len(groups) - 1
+		}
+		groups[idx].entries = append(groups[idx].entries, missing...)
+		merged = true
+	}
+	if !merged {
+		return s
+	}
+
+	parts := []string{heading}
+	for _, g := range groups {
+		if g.heading != "" {
+			parts = append(parts, g.heading)
+		}
+		if len(g.entries) > 0 {
+			parts = append(parts, strings.Join(g.entries, "\n"))
+		}
+	}
+
+	s.text = strings.Join(parts, "\n\n")
+	return s
+}
+
+// entryGroup is the list of entries under a third level heading of a section
+type entryGroup struct {
+	heading string
+	entries []string
+}
+
+// parseGroups splits a section body into its subheadings and entries, where an
+// entry is a list item with its continuation lines or a paragraph
+func parseGroups(body string) []entryGroup {
+	groups := []entryGroup{{}}
+	var entry []string
+
+	flush := func() {
+		if len(entry) > 0 {
+			last := &groups[len(groups)-1]
+			last.entries = append(last.entries, strings.Join(entry, "\n"))
+			entry = nil
+		}
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(line, "### "):
+			flush()
+			groups = append(groups, entryGroup{heading: strings.TrimRight(line, " ")})
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			flush()
+			entry = []string{strings.TrimRight(line, " ")}
+		default:
+			entry = append(entry, strings.TrimRight(line, " "))
+		}
+	}
+	flush()
+
+	if len(groups[0].entries) == 0 {
+		groups = groups[1:]
+	}
+	return groups
+}
+
+// dated appends the release date to the heading unless it already has one
+func (s docSection) dated(date time.Time) docSection {
+	if date.IsZero() {
+		return s
+	}
+
+	heading, rest, _ := strings.Cut(s.text, "\n")
+	if strings.Contains(heading, " - ") {
+		return s
+	}
+
+	s.text = strings.TrimRight(heading, " ") + " - " + date.Format(time.DateOnly)
+	if rest != "" {
+		s.text += "\n" + rest
+	}
+	return s
+}
@@ -0,0 +1,162 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_Update(t *testing.T) {
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		opts      WriteOptions
+		expected  string
+		err       error
+	}{
+		{
+			name:      "Success with new file",
+			existing:  "",
+			generated: "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login",
+			opts:      WriteOptions{Date: date},
+			expected:  "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login\n",
+		},
+		{
+			name:      "Success with older entries and header",
+			existing:  "# Changelog\n\nNotes about this project.\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login\n",
+			generated: "# CHANGELOG\n\n## [1.1.0]\n\n### Fixes\n\n- **fix:** Handle nil",
+			opts:      WriteOptions{Date: date},
+			expected: "# Changelog\n\nNotes about this project.\n\n" +
+				"## [1.1.0]\n\n### Fixes\n\n- **fix:** Handle nil\n\n" +
+				"## [1.0.0]\n\n### Features\n\n- **feat:** Add login\n",
+		},
+		{
+			name:      "Success with new keep a changelog file",
+			existing:  "",
+			generated: "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login",
+			opts:      WriteOptions{KeepAChangelog: true, Date: date},
+			expected: keepAChangelogHeader + "\n\n" +
+				"## [Unreleased]\n\n" +
+				"## [1.0.0] - 2024-06-01\n\n### Features\n\n- **feat:** Add login\n",
+		},
+		{
+			name: "Success with release below existing unreleased section",
+			existing: "# Changelog\n\n## [Unreleased]\n\n- **feat:** Add login\n\n" +
+				"## [0.9.0] - 2024-01-01\n\n- **fix:** Old fix\n",
+			generated: "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login",
+			opts:      WriteOptions{Date: date},
+			expected: "# Changelog\n\n## [Unreleased]\n\n" +
+				"## [1.0.0] - 2024-06-01\n\n### Features\n\n- **feat:** Add login\n\n" +
+				"## [0.9.0] - 2024-01-01\n\n- **fix:** Old fix\n",
+		},
+		{
+			name: "Success with release keeping hand-written unreleased entries",
+			existing: "# Changelog\n\n## [Unreleased]\n\n### Features\n\n- **feat:** Add login\n- Support SSO\n  through SAML\n\n" +
+				"### Security\n\n- Rotate the signing keys\n\n" +
+				"## [0.9.0] - 2024-01-01\n\n- **fix:** Old fix\n",
+			generated: "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add login\n\n### Fixes\n\n- **fix:** Handle nil",
+			opts:      WriteOptions{Date: date},
+			expected: "# Changelog\n\n## [Unreleased]\n\n" +
+				"## [1.0.0] - 2024-06-01\n\n### Features\n\n- **feat:** Add login\n- Support SSO\n  through SAML\n\n" +
+				"### Fixes\n\n- **fix:** Handle nil\n\n### Security\n\n- Rotate the signing keys\n\n" +
+				"## [0.9.0] - 2024-01-01\n\n- **fix:** Old fix\n",
+		},
+		{
+			name:      "Success with regenerated unreleased section",
+			existing:  "# Changelog\n\n## [Unreleased]\n\n- **feat:** Old entry\n\n## [0.9.0]\n\n- **fix:** Old fix\n",
+			generated: "# CHANGELOG\n\n## [Unreleased]\n\n### Features\n\n- **feat:** New entry",
+			opts:      WriteOptions{Date: date},
+			expected: "# Changelog\n\n## [Unreleased]\n\n### Features\n\n- **feat:** New entry\n\n" +
+				"## [0.9.0]\n\n- **fix:** Old fix\n",
+		},
+		{
+			name:      "Failure with existing version",
+			existing:  "# Changelog\n\n## [v1.0.0] - 2024-01-01\n\n- **feat:** Add login\n",
+			generated: "# CHANGELOG\n\n## [1.0.0]\n\n- **feat:** Add login",
+			opts:      WriteOptions{Date: date},
+			err:       errors.ErrChangelogSectionExists,
+		},
+		{
+			name:      "Failure without version section",
+			existing:  "",
+			generated: "Nothing to report",
+			opts:      WriteOptions{Date: date},
+			err:       errors.ErrNoChangelogSection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Update(tt.existing, tt.generated, tt.opts)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Combine(t *testing.T) {
+	tests := []struct {
+		name     string
+		docs     []string
+		expected string
+	}{
+		{
+			name:     "Success with single document",
+			docs:     []string{"# CHANGELOG\n\n## [1.0.0]\n\n- **feat:** Add login"},
+			expected: "# CHANGELOG\n\n## [1.0.0]\n\n- **feat:** Add login",
+		},
+		{
+			name: "Success with several documents",
+			docs: []string{
+				"# CHANGELOG\n\n## [1.1.0]\n\n- **fix:** Handle nil",
+				"# CHANGELOG\n\n## [1.0.0]\n\n- **feat:** Add login",
+			},
+			expected: "# CHANGELOG\n\n## [1.1.0]\n\n- **fix:** Handle nil\n\n## [1.0.0]\n\n- **feat:** Add login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Combine(tt.docs))
+		})
+	}
+}
+
+func Test_SetVersion(t *testing.T) {
+	assert.Equal(t,
+		"# CHANGELOG\n\n## [1.1.0]\n\n- **fix:** Handle nil",
+		SetVersion("# CHANGELOG\n\n## [X.Y.Z]\n\n- **fix:** Handle nil", "1.1.0"),
+	)
+	assert.Equal(t, "No sections", SetVersion("No sections", "1.1.0"))
+}
+
+func Test_SetTitle(t *testing.T) {
+	assert.Equal(t,
+		"# api\n\n## [1.1.0]\n\n- **fix:** Handle nil",
+		SetTitle("# CHANGELOG\n\n## [1.1.0]\n\n- **fix:** Handle nil", "api"),
+	)
+	assert.Equal(t,
+		"# api\n\n## [1.1.0]\n\n# Not a title",
+		SetTitle("## [1.1.0]\n\n# Not a title", "api"),
+	)
+}
+
+func Test_Sections(t *testing.T) {
+	assert.Equal(t,
+		"## [1.1.0]\n\n- **fix:** Handle nil\n\n## [1.0.0]\n\n- **feat:** Add login",
+		Sections("# CHANGELOG\n\n## [1.1.0]\n\n- **fix:** Handle nil\n\n## [1.0.0]\n\n- **feat:** Add login"),
+	)
+	assert.Equal(t, "", Sections("# CHANGELOG"))
+}
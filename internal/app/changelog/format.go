@@ -0,0 +1,162 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"cmt/internal/app/errors"
+)
+
+// Output formats of the changelog
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatHTML     = "html"
+	FormatAsciiDoc = "asciidoc"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatMarkdown, FormatJSON, FormatYAML, FormatHTML, FormatAsciiDoc}
+
+// Document is the stable schema of the JSON and YAML output
+type Document struct {
+	Releases []Release `json:"releases" yaml:"releases"`
+}
+
+// Format renders releases, newest first, in the given output format
+func Format(releases []Release, format string) (string, error) {
+	if releases == nil {
+		releases = []Release{}
+	}
+
+	switch strings.ToLower(format) {
+	case "", FormatMarkdown, "md":
+		return Markdown(releases), nil
+	case FormatJSON:
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(Document{Releases: releases}); err != nil {
+			return "", err
+		}
+		return strings.TrimRight(b.String(), "\n"), nil
+	case FormatYAML, "yml":
+		out, err := yaml.Marshal(Document{Releases: releases})
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(out), "\n"), nil
+	case FormatHTML:
+		return HTML(releases), nil
+	case FormatAsciiDoc, "adoc":
+		return AsciiDoc(releases), nil
+	default:
+		return "", fmt.Errorf("%w: %s (use %s)", errors.ErrInvalidChangelogFormat, format, strings.Join(Formats, ", "))
+	}
+}
+
+// IsStructured reports whether the format is built from the commits rather than the model output
+func IsStructured(format string) bool {
+	switch strings.ToLower(format) {
+	case "", FormatMarkdown, "md":
+		return false
+	default:
+		return true
+	}
+}
+
+// HTML renders releases as an HTML fragment
+func HTML(releases []Release) string {
+	var b strings.Builder
+	b.WriteString("<h1>Changelog</h1>\n")
+
+	for _, r := range releases {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(r.title()))
+
+		for _, s := range r.Sections {
+			fmt.Fprintf(&b, "<h3>%s</h3>\n<ul>\n", html.EscapeString(s.Title))
+			for _, e := range s.Entries {
+				b.WriteString("  <li>")
+				if label := e.label(); label != "" {
+					fmt.Fprintf(&b, "<strong>%s:</strong> ", html.EscapeString(label))
+				}
+				fmt.Fprintf(&b, "%s %s</li>\n", html.EscapeString(e.Description), e.htmlLink())
+			}
+			b.WriteString("</ul>\n")
+		}
+
+		if len(r.Breaking) > 0 {
+			b.WriteString("<h3>Breaking Changes</h3>\n<ul>\n")
+			for _, e := range r.Breaking {
+				fmt.Fprintf(&b, "  <li>%s %s</li>\n", html.EscapeString(e.note()), e.htmlLink())
+			}
+			b.WriteString("</ul>\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// AsciiDoc renders releases as an AsciiDoc document
+func AsciiDoc(releases []Release) string {
+	var b strings.Builder
+	b.WriteString("= Changelog\n")
+
+	for _, r := range releases {
+		fmt.Fprintf(&b, "\n== %s\n", r.title())
+
+		for _, s := range r.Sections {
+			fmt.Fprintf(&b, "\n=== %s\n\n", s.Title)
+			for _, e := range s.Entries {
+				b.WriteString("* ")
+				if label := e.label(); label != "" {
+					fmt.Fprintf(&b, "*%s:* ", label)
+				}
+				fmt.Fprintf(&b, "%s %s\n", e.Description, e.asciiDocLink())
+			}
+		}
+
+		if len(r.Breaking) > 0 {
+			b.WriteString("\n=== Breaking Changes\n\n")
+			for _, e := range r.Breaking {
+				fmt.Fprintf(&b, "* %s %s\n", e.note(), e.asciiDocLink())
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// title returns the version with its package and date, if known
+func (r Release) title() string {
+	title := r.Version
+	if r.Package != "" {
+		title = r.Package + " " + title
+	}
+	if r.Date != "" {
+		title += " (" + r.Date + ")"
+	}
+	return title
+}
+
+// htmlLink formats the short hash as an HTML link when the commit URL is known
+func (e Entry) htmlLink() string {
+	if e.URL == "" {
+		return "(<code>" + html.EscapeString(e.ShortHash) + "</code>)"
+	}
+	return fmt.Sprintf(`(<a href="%s"><code>%s</code></a>)`, html.EscapeString(e.URL), html.EscapeString(e.ShortHash))
+}
+
+// asciiDocLink formats the short hash as an AsciiDoc link when the commit URL is known
+func (e Entry) asciiDocLink() string {
+	if e.URL == "" {
+		return "(`" + e.ShortHash + "`)"
+	}
+	return fmt.Sprintf("(%s[`%s`])", e.URL, e.ShortHash)
+}
@@ -0,0 +1,155 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_Format(t *testing.T) {
+	releases := []Release{
+		{
+			Version: "1.2.0",
+			Date:    "2024-06-01",
+			Sections: []Section{
+				{
+					Type:  "feat",
+					Title: "Features",
+					Entries: []Entry{
+						{Hash: "aaaaaaa1111", ShortHash: "aaaaaaa", Type: "feat", Scope: "api", Description: "Add <b>endpoint</b>", Breaking: true, URL: "https://github.com/tab/cmt/commit/aaaaaaa1111"},
+					},
+				},
+				{
+					Type:    "other",
+					Title:   "Other",
+					Entries: []Entry{{Hash: "bbbbbbb2222", ShortHash: "bbbbbbb", Description: "Update readme"}},
+				},
+			},
+			Breaking: []Entry{
+				{Hash: "aaaaaaa1111", ShortHash: "aaaaaaa", Type: "feat", Scope: "api", Description: "Add <b>endpoint</b>", Breaking: true, BreakingNote: "v1 is gone", URL: "https://github.com/tab/cmt/commit/aaaaaaa1111"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+		err      error
+	}{
+		{
+			name:   "Success with markdown",
+			format: "markdown",
+			expected: "# CHANGELOG\n\n## [1.2.0]\n\n" +
+				"### Features\n\n- **feat(api):** Add <b>endpoint</b> ([aaaaaaa](https://github.com/tab/cmt/commit/aaaaaaa1111))\n\n" +
+				"### Other\n\n- Update readme (bbbbbbb)\n\n" +
+				"### Breaking Changes\n\n- **BREAKING CHANGES:** v1 is gone ([aaaaaaa](https://github.com/tab/cmt/commit/aaaaaaa1111))",
+		},
+		{
+			name:   "Success with json",
+			format: "json",
+			expected: `{
+  "releases": [
+    {
+      "version": "1.2.0",
+      "date": "2024-06-01",
+      "sections": [
+        {
+          "type": "feat",
+          "title": "Features",
+          "entries": [
+            {
+              "hash": "aaaaaaa1111",
+              "short_hash": "aaaaaaa",
+              "type": "feat",
+              "scope": "api",
+              "description": "Add <b>endpoint</b>",
+              "breaking": true,
+              "url": "https://github.com/tab/cmt/commit/aaaaaaa1111"
+            }
+          ]
+        },
+        {
+          "type": "other",
+          "title": "Other",
+          "entries": [
+            {
+              "hash": "bbbbbbb2222",
+              "short_hash": "bbbbbbb",
+              "description": "Update readme",
+              "breaking": false
+            }
+          ]
+        }
+      ],
+      "breaking": [
+        {
+          "hash": "aaaaaaa1111",
+          "short_hash": "aaaaaaa",
+          "type": "feat",
+          "scope": "api",
+          "description": "Add <b>endpoint</b>",
+          "breaking": true,
+          "breaking_note": "v1 is gone",
+          "url": "https://github.com/tab/cmt/commit/aaaaaaa1111"
+        }
+      ]
+    }
+  ]
+}`,
+		},
+		{
+			name:   "Success with html",
+			format: "html",
+			expected: "<h1>Changelog</h1>\n<h2>1.2.0 (2024-06-01)</h2>\n" +
+				"<h3>Features</h3>\n<ul>\n" +
+				"  <li><strong>feat(api):</strong> Add &lt;b&gt;endpoint&lt;/b&gt; (<a href=\"https://github.com/tab/cmt/commit/aaaaaaa1111\"><code>aaaaaaa</code></a>)</li>\n</ul>\n" +
+				"<h3>Other</h3>\n<ul>\n  <li>Update readme (<code>bbbbbbb</code>)</li>\n</ul>\n" +
+				"<h3>Breaking Changes</h3>\n<ul>\n" +
+				"  <li>v1 is gone (<a href=\"https://github.com/tab/cmt/commit/aaaaaaa1111\"><code>aaaaaaa</code></a>)</li>\n</ul>",
+		},
+		{
+			name:   "Success with asciidoc",
+			format: "asciidoc",
+			expected: "= Changelog\n\n== 1.2.0 (2024-06-01)\n\n" +
+				"=== Features\n\n* *feat(api):* Add <b>endpoint</b> (https://github.com/tab/cmt/commit/aaaaaaa1111[`aaaaaaa`])\n\n" +
+				"=== Other\n\n* Update readme (`bbbbbbb`)\n\n" +
+				"=== Breaking Changes\n\n* v1 is gone (https://github.com/tab/cmt/commit/aaaaaaa1111[`aaaaaaa`])",
+		},
+		{
+			name:   "Failure with unknown format",
+			format: "pdf",
+			err:    errors.ErrInvalidChangelogFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Format(releases, tt.format)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Format_YAML(t *testing.T) {
+	result, err := Format([]Release{{Version: "Unreleased", Sections: []Section{}, Breaking: []Entry{}}}, "yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "releases:\n    - version: Unreleased\n      sections: []\n      breaking: []", result)
+}
+
+func Test_Format_Empty(t *testing.T) {
+	result, err := Format(nil, "json")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"releases\": []\n}", result)
+}
@@ -3,36 +3,102 @@ package commands
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"cmt/internal/app/changelog"
 	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/app/semver"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
 // changelogCmd handles changelog generation
 type changelogCmd struct {
+	cfg       *config.Config
 	gitClient git.Client
 	gptClient gpt.Client
 	log       logger.Logger
+	root      func() string
+	now       func() time.Time
 }
 
 // NewChangelogCommand creates a new changelog command
 func NewChangelogCommand(
+	cfg *config.Config,
 	gitClient git.Client,
 	gptClient gpt.Client,
 	log logger.Logger,
 ) Command {
 	return &changelogCmd{
+		cfg:       cfg,
 		gitClient: gitClient,
 		gptClient: gptClient,
 		log:       log,
+		root:      config.RepoRoot,
+		now:       time.Now,
 	}
 }
 
+// releaseRange is a changelog section and the git log options selecting its commits
+type releaseRange struct {
+	version string
+	opts    []string
+}
+
+// rangeFlags are the options choosing which commits the changelog covers
+type rangeFlags struct {
+	args     []string
+	from     string
+	to       string
+	sinceTag string
+	allTags  bool
+	// tagPrefix selects the release tags of a package
+	tagPrefix string
+}
+
 // Run executes the changelog command
 func (c *changelogCmd) Run(ctx context.Context, args []string) int {
+	offline, args := hasFlag(args, "--offline")
+	keepAChangelog, args := hasFlag(args, "--keep-a-changelog")
+	allTags, args := hasFlag(args, "--all-tags")
+	allPackages, args := hasFlag(args, "--all-packages")
+	path, write, args := writeFlag(args)
+	sinceTag, args := flagValue(args, "--since-tag")
+	from, args := flagValue(args, "--from")
+	to, args := flagValue(args, "--to")
+	format, args := flagValue(args, "--format")
+	pkgName, args := flagValue(args, "--package")
+
+	if _, err := changelog.Format(nil, format); err != nil {
+		fmt.Println(errors.Format(err))
+		return 1
+	}
+
+	// Structured formats are built from the commits, Markdown is written by the model unless offline
+	structured := changelog.IsStructured(format)
+	if structured && write {
+		fmt.Println(errors.Format(fmt.Errorf("%w: --write supports Markdown only", errors.ErrInvalidChangelogFormat)))
+		return 1
+	}
+	deterministic := offline || structured
+
+	packages, err := c.packages(pkgName, allPackages)
+	if err != nil {
+		fmt.Println(errors.Format(err))
+		return 1
+	}
+	if write && path != "" && len(packages) > 1 {
+		fmt.Println(errors.Format(fmt.Errorf("%w: --write=%s needs a single package", errors.ErrFailedToWriteChangelog, path)))
+		return 1
+	}
+
 	var rangeOpts []string
 	for _, arg := range args {
 		value := strings.TrimSpace(arg)
@@ -44,40 +110,365 @@ func (c *changelogCmd) Run(ctx context.Context, args []string) int {
 	c.log.Info().
 		Str("command", "changelog").
 		Str("range", strings.Join(rangeOpts, " ")).
+		Str("package", pkgName).
+		Bool("all_packages", allPackages).
+		Bool("offline", offline).
+		Bool("write", write).
+		Str("format", format).
 		Msg("Starting changelog generation")
 
+	// The spinner writes to stdout, keep it out of output meant for other programs
 	spin := spinner.New("Fetching git history…")
-	spin.Start()
+	if !structured {
+		spin.Start()
+	}
 
-	commits, err := c.gitClient.Log(ctx, rangeOpts)
-	if err != nil {
-		spin.Stop()
-		c.log.Error().
-			Str("command", "changelog").
-			Err(err).
-			Msg("Failed to fetch git log")
-		return 1
+	var url string
+	if deterministic {
+		url = c.repositoryURL(ctx)
 	}
 
-	spin.SetMessage("Loading…")
+	flags := rangeFlags{args: rangeOpts, from: from, to: to, sinceTag: sinceTag, allTags: allTags}
 
-	result, err := c.gptClient.FetchChangelog(ctx, commits)
-	if err != nil {
-		spin.Stop()
-		c.log.Error().
-			Str("command", "changelog").
-			Err(err).
-			Msg("Failed to generate changelog")
-		return 1
+	var docs []string
+	var built []changelog.Release
+	var generated []config.Package
+	for _, pkg := range packages {
+		flags.tagPrefix = pkg.TagPrefix()
+
+		doc, releases, err := c.generate(ctx, pkg, flags, deterministic, url, spin)
+		if errors.Is(err, errors.ErrNoGitCommits) && len(packages) > 1 {
+			c.log.Debug().Str("package", pkg.Name).Msg("No changes in package")
+			continue
+		}
+		if err != nil {
+			spin.Stop()
+			c.log.Error().
+				Str("command", "changelog").
+				Str("package", pkg.Name).
+				Err(err).
+				Msg("Failed to generate changelog")
+			return 1
+		}
+
+		if deterministic {
+			doc = changelog.Markdown(releases)
+		}
+
+		docs = append(docs, doc)
+		built = append(built, releases...)
+		generated = append(generated, pkg)
 	}
 
 	spin.Stop()
 
+	if len(generated) == 0 {
+		fmt.Println(errors.Format(errors.ErrNoGitCommits))
+		return 1
+	}
+
+	result := c.join(generated, docs)
+	if structured {
+		if result, err = changelog.Format(built, format); err != nil {
+			c.log.Error().
+				Str("command", "changelog").
+				Err(err).
+				Msg("Failed to format changelog")
+			return 1
+		}
+	}
+
 	c.log.Info().
 		Str("command", "changelog").
+		Int("packages", len(generated)).
 		Int("lines", len(strings.Split(result, "\n"))).
 		Msg("Changelog generated successfully")
 
+	if write {
+		for i, pkg := range generated {
+			target := path
+			if target == "" && pkg.Name != "" {
+				target = filepath.Join(c.root(), filepath.FromSlash(pkg.ChangelogFile()))
+			}
+			if code := c.write(target, docs[i], keepAChangelog); code != 0 {
+				return code
+			}
+		}
+		return 0
+	}
+
 	fmt.Println(result)
 	return 0
 }
+
+// generate builds the changelog of a package, or of the whole repository for
+// the zero package. Deterministic changelogs are returned as releases, the
+// model output as a Markdown document
+func (c *changelogCmd) generate(
+	ctx context.Context,
+	pkg config.Package,
+	flags rangeFlags,
+	deterministic bool,
+	url string,
+	spin *spinner.Spinner,
+) (string, []changelog.Release, error) {
+	releases := c.releases(ctx, flags)
+
+	var docs []string
+	var built []changelog.Release
+	for _, r := range releases {
+		opts := r.opts
+		if specs := pkg.Pathspecs(); len(specs) > 0 {
+			opts = append(append(slices.Clone(opts), "--"), specs...)
+		}
+
+		commits, err := c.gitClient.Log(ctx, opts)
+		if errors.Is(err, errors.ErrNoGitCommits) && len(releases) > 1 {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		if deterministic {
+			built = append(built, changelog.Build(commits, changelog.Options{
+				Version:   r.version,
+				URL:       url,
+				Package:   pkg.Name,
+				TagPrefix: pkg.TagPrefix(),
+			}))
+			continue
+		}
+
+		spin.SetMessage("Loading…")
+
+		result, err := c.gptClient.FetchChangelog(ctx, git.FormatLog(commits))
+		if err != nil {
+			return "", nil, err
+		}
+
+		// Like the deterministic changelog, a range without a release is titled
+		// after the tag of its newest commit, so --write never sees a placeholder
+		version := r.version
+		if version == "" {
+			version = changelog.Version(commits, pkg.TagPrefix())
+		}
+		docs = append(docs, changelog.SetVersion(result, version))
+	}
+
+	if deterministic {
+		return "", built, nil
+	}
+	return changelog.Combine(docs), nil, nil
+}
+
+// packages resolves the package flags into the packages to generate a
+// changelog for, a single zero package standing for the whole repository
+func (c *changelogCmd) packages(name string, all bool) ([]config.Package, error) {
+	switch {
+	case all:
+		if len(c.cfg.Packages) == 0 {
+			return nil, fmt.Errorf("%w: no packages defined in %s", errors.ErrUnknownPackage, config.ConfigFileName)
+		}
+		return c.cfg.Packages, nil
+	case name != "":
+		pkg, ok := c.cfg.Package(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not defined in %s", errors.ErrUnknownPackage, name, config.ConfigFileName)
+		}
+		return []config.Package{pkg}, nil
+	default:
+		return []config.Package{{}}, nil
+	}
+}
+
+// join combines the changelogs of several packages for the console, titling each with its package
+func (c *changelogCmd) join(packages []config.Package, docs []string) string {
+	if len(docs) == 1 {
+		return docs[0]
+	}
+
+	titled := make([]string, 0, len(docs))
+	for i, doc := range docs {
+		titled = append(titled, changelog.SetTitle(doc, packages[i].Name))
+	}
+	return strings.Join(titled, "\n\n")
+}
+
+// releases resolves the range flags into the sections to generate, newest first.
+// Without an explicit range the commits since the latest semver tag are used
+func (c *changelogCmd) releases(ctx context.Context, flags rangeFlags) []releaseRange {
+	switch {
+	case flags.allTags:
+		return c.tagReleases(ctx, flags.tagPrefix)
+	case flags.from != "" || flags.to != "":
+		to := flags.to
+		if to == "" {
+			to = "HEAD"
+		}
+		if flags.from == "" {
+			return []releaseRange{{opts: []string{to}}}
+		}
+		return []releaseRange{{opts: []string{flags.from + ".." + to}}}
+	case len(flags.args) > 0:
+		return []releaseRange{{opts: flags.args}}
+	}
+
+	tag := flags.sinceTag
+	if tag == "" {
+		tag = c.latestTag(ctx, flags.tagPrefix)
+	}
+	if tag == "" {
+		return []releaseRange{{}}
+	}
+
+	c.log.Debug().Str("tag", tag).Msg("Generating changelog since tag")
+	return []releaseRange{{opts: []string{tag + "..HEAD"}}}
+}
+
+// tagReleases returns a release for every semver tag carrying the prefix plus the unreleased commits
+func (c *changelogCmd) tagReleases(ctx context.Context, prefix string) []releaseRange {
+	sorted := c.releaseTags(ctx, prefix)
+	if len(sorted) == 0 {
+		return []releaseRange{{}}
+	}
+
+	releases := []releaseRange{{version: changelog.Unreleased, opts: []string{prefix + sorted[len(sorted)-1] + "..HEAD"}}}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		opts := []string{prefix + sorted[i]}
+		if i > 0 {
+			opts = []string{prefix + sorted[i-1] + ".." + prefix + sorted[i]}
+		}
+		releases = append(releases, releaseRange{version: strings.TrimPrefix(sorted[i], "v"), opts: opts})
+	}
+
+	return releases
+}
+
+// latestTag returns the highest semver tag carrying the prefix reachable from HEAD, or an empty string
+func (c *changelogCmd) latestTag(ctx context.Context, prefix string) string {
+	sorted := c.releaseTags(ctx, prefix)
+	if len(sorted) == 0 {
+		return ""
+	}
+	return prefix + sorted[len(sorted)-1]
+}
+
+// releaseTags returns the versions of the tags carrying the prefix, such as
+// v1.2.0 for api/v1.2.0, in ascending semver order
+func (c *changelogCmd) releaseTags(ctx context.Context, prefix string) []string {
+	tags, err := c.gitClient.Tags(ctx)
+	if err != nil {
+		c.log.Debug().Err(err).Msg("Failed to read tags")
+		return nil
+	}
+
+	var versions []string
+	for _, tag := range tags {
+		if version, ok := strings.CutPrefix(tag, prefix); ok {
+			versions = append(versions, version)
+		}
+	}
+
+	return semver.Sort(versions)
+}
+
+// write merges the generated section into the changelog file
+func (c *changelogCmd) write(path, generated string, keepAChangelog bool) int {
+	if path == "" {
+		path = filepath.Join(c.root(), changelog.DefaultFile)
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		c.log.Error().Err(err).Str("path", path).Msg("Failed to read changelog")
+		fmt.Println(errors.Format(fmt.Errorf("%w: %s", errors.ErrFailedToWriteChangelog, path)))
+		return 1
+	}
+
+	content, err := changelog.Update(string(existing), generated, changelog.WriteOptions{
+		KeepAChangelog: keepAChangelog,
+		Date:           c.now(),
+	})
+	if err != nil {
+		c.log.Error().Err(err).Str("path", path).Msg("Failed to update changelog")
+		fmt.Println(errors.Format(err))
+		return 1
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		c.log.Error().Err(err).Str("path", path).Msg("Failed to write changelog")
+		fmt.Println(errors.Format(fmt.Errorf("%w: %s", errors.ErrFailedToWriteChangelog, path)))
+		return 1
+	}
+
+	fmt.Printf("✅ Changelog written to %s\n", path)
+	return 0
+}
+
+// writeFlag extracts --write with its optional path, given as --write=PATH or
+// as the next argument when it names a Markdown file
+func writeFlag(args []string) (string, bool, []string) {
+	found := false
+	path := ""
+	remaining := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if value, ok := strings.CutPrefix(arg, "--write="); ok {
+			found, path = true, value
+			continue
+		}
+		if arg != "--write" {
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		found = true
+		if i+1 < len(args) && isMarkdownFile(args[i+1]) {
+			path = args[i+1]
+			i++
+		}
+	}
+
+	return path, found, remaining
+}
+
+// flagValue extracts a flag given as --name=VALUE or --name VALUE
+func flagValue(args []string, name string) (string, []string) {
+	value := ""
+	remaining := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		if v, ok := strings.CutPrefix(args[i], name+"="); ok {
+			value = v
+			continue
+		}
+		if args[i] == name && i+1 < len(args) {
+			value = args[i+1]
+			i++
+			continue
+		}
+		remaining = append(remaining, args[i])
+	}
+
+	return value, remaining
+}
+
+// isMarkdownFile reports whether the argument looks like a Markdown file rather than a revision
+func isMarkdownFile(arg string) bool {
+	ext := strings.ToLower(filepath.Ext(arg))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// repositoryURL returns the web address of the origin remote used to link commits
+func (c *changelogCmd) repositoryURL(ctx context.Context) string {
+	remote, err := c.gitClient.ConfigValue(ctx, "remote.origin.url")
+	if err != nil {
+		c.log.Debug().Err(err).Msg("Failed to read origin remote")
+		return ""
+	}
+
+	return changelog.RepositoryURL(remote)
+}
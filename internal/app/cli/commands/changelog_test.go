@@ -2,15 +2,20 @@ package commands
 
 import (
 	"context"
-	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"cmt/internal/app/errors"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
@@ -22,12 +27,18 @@ func Test_NewChangelogCommand(t *testing.T) {
 	mockGPT := gpt.NewMockClient(ctrl)
 	mockLogger := logger.NewMockLogger(ctrl)
 
-	cmd := NewChangelogCommand(mockGit, mockGPT, mockLogger)
+	cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger)
 	assert.NotNil(t, cmd)
 }
 
 func Test_ChangelogCmd_Run(t *testing.T) {
 	nopLogger := zerolog.Nop()
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	commits := []git.Commit{
+		{Hash: "1111111111", Subject: "feat: Add login", Author: "Jane", Date: date},
+		{Hash: "2222222222", Subject: "fix: Handle nil", Author: "John", Date: date},
+	}
+	formatted := "1111111|feat: Add login|Jane|2024-06-01\n2222222|fix: Handle nil|John|2024-06-01"
 
 	tests := []struct {
 		name           string
@@ -42,11 +53,15 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
 
 				mockGit.EXPECT().
-					Log(gomock.Any(), gomock.Any()).
-					Return("commit1\ncommit2", nil)
+					Tags(gomock.Any()).
+					Return(nil, nil)
+
+				mockGit.EXPECT().
+					Log(gomock.Any(), nil).
+					Return(commits, nil)
 
 				mockGPT.EXPECT().
-					FetchChangelog(gomock.Any(), "commit1\ncommit2").
+					FetchChangelog(gomock.Any(), formatted).
 					Return("# Changelog\n\n- Feature 1\n- Feature 2", nil)
 
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
@@ -61,16 +76,35 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 
 				mockGit.EXPECT().
 					Log(gomock.Any(), gomock.Any()).
-					Return("commit1\ncommit2", nil)
+					Return(commits, nil)
 
 				mockGPT.EXPECT().
-					FetchChangelog(gomock.Any(), "commit1\ncommit2").
+					FetchChangelog(gomock.Any(), formatted).
 					Return("# Changelog\n\n- Feature 1", nil)
 
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
 			},
 			expectedReturn: 0,
 		},
+		{
+			name: "Success with since tag as separate argument",
+			args: []string{"--since-tag", "v1.0.0"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+				mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+
+				mockGit.EXPECT().
+					Log(gomock.Any(), []string{"v1.0.0..HEAD"}).
+					Return(commits, nil)
+
+				mockGPT.EXPECT().
+					FetchChangelog(gomock.Any(), formatted).
+					Return("# Changelog", nil)
+
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+			},
+			expectedReturn: 0,
+		},
 		{
 			name: "Success with multiple args",
 			args: []string{"HEAD~10", "HEAD"},
@@ -79,10 +113,10 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 
 				mockGit.EXPECT().
 					Log(gomock.Any(), gomock.Any()).
-					Return("commits", nil)
+					Return(commits, nil)
 
 				mockGPT.EXPECT().
-					FetchChangelog(gomock.Any(), "commits").
+					FetchChangelog(gomock.Any(), formatted).
 					Return("# Changelog", nil)
 
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
@@ -97,25 +131,108 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 
 				mockGit.EXPECT().
 					Log(gomock.Any(), gomock.Any()).
-					Return("commits", nil)
+					Return(commits, nil)
 
 				mockGPT.EXPECT().
-					FetchChangelog(gomock.Any(), "commits").
+					FetchChangelog(gomock.Any(), formatted).
 					Return("# Changelog", nil)
 
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
 			},
 			expectedReturn: 0,
 		},
+		{
+			name: "Success with offline flag",
+			args: []string{"--offline", "v1.0..v2.0"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+
+				mockGit.EXPECT().
+					Log(gomock.Any(), []string{"v1.0..v2.0"}).
+					Return(commits, nil)
+
+				mockGit.EXPECT().
+					ConfigValue(gomock.Any(), "remote.origin.url").
+					Return("git@github.com:tab/cmt.git", nil)
+
+				mockGPT.EXPECT().FetchChangelog(gomock.Any(), gomock.Any()).Times(0)
+
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+			},
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with offline flag without remote",
+			args: []string{"--offline"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+
+				mockGit.EXPECT().
+					Tags(gomock.Any()).
+					Return([]string{"v1.0.0", "v1.2.0", "v1.10.0-rc.1"}, nil)
+
+				mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).Times(1)
+
+				mockGit.EXPECT().
+					Log(gomock.Any(), []string{"v1.10.0-rc.1..HEAD"}).
+					Return(commits, nil)
+
+				mockGit.EXPECT().
+					ConfigValue(gomock.Any(), "remote.origin.url").
+					Return("", errors.New("git error"))
+
+				mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).Times(1)
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+			},
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with json format",
+			args: []string{"--format", "json", "v1.0..v2.0"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+
+				mockGit.EXPECT().
+					Log(gomock.Any(), []string{"v1.0..v2.0"}).
+					Return(commits, nil)
+
+				mockGit.EXPECT().
+					ConfigValue(gomock.Any(), "remote.origin.url").
+					Return("https://github.com/tab/cmt.git", nil)
+
+				mockGPT.EXPECT().FetchChangelog(gomock.Any(), gomock.Any()).Times(0)
+
+				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
+			},
+			expectedReturn: 0,
+		},
+		{
+			name:           "Failure with unknown format",
+			args:           []string{"--format=pdf"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {},
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with write and structured format",
+			args:           []string{"--format=yaml", "--write"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {},
+			expectedReturn: 1,
+		},
 		{
 			name: "Failure when git log fails",
 			args: []string{},
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
 
+				mockGit.EXPECT().
+					Tags(gomock.Any()).
+					Return(nil, errors.New("git error"))
+
+				mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).Times(1)
+
 				mockGit.EXPECT().
 					Log(gomock.Any(), gomock.Any()).
-					Return("", errors.New("git error"))
+					Return(nil, errors.New("git error"))
 
 				mockLogger.EXPECT().Error().Return(nopLogger.Error()).Times(1)
 			},
@@ -127,12 +244,16 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient, mockLogger *logger.MockLogger) {
 				mockLogger.EXPECT().Info().Return(nopLogger.Info()).Times(1)
 
+				mockGit.EXPECT().
+					Tags(gomock.Any()).
+					Return([]string{"latest"}, nil)
+
 				mockGit.EXPECT().
 					Log(gomock.Any(), gomock.Any()).
-					Return("commits", nil)
+					Return(commits, nil)
 
 				mockGPT.EXPECT().
-					FetchChangelog(gomock.Any(), "commits").
+					FetchChangelog(gomock.Any(), formatted).
 					Return("", errors.New("api error"))
 
 				mockLogger.EXPECT().Error().Return(nopLogger.Error()).Times(1)
@@ -150,7 +271,7 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 			mockGPT := gpt.NewMockClient(ctrl)
 			mockLogger := logger.NewMockLogger(ctrl)
 
-			cmd := NewChangelogCommand(mockGit, mockGPT, mockLogger)
+			cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger)
 
 			tt.before(mockGit, mockGPT, mockLogger)
 
@@ -159,3 +280,414 @@ func Test_ChangelogCmd_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_ChangelogCmd_Write(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	commits := []git.Commit{
+		{Hash: "1111111111", Subject: "feat: Add login", Refs: []string{"tag: v1.1.0"}},
+	}
+
+	tests := []struct {
+		name           string
+		existing       string
+		args           func(dir string) []string
+		expected       string
+		expectedReturn int
+	}{
+		{
+			name:     "Success with default path",
+			existing: "# Changelog\n\n## [1.0.0]\n\n- **fix:** Old fix\n",
+			args: func(dir string) []string {
+				return []string{"--offline", "--write"}
+			},
+			expected:       "# Changelog\n\n## [1.1.0]\n\n### Features\n\n- **feat:** Add login (1111111)\n\n## [1.0.0]\n\n- **fix:** Old fix\n",
+			expectedReturn: 0,
+		},
+		{
+			name:     "Success with keep a changelog",
+			existing: "",
+			args: func(dir string) []string {
+				return []string{"--offline", "--keep-a-changelog", "--write=" + filepath.Join(dir, "CHANGELOG.md")}
+			},
+			expected:       "## [Unreleased]\n\n## [1.1.0] - 2024-06-01\n\n### Features\n\n- **feat:** Add login (1111111)\n",
+			expectedReturn: 0,
+		},
+		{
+			name:     "Failure with existing section",
+			existing: "# Changelog\n\n## [1.1.0]\n\n- **feat:** Add login\n",
+			args: func(dir string) []string {
+				return []string{"--offline", "--write", filepath.Join(dir, "CHANGELOG.md")}
+			},
+			expected:       "# Changelog\n\n## [1.1.0]\n\n- **feat:** Add login\n",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "CHANGELOG.md")
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.existing), 0o644))
+			}
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockGit.EXPECT().Tags(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+			mockGit.EXPECT().ConfigValue(gomock.Any(), "remote.origin.url").Return("", nil)
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger).(*changelogCmd)
+			cmd.root = func() string { return dir }
+			cmd.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+			result := cmd.Run(context.Background(), tt.args(dir))
+			assert.Equal(t, tt.expectedReturn, result)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tt.expected)
+		})
+	}
+}
+
+func Test_ChangelogCmd_WriteModel(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	generated := "# CHANGELOG\n\n## [X.Y.Z]\n\n### Features\n\n- **feat:** Add login"
+
+	tests := []struct {
+		name     string
+		commits  []git.Commit
+		expected string
+	}{
+		{
+			name:     "Success with untagged HEAD",
+			commits:  []git.Commit{{Hash: "1111111111", Subject: "feat: Add login"}},
+			expected: "# CHANGELOG\n\n## [Unreleased]\n\n### Features\n\n- **feat:** Add login\n",
+		},
+		{
+			name:     "Success with tagged HEAD",
+			commits:  []git.Commit{{Hash: "1111111111", Subject: "feat: Add login", Refs: []string{"tag: v1.1.0"}}},
+			expected: "# CHANGELOG\n\n## [1.1.0]\n\n### Features\n\n- **feat:** Add login\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockGit.EXPECT().Tags(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(tt.commits, nil).AnyTimes()
+			mockGPT.EXPECT().FetchChangelog(gomock.Any(), gomock.Any()).Return(generated, nil).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger).(*changelogCmd)
+			cmd.root = func() string { return dir }
+
+			assert.Equal(t, 0, cmd.Run(context.Background(), []string{"--write"}))
+
+			content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+			assert.NotContains(t, string(content), "X.Y.Z")
+		})
+	}
+
+	t.Run("Success regenerating the unreleased section", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dir := t.TempDir()
+
+		mockGit := git.NewMockClient(ctrl)
+		mockGPT := gpt.NewMockClient(ctrl)
+		mockLogger := logger.NewMockLogger(ctrl)
+
+		mockGit.EXPECT().Tags(gomock.Any()).Return(nil, nil).AnyTimes()
+		mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(tests[0].commits, nil).Times(2)
+		mockGPT.EXPECT().FetchChangelog(gomock.Any(), gomock.Any()).Return(generated, nil).Times(2)
+		mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+		mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+		cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger).(*changelogCmd)
+		cmd.root = func() string { return dir }
+
+		assert.Equal(t, 0, cmd.Run(context.Background(), []string{"--write"}))
+		assert.Equal(t, 0, cmd.Run(context.Background(), []string{"--write"}))
+
+		content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		assert.Equal(t, tests[0].expected, string(content))
+	})
+}
+
+func Test_WriteFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		path      string
+		found     bool
+		remaining []string
+	}{
+		{name: "Success without flag", args: []string{"v1.0..v2.0"}, remaining: []string{"v1.0..v2.0"}},
+		{name: "Success with bare flag", args: []string{"--write", "v1.0..v2.0"}, found: true, remaining: []string{"v1.0..v2.0"}},
+		{name: "Success with path argument", args: []string{"--write", "docs/CHANGES.md", "v1.0..v2.0"}, path: "docs/CHANGES.md", found: true, remaining: []string{"v1.0..v2.0"}},
+		{name: "Success with assigned path", args: []string{"--write=NEWS"}, path: "NEWS", found: true, remaining: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, found, remaining := writeFlag(tt.args)
+
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.remaining, remaining)
+		})
+	}
+}
+
+func Test_ChangelogCmd_Releases(t *testing.T) {
+	nopLogger := zerolog.Nop()
+
+	tests := []struct {
+		name     string
+		flags    rangeFlags
+		tags     []string
+		expected []releaseRange
+	}{
+		{
+			name:     "Success with latest tag",
+			flags:    rangeFlags{},
+			tags:     []string{"v1.0.0", "v1.2.0", "nightly"},
+			expected: []releaseRange{{opts: []string{"v1.2.0..HEAD"}}},
+		},
+		{
+			name:     "Success without tags",
+			flags:    rangeFlags{},
+			tags:     nil,
+			expected: []releaseRange{{}},
+		},
+		{
+			name:     "Success with since tag",
+			flags:    rangeFlags{sinceTag: "v1.0.0"},
+			expected: []releaseRange{{opts: []string{"v1.0.0..HEAD"}}},
+		},
+		{
+			name:     "Success with from and to",
+			flags:    rangeFlags{from: "v1.0.0", to: "v1.1.0"},
+			expected: []releaseRange{{opts: []string{"v1.0.0..v1.1.0"}}},
+		},
+		{
+			name:     "Success with from only",
+			flags:    rangeFlags{from: "abc123"},
+			expected: []releaseRange{{opts: []string{"abc123..HEAD"}}},
+		},
+		{
+			name:     "Success with to only",
+			flags:    rangeFlags{to: "v1.1.0"},
+			expected: []releaseRange{{opts: []string{"v1.1.0"}}},
+		},
+		{
+			name:     "Success with explicit range",
+			flags:    rangeFlags{args: []string{"HEAD~10", "HEAD"}},
+			expected: []releaseRange{{opts: []string{"HEAD~10", "HEAD"}}},
+		},
+		{
+			name:  "Success with all tags",
+			flags: rangeFlags{allTags: true},
+			tags:  []string{"v1.1.0", "v1.0.0", "v2.0.0-rc.1"},
+			expected: []releaseRange{
+				{version: "Unreleased", opts: []string{"v2.0.0-rc.1..HEAD"}},
+				{version: "2.0.0-rc.1", opts: []string{"v1.1.0..v2.0.0-rc.1"}},
+				{version: "1.1.0", opts: []string{"v1.0.0..v1.1.0"}},
+				{version: "1.0.0", opts: []string{"v1.0.0"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockGit.EXPECT().Tags(gomock.Any()).Return(tt.tags, nil).AnyTimes()
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+
+			cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger).(*changelogCmd)
+
+			assert.Equal(t, tt.expected, cmd.releases(context.Background(), tt.flags))
+		})
+	}
+}
+
+func Test_ChangelogCmd_AllTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nopLogger := zerolog.Nop()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CHANGELOG.md")
+
+	mockGit := git.NewMockClient(ctrl)
+	mockGPT := gpt.NewMockClient(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+	mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.0.0", "v1.1.0"}, nil)
+	mockGit.EXPECT().ConfigValue(gomock.Any(), "remote.origin.url").Return("", nil)
+	mockGit.EXPECT().Log(gomock.Any(), []string{"v1.1.0..HEAD"}).Return(nil, errors.Join(errors.New("git log"), errors.ErrNoGitCommits))
+	mockGit.EXPECT().Log(gomock.Any(), []string{"v1.0.0..v1.1.0"}).Return([]git.Commit{{Hash: "2222222222", Subject: "fix: Handle nil"}}, nil)
+	mockGit.EXPECT().Log(gomock.Any(), []string{"v1.0.0"}).Return([]git.Commit{{Hash: "1111111111", Subject: "feat: Add login"}}, nil)
+
+	cmd := NewChangelogCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger)
+
+	result := cmd.Run(context.Background(), []string{"--offline", "--all-tags", "--write=" + path})
+	assert.Equal(t, 0, result)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# CHANGELOG\n\n"+
+		"## [1.1.0]\n\n### Fixes\n\n- **fix:** Handle nil (2222222)\n\n"+
+		"## [1.0.0]\n\n### Features\n\n- **feat:** Add login (1111111)\n", string(content))
+}
+
+func Test_ChangelogCmd_Packages(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	apiSpecs := []string{"--", ":(top,glob)services/api/**", ":(top,glob)libs/proto"}
+	webSpecs := []string{"--", ":(top,glob)web"}
+
+	cfg := config.DefaultConfig()
+	cfg.Packages = []config.Package{
+		{Name: "api", Paths: []string{"services/api/**", "libs/proto"}},
+		{Name: "web", Paths: []string{"web"}, Changelog: "web/CHANGES.md"},
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		before         func(mockGit *git.MockClient)
+		expected       map[string]string
+		expectedReturn int
+	}{
+		{
+			name: "Success with package",
+			args: []string{"--offline", "--package", "api", "--write"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v2.0.0", "api/v1.2.0", "web/v3.0.0", "api/v1.10.0"}, nil)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"api/v1.10.0..HEAD"}, apiSpecs...)).
+					Return([]git.Commit{{Hash: "1111111111", Subject: "feat(api): Add endpoint", Refs: []string{"tag: web/v3.1.0"}}}, nil)
+			},
+			expected: map[string]string{
+				"services/api/CHANGELOG.md": "# CHANGELOG\n\n## [Unreleased]\n\n### Features\n\n- **feat(api):** Add endpoint (1111111)\n",
+			},
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with all packages",
+			args: []string{"--offline", "--all-packages", "--all-tags", "--write"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"api/v1.0.0", "web/v1.0.0"}, nil).Times(2)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"api/v1.0.0..HEAD"}, apiSpecs...)).
+					Return(nil, errors.ErrNoGitCommits)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"api/v1.0.0"}, apiSpecs...)).
+					Return([]git.Commit{{Hash: "1111111111", Subject: "feat: Add endpoint"}}, nil)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"web/v1.0.0..HEAD"}, webSpecs...)).
+					Return([]git.Commit{{Hash: "2222222222", Subject: "fix: Align header"}}, nil)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"web/v1.0.0"}, webSpecs...)).
+					Return(nil, errors.ErrNoGitCommits)
+			},
+			expected: map[string]string{
+				"services/api/CHANGELOG.md": "# CHANGELOG\n\n## [1.0.0]\n\n### Features\n\n- **feat:** Add endpoint (1111111)\n",
+				"web/CHANGES.md":            "# CHANGELOG\n\n## [Unreleased]\n\n### Fixes\n\n- **fix:** Align header (2222222)\n",
+			},
+			expectedReturn: 0,
+		},
+		{
+			name: "Success skipping unchanged packages",
+			args: []string{"--offline", "--all-packages", "--write"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"api/v1.0.0"}, nil).Times(2)
+				mockGit.EXPECT().
+					Log(gomock.Any(), append([]string{"api/v1.0.0..HEAD"}, apiSpecs...)).
+					Return(nil, errors.ErrNoGitCommits)
+				mockGit.EXPECT().
+					Log(gomock.Any(), webSpecs).
+					Return([]git.Commit{{Hash: "2222222222", Subject: "fix: Align header"}}, nil)
+			},
+			expected: map[string]string{
+				"web/CHANGES.md": "# CHANGELOG\n\n## [Unreleased]\n\n### Fixes\n\n- **fix:** Align header (2222222)\n",
+			},
+			expectedReturn: 0,
+		},
+		{
+			name:           "Failure with unknown package",
+			args:           []string{"--offline", "--package=cli"},
+			before:         func(mockGit *git.MockClient) {},
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with write path for all packages",
+			args:           []string{"--offline", "--all-packages", "--write=NEWS.md"},
+			before:         func(mockGit *git.MockClient) {},
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "api"), 0o755))
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "web"), 0o755))
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockGit.EXPECT().ConfigValue(gomock.Any(), "remote.origin.url").Return("", nil).AnyTimes()
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit)
+
+			cmd := NewChangelogCommand(cfg, mockGit, mockGPT, mockLogger).(*changelogCmd)
+			cmd.root = func() string { return dir }
+
+			result := cmd.Run(context.Background(), tt.args)
+			assert.Equal(t, tt.expectedReturn, result)
+
+			for file, expected := range tt.expected {
+				content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(content))
+			}
+		})
+	}
+}
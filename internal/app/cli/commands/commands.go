@@ -8,6 +8,7 @@ import (
 	"cmt/internal/app/cli/spinner"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
@@ -20,6 +21,8 @@ type Command interface {
 type CommandsParams struct {
 	fx.In
 
+	Config    *config.Config
+	Flags     config.Flags
 	GitClient git.Client
 	GPTClient gpt.Client
 	Log       logger.Logger
@@ -34,6 +37,12 @@ type commandsResult struct {
 	Version   Command `name:"version"`
 	Changelog Command `name:"changelog"`
 	Commit    Command `name:"commit"`
+	Config    Command `name:"config"`
+	Release   Command `name:"release"`
+	PR        Command `name:"pr"`
+	Reword    Command `name:"reword"`
+	Explain   Command `name:"explain"`
+	Review    Command `name:"review"`
 }
 
 // provideCommands creates all command instances
@@ -41,7 +50,13 @@ func provideCommands(p CommandsParams) commandsResult {
 	return commandsResult{
 		Help:      NewHelpCommand(),
 		Version:   NewVersionCommand(),
-		Changelog: NewChangelogCommand(p.GitClient, p.GPTClient, p.Log),
-		Commit:    NewCommitCommand(p.GitClient, p.GPTClient, p.Log, p.Spinner),
+		Changelog: NewChangelogCommand(p.Config, p.GitClient, p.GPTClient, p.Log),
+		Commit:    NewCommitCommand(p.Config, p.GitClient, p.GPTClient, p.Log, p.Spinner),
+		Config:    NewConfigCommand(p.Config, p.Flags),
+		Release:   NewReleaseCommand(p.GitClient, p.GPTClient, p.Log),
+		PR:        NewPRCommand(p.Config, p.GitClient, p.GPTClient, p.Log),
+		Reword:    NewRewordCommand(p.Config, p.GitClient, p.GPTClient, p.Log, p.Spinner),
+		Explain:   NewExplainCommand(p.Config, p.GitClient, p.GPTClient, p.Log, p.Spinner),
+		Review:    NewReviewCommand(p.Config, p.GitClient, p.GPTClient, p.Log, p.Spinner),
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"cmt/internal/app/cli/spinner"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
@@ -21,6 +22,8 @@ func Test_ProvideCommands(t *testing.T) {
 	mockLogger := logger.NewMockLogger(ctrl)
 
 	params := CommandsParams{
+		Config:    config.DefaultConfig(),
+		Flags:     config.Flags{},
 		GitClient: mockGit,
 		GPTClient: mockGPT,
 		Log:       mockLogger,
@@ -33,4 +36,10 @@ func Test_ProvideCommands(t *testing.T) {
 	assert.NotNil(t, result.Version)
 	assert.NotNil(t, result.Changelog)
 	assert.NotNil(t, result.Commit)
+	assert.NotNil(t, result.Config)
+	assert.NotNil(t, result.Release)
+	assert.NotNil(t, result.PR)
+	assert.NotNil(t, result.Reword)
+	assert.NotNil(t, result.Explain)
+	assert.NotNil(t, result.Review)
 }
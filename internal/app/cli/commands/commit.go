@@ -8,14 +8,19 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/editor"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/ticket"
 	"cmt/internal/app/ui/commit"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
 // commitCmd handles commit message generation and committing
 type commitCmd struct {
+	cfg       *config.Config
 	gitClient git.Client
 	gptClient gpt.Client
 	log       logger.Logger
@@ -24,12 +29,14 @@ type commitCmd struct {
 
 // NewCommitCommand creates a new commit command
 func NewCommitCommand(
+	cfg *config.Config,
 	gitClient git.Client,
 	gptClient gpt.Client,
 	log logger.Logger,
 	spinner spinner.Factory,
 ) Command {
 	return &commitCmd{
+		cfg:       cfg,
 		gitClient: gitClient,
 		gptClient: gptClient,
 		spinner:   spinner,
@@ -40,6 +47,10 @@ func NewCommitCommand(
 // Run executes the commit command
 func (c *commitCmd) Run(ctx context.Context, args []string) int {
 	prefix := c.parsePrefix(args)
+	branchTicket := ticket.Resolve(ctx, c.cfg, c.gitClient, c.log)
+	if prefix == "" {
+		prefix = branchTicket.Prefix()
+	}
 
 	c.log.Info().
 		Str("command", "commit").
@@ -57,6 +68,9 @@ func (c *commitCmd) Run(ctx context.Context, args []string) int {
 		GPTClient: c.gptClient,
 		Spinner:   c.spinner,
 		Logger:    c.log,
+		Editor:    editor.Resolve(ctx, c.cfg, c.gitClient),
+		Ticket:    branchTicket,
+		Ignore:    ignore.Load(c.cfg, c.log),
 	}
 
 	model := commit.NewModel(input)
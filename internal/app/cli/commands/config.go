@@ -0,0 +1,304 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"cmt/internal/app/credentials"
+	"cmt/internal/app/errors"
+	"cmt/internal/config"
+)
+
+// wizardPrompts lists the keys asked for by config init in order
+var wizardPrompts = []struct {
+	key   string
+	label string
+}{
+	{key: "model.name", label: "Model"},
+	{key: "model.max_tokens", label: "Max tokens"},
+	{key: "model.temperature", label: "Temperature (0-2)"},
+	{key: "api.retry_count", label: "Retry count"},
+	{key: "api.timeout", label: "API timeout"},
+	{key: "logging.level", label: "Log level (debug, info, warn, error)"},
+	{key: "editor", label: "External editor (empty for built-in)"},
+	{key: "ticket.pattern", label: "Ticket pattern (empty to disable)"},
+	{key: "ticket.placement", label: "Ticket placement (prefix, scope, footer)"},
+	{key: "secrets.mode", label: "Secrets found in diffs (abort, redact, off)"},
+}
+
+// configCmd handles inspection and editing of the configuration
+type configCmd struct {
+	cfg        *config.Config
+	flags      config.Flags
+	resolve    func(flags map[string]string) (*config.Config, error)
+	storeToken func(token string) (string, error)
+	in         io.Reader
+	out        io.Writer
+}
+
+// NewConfigCommand creates a new config command
+func NewConfigCommand(cfg *config.Config, flags config.Flags) Command {
+	return &configCmd{
+		cfg:        cfg,
+		flags:      flags,
+		resolve:    config.Resolve,
+		storeToken: credentials.Store,
+		in:         os.Stdin,
+		out:        os.Stdout,
+	}
+}
+
+// Run executes the config command
+func (c *configCmd) Run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+
+	switch args[0] {
+	case "show":
+		return c.show(args[1:])
+	case "get":
+		return c.get(args[1:])
+	case "set":
+		return c.set(args[1:])
+	case "validate":
+		return c.validate()
+	case "init":
+		return c.initialize(args[1:])
+	case "set-key":
+		return c.setKey(args[1:])
+	default:
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+}
+
+// show prints every resolved configuration value, optionally with its origin
+func (c *configCmd) show(args []string) int {
+	withOrigin := false
+	for _, arg := range args {
+		switch arg {
+		case "--origin":
+			withOrigin = true
+		default:
+			fmt.Fprint(c.out, configUsage)
+			return 1
+		}
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	for _, setting := range c.cfg.Settings() {
+		if withOrigin {
+			fmt.Fprintf(w, "%s = %s\t# %s\n", setting.Key, setting.Value, setting.Origin)
+			continue
+		}
+		fmt.Fprintf(w, "%s = %s\n", setting.Key, setting.Value)
+	}
+
+	if err := w.Flush(); err != nil {
+		return 1
+	}
+
+	return 0
+}
+
+// get prints the resolved value of a single key
+func (c *configCmd) get(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+
+	value, err := c.cfg.Get(args[0])
+	if err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	fmt.Fprintln(c.out, value)
+	return 0
+}
+
+// set stores a single key in the repository or global config file
+func (c *configCmd) set(args []string) int {
+	global, args := hasFlag(args, "--global")
+	if len(args) != 2 {
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+
+	if !global && config.IsTrustedKey(args[0]) {
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s (use --global)", errors.ErrUntrustedConfigKey, args[0])))
+		return 1
+	}
+
+	path := configPath(global)
+	if err := config.SetValue(path, args[0], args[1]); err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	fmt.Fprintf(c.out, "✅ %s = %s written to %s\n", args[0], args[1], path)
+	return 0
+}
+
+// validate checks the configuration merged with the command line flags and
+// explains every invalid key
+func (c *configCmd) validate() int {
+	cfg, err := c.resolve(c.flags)
+	if err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	err = cfg.Validate()
+	if err == nil {
+		fmt.Fprintln(c.out, "✅ configuration is valid")
+		return 0
+	}
+
+	for _, fieldErr := range config.FieldErrors(err) {
+		value, _ := cfg.Get(fieldErr.Key)
+		fmt.Fprintf(c.out, "❌ %s = %q from %s\n   %v\n", fieldErr.Key, value, cfg.Origin(fieldErr.Key), fieldErr.Err)
+	}
+
+	return 1
+}
+
+// initialize interactively asks for each setting and writes a new config file
+func (c *configCmd) initialize(args []string) int {
+	global, args := hasFlag(args, "--global")
+	force, args := hasFlag(args, "--force")
+	if len(args) != 0 {
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+
+	path := configPath(global)
+	if _, err := os.Stat(path); err == nil && !force {
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s (use --force to overwrite)", errors.ErrConfigFileExists, path)))
+		return 1
+	}
+
+	cfg := config.DefaultConfig()
+	reader := bufio.NewReader(c.in)
+
+	for _, prompt := range wizardPrompts {
+		if !global && config.IsTrustedKey(prompt.key) {
+			continue
+		}
+
+		for {
+			current, _ := cfg.Get(prompt.key)
+			fmt.Fprintf(c.out, "%s [%s]: ", prompt.label, current)
+
+			line, err := reader.ReadString('\n')
+			if err != nil && line == "" {
+				fmt.Fprintln(c.out, "\naborted, no config written")
+				return 1
+			}
+
+			answer := strings.TrimSpace(line)
+			if answer == "" {
+				break
+			}
+
+			if err := c.apply(cfg, prompt.key, answer); err != nil {
+				fmt.Fprintf(c.out, "   %v\n", err)
+				continue
+			}
+			break
+		}
+	}
+
+	if err := config.WriteFile(path, cfg); err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	fmt.Fprintf(c.out, "✅ configuration written to %s\n", path)
+	return 0
+}
+
+// setKey reads the API token from the input and stores it outside of the environment
+func (c *configCmd) setKey(args []string) int {
+	if len(args) != 0 {
+		fmt.Fprint(c.out, configUsage)
+		return 1
+	}
+
+	fmt.Fprint(c.out, "OpenAI API key: ")
+
+	line, err := bufio.NewReader(c.in).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(c.out, "\naborted, no key stored")
+		return 1
+	}
+
+	location, err := c.storeToken(line)
+	if err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	fmt.Fprintf(c.out, "✅ API key stored in %s\n", location)
+	return 0
+}
+
+// apply sets a key on cfg and reverts it when the value does not validate
+func (c *configCmd) apply(cfg *config.Config, key, value string) error {
+	previous, _ := cfg.Get(key)
+
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+
+	for _, fieldErr := range config.FieldErrors(cfg.Validate()) {
+		if fieldErr.Key == key {
+			_ = cfg.Set(key, previous)
+			return fieldErr.Err
+		}
+	}
+
+	return nil
+}
+
+// configPath returns the repository or global config file path
+func configPath(global bool) string {
+	if global {
+		return config.GlobalPath()
+	}
+	return config.RepoPath()
+}
+
+// hasFlag reports whether flag is present in args and returns args without it
+func hasFlag(args []string, flag string) (bool, []string) {
+	found := false
+	remaining := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		remaining = append(remaining, arg)
+	}
+
+	return found, remaining
+}
+
+// configUsage is the usage text of the config command
+const configUsage = `Usage:
+  cmt config show [--origin]                 Print the resolved configuration
+  cmt config get KEY                         Print a single value
+  cmt config set [--global] KEY VALUE        Store a value in cmt.yaml or the global config
+  cmt config validate                        Check the configuration for invalid values
+  cmt config init [--global] [--force]       Create a config file interactively
+  cmt config set-key                         Store the API key in the OS keyring (or a private file)
+`
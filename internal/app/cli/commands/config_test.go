@@ -0,0 +1,397 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/config"
+)
+
+// chdirTemp switches into a fresh temporary directory outside any repository
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "xdg"))
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalWd); err != nil {
+			t.Errorf("failed to restore directory: %v", err)
+		}
+	})
+	return tmpDir
+}
+
+func Test_NewConfigCommand(t *testing.T) {
+	cmd := NewConfigCommand(config.DefaultConfig(), config.Flags{})
+	assert.NotNil(t, cmd)
+}
+
+func Test_ConfigCmd_Run(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedReturn int
+		contains       []string
+		notContains    []string
+	}{
+		{
+			name:           "Success with show",
+			args:           []string{"show"},
+			expectedReturn: 0,
+			contains:       []string{"model.name = " + config.DefaultModelName},
+			notContains:    []string{"# default"},
+		},
+		{
+			name:           "Success with show origin",
+			args:           []string{"show", "--origin"},
+			expectedReturn: 0,
+			contains:       []string{"model.name = " + config.DefaultModelName, "# default"},
+		},
+		{
+			name:           "Success with get",
+			args:           []string{"get", "model.max_tokens"},
+			expectedReturn: 0,
+			contains:       []string{"500"},
+		},
+		{
+			name:           "Failure with get unknown key",
+			args:           []string{"get", "model.unknown"},
+			expectedReturn: 1,
+			contains:       []string{"unknown config key"},
+		},
+		{
+			name:           "Failure with get without key",
+			args:           []string{"get"},
+			expectedReturn: 1,
+			contains:       []string{"Usage:"},
+		},
+		{
+			name:           "Failure without subcommand",
+			args:           []string{},
+			expectedReturn: 1,
+			contains:       []string{"Usage:"},
+		},
+		{
+			name:           "Failure with unknown subcommand",
+			args:           []string{"unknown"},
+			expectedReturn: 1,
+			contains:       []string{"Usage:"},
+		},
+		{
+			name:           "Failure with unknown flag",
+			args:           []string{"show", "--unknown"},
+			expectedReturn: 1,
+			contains:       []string{"Usage:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &configCmd{cfg: config.DefaultConfig(), out: &out}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			for _, s := range tt.contains {
+				assert.Contains(t, out.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func Test_ConfigCmd_Set(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedReturn int
+		expectedFile   string
+		contains       string
+	}{
+		{
+			name:           "Success",
+			args:           []string{"set", "model.name", "gpt-4"},
+			expectedReturn: 0,
+			expectedFile:   "model:\n    name: gpt-4\n",
+			contains:       "written to",
+		},
+		{
+			name:           "Failure with invalid value",
+			args:           []string{"set", "model.temperature", "9"},
+			expectedReturn: 1,
+			contains:       "invalid temperature",
+		},
+		{
+			name:           "Failure with editor in repository config",
+			args:           []string{"set", "editor", "vim"},
+			expectedReturn: 1,
+			contains:       "not allowed in the repository config: editor (use --global)",
+		},
+		{
+			name:           "Failure with missing value",
+			args:           []string{"set", "model.name"},
+			expectedReturn: 1,
+			contains:       "Usage:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := chdirTemp(t)
+
+			var out bytes.Buffer
+			cmd := &configCmd{cfg: config.DefaultConfig(), out: &out}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.contains)
+			if tt.expectedFile != "" {
+				data, err := os.ReadFile(filepath.Join(dir, config.ConfigFileName))
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedFile, string(data))
+			}
+		})
+	}
+}
+
+func Test_ConfigCmd_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		flags          config.Flags
+		resolve        func(flags map[string]string) (*config.Config, error)
+		expectedReturn int
+		contains       []string
+	}{
+		{
+			name: "Success",
+			resolve: func(flags map[string]string) (*config.Config, error) {
+				return config.DefaultConfig(), nil
+			},
+			expectedReturn: 0,
+			contains:       []string{"configuration is valid"},
+		},
+		{
+			name: "Failure with invalid values",
+			resolve: func(flags map[string]string) (*config.Config, error) {
+				cfg := config.DefaultConfig()
+				cfg.Model.Temperature = 3
+				cfg.Ticket.Placement = "middle"
+				return cfg, nil
+			},
+			expectedReturn: 1,
+			contains: []string{
+				`model.temperature = "3" from default`,
+				"must be between 0 and 2",
+				`ticket.placement = "middle" from default`,
+			},
+		},
+		{
+			name:  "Failure with invalid flag",
+			flags: config.Flags{"model.temperature": "5"},
+			resolve: func(flags map[string]string) (*config.Config, error) {
+				cfg := config.DefaultConfig()
+				if err := cfg.Set("model.temperature", flags["model.temperature"]); err != nil {
+					return nil, err
+				}
+				return cfg, nil
+			},
+			expectedReturn: 1,
+			contains:       []string{`model.temperature = "5"`},
+		},
+		{
+			name: "Failure with unreadable config",
+			resolve: func(flags map[string]string) (*config.Config, error) {
+				return nil, assert.AnError
+			},
+			expectedReturn: 1,
+			contains:       []string{assert.AnError.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &configCmd{cfg: config.DefaultConfig(), flags: tt.flags, resolve: tt.resolve, out: &out}
+
+			result := cmd.Run(context.Background(), []string{"validate"})
+
+			assert.Equal(t, tt.expectedReturn, result)
+			for _, s := range tt.contains {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func Test_ConfigCmd_Init(t *testing.T) {
+	tests := []struct {
+		name           string
+		existing       bool
+		args           []string
+		input          string
+		expectedReturn int
+		validate       func(t *testing.T, cfg *config.Config)
+		contains       string
+	}{
+		{
+			name:           "Success with defaults",
+			args:           []string{"init"},
+			input:          strings.Repeat("\n", 10),
+			expectedReturn: 0,
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.Equal(t, config.DefaultModelName, cfg.Model.Name)
+			},
+			contains: "configuration written",
+		},
+		{
+			name:           "Success with answers and retry on invalid value",
+			args:           []string{"init"},
+			input:          "gpt-4\n\n5\n0.2\n\n\n\n([A-Z]+-\\d+)\nfooter\nredact\n",
+			expectedReturn: 0,
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.Equal(t, "gpt-4", cfg.Model.Name)
+				assert.Equal(t, 0.2, cfg.Model.Temperature)
+				assert.Empty(t, cfg.Editor)
+				assert.Equal(t, `([A-Z]+-\d+)`, cfg.Ticket.Pattern)
+				assert.Equal(t, config.TicketPlacementFooter, cfg.Ticket.Placement)
+				assert.Equal(t, config.SecretsModeRedact, cfg.Secrets.Mode)
+			},
+			contains: "invalid temperature",
+		},
+		{
+			name:           "Success with editor in global config",
+			args:           []string{"init", "--global"},
+			input:          "\n\n\n\n\n\nvim\n\n\n\n",
+			expectedReturn: 0,
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.Equal(t, "vim", cfg.Editor)
+			},
+			contains: "External editor",
+		},
+		{
+			name:           "Success with force overwriting",
+			existing:       true,
+			args:           []string{"init", "--force"},
+			input:          strings.Repeat("\n", 10),
+			expectedReturn: 0,
+			validate: func(t *testing.T, cfg *config.Config) {
+				assert.Equal(t, config.DefaultModelName, cfg.Model.Name)
+			},
+			contains: "configuration written",
+		},
+		{
+			name:           "Failure with existing file",
+			existing:       true,
+			args:           []string{"init"},
+			expectedReturn: 1,
+			contains:       "--force",
+		},
+		{
+			name:           "Failure with closed input",
+			args:           []string{"init"},
+			input:          "gpt-4\n",
+			expectedReturn: 1,
+			contains:       "aborted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			dir := chdirTemp(t)
+			path := filepath.Join(dir, config.ConfigFileName)
+			if tt.existing {
+				require.NoError(t, os.WriteFile(path, []byte("model:\n  name: old\n"), 0o600))
+			}
+
+			var out bytes.Buffer
+			cmd := &configCmd{cfg: config.DefaultConfig(), in: strings.NewReader(tt.input), out: &out}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.contains)
+			if tt.validate != nil {
+				cfg, err := config.Load(nil)
+				require.NoError(t, err)
+				tt.validate(t, cfg)
+			}
+		})
+	}
+}
+
+func Test_ConfigCmd_SetKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		input          string
+		storeToken     func(token string) (string, error)
+		expectedReturn int
+		contains       string
+	}{
+		{
+			name:  "Success",
+			args:  []string{"set-key"},
+			input: "sk-test\n",
+			storeToken: func(token string) (string, error) {
+				assert.Equal(t, "sk-test\n", token)
+				return "OS keyring", nil
+			},
+			expectedReturn: 0,
+			contains:       "stored in OS keyring",
+		},
+		{
+			name:  "Failure with store error",
+			args:  []string{"set-key"},
+			input: "sk-test\n",
+			storeToken: func(token string) (string, error) {
+				return "", assert.AnError
+			},
+			expectedReturn: 1,
+			contains:       assert.AnError.Error(),
+		},
+		{
+			name:           "Failure with closed input",
+			args:           []string{"set-key"},
+			expectedReturn: 1,
+			contains:       "aborted",
+		},
+		{
+			name:           "Failure with extra arguments",
+			args:           []string{"set-key", "sk-test"},
+			expectedReturn: 1,
+			contains:       "Usage:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &configCmd{
+				cfg:        config.DefaultConfig(),
+				storeToken: tt.storeToken,
+				in:         strings.NewReader(tt.input),
+				out:        &out,
+			}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.contains)
+		})
+	}
+}
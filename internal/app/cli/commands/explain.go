@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/ui/explain"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// explainCmd explains in plain language what a commit changes
+type explainCmd struct {
+	cfg       *config.Config
+	gitClient git.Client
+	gptClient gpt.Client
+	log       logger.Logger
+	spinner   spinner.Factory
+	view      func(explain.Input) error
+	out       io.Writer
+}
+
+// NewExplainCommand creates a new explain command
+func NewExplainCommand(
+	cfg *config.Config,
+	gitClient git.Client,
+	gptClient gpt.Client,
+	log logger.Logger,
+	spinner spinner.Factory,
+) Command {
+	return &explainCmd{
+		cfg:       cfg,
+		gitClient: gitClient,
+		gptClient: gptClient,
+		log:       log,
+		spinner:   spinner,
+		view:      runExplain,
+		out:       os.Stdout,
+	}
+}
+
+// Run executes the explain command
+func (c *explainCmd) Run(ctx context.Context, args []string) int {
+	if len(args) > 1 {
+		fmt.Fprint(c.out, explainUsage)
+		return 1
+	}
+
+	rev := "HEAD"
+	if len(args) == 1 {
+		rev = args[0]
+	}
+	if strings.HasPrefix(rev, "-") {
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s", errors.ErrInvalidRevision, rev)))
+		return 1
+	}
+
+	c.log.Info().
+		Str("command", "explain").
+		Str("rev", rev).
+		Msg("Starting commit explanation")
+
+	commits, err := c.gitClient.Log(ctx, []string{"-n", "1", rev})
+	if err != nil {
+		c.log.Error().Str("command", "explain").Err(err).Msg("Failed to fetch git log")
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s", err, rev)))
+		return 1
+	}
+
+	diff, err := c.gitClient.CommitDiff(ctx, commits[0].Hash)
+	if err != nil && !errors.Is(err, errors.ErrNoGitChanges) {
+		c.log.Error().Str("command", "explain").Err(err).Msg("Failed to fetch commit diff")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	err = c.view(explain.Input{
+		Commit:    commits[0],
+		Diff:      diff,
+		GPTClient: c.gptClient,
+		Ignore:    ignore.Load(c.cfg, c.log),
+		Ctx:       ctx,
+		Spinner:   c.spinner,
+	})
+	if err != nil {
+		c.log.Error().Str("command", "explain").Err(err).Msg("Failed to explain commit")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	return 0
+}
+
+// runExplain shows the explanation viewer and returns the error of the last explanation
+func runExplain(input explain.Input) error {
+	finalModel, err := tea.NewProgram(explain.NewModel(input), tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+
+	if model, ok := finalModel.(explain.Model); ok {
+		return model.GetError()
+	}
+	return nil
+}
+
+// explainUsage is the usage text of the explain command
+const explainUsage = `Usage:
+  cmt explain [REV]
+
+Explains in plain language what the commit REV changes and why it might
+matter (default: HEAD).
+`
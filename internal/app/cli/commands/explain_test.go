@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ui/explain"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_NewExplainCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewExplainCommand(config.DefaultConfig(), git.NewMockClient(ctrl), gpt.NewMockClient(ctrl), logger.NewMockLogger(ctrl), spinner.NewSpinner)
+	assert.NotNil(t, cmd)
+}
+
+func Test_ExplainCmd_Run(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	commit := git.Commit{Hash: "aaaaaaa111", Subject: "fix: Handle nil"}
+
+	tests := []struct {
+		name           string
+		args           []string
+		before         func(mockGit *git.MockClient)
+		viewErr        error
+		expectedDiff   string
+		expected       string
+		expectedReturn int
+	}{
+		{
+			name: "Success with default revision",
+			args: []string{},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"-n", "1", "HEAD"}).Return([]git.Commit{commit}, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa111").Return("diff", nil)
+			},
+			expectedDiff:   "diff",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with empty commit",
+			args: []string{"v1.0.0"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"-n", "1", "v1.0.0"}).Return([]git.Commit{commit}, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa111").Return("", errors.ErrNoGitChanges)
+			},
+			expectedReturn: 0,
+		},
+		{
+			name: "Failure with unknown revision",
+			args: []string{"missing"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"-n", "1", "missing"}).Return(nil, errors.ErrFailedToLoadGitLog)
+			},
+			expected:       "missing",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when explanation fails",
+			args: []string{"HEAD"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return([]git.Commit{commit}, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa111").Return("diff", nil)
+			},
+			viewErr:        errors.ErrNoResponse,
+			expectedDiff:   "diff",
+			expected:       "no response from GPT",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with revision looking like an option",
+			args:           []string{"--output=/tmp/x"},
+			before:         func(mockGit *git.MockClient) {},
+			expected:       "invalid revision: --output=/tmp/x",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with several revisions",
+			args:           []string{"HEAD", "HEAD~1"},
+			before:         func(mockGit *git.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Warn().Return(nopLogger.Warn()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit)
+
+			var out bytes.Buffer
+			cmd := NewExplainCommand(config.DefaultConfig(), mockGit, gpt.NewMockClient(ctrl), mockLogger, spinner.NewSpinner).(*explainCmd)
+			cmd.out = &out
+			cmd.view = func(input explain.Input) error {
+				assert.Equal(t, commit, input.Commit)
+				assert.Equal(t, tt.expectedDiff, input.Diff)
+				return tt.viewErr
+			}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.expected)
+		})
+	}
+}
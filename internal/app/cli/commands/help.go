@@ -31,29 +31,60 @@ Usage:
   cmt [command] [options]
 
 Commands:
-  changelog [RANGE]   Generate a changelog from git history
+  changelog [RANGE]   Generate a changelog from git history (--offline skips the model)
+  release             Recommend the next version (--pre ID, --tag, --offline)
+  pr [BASE]           Generate a pull request title and description (--output PATH)
+  reword BASE..       Rewrite unpushed commit messages that are not conventional
+  explain [REV]       Explain in plain language what a commit changes
+  review              Review the staged changes (--format sarif|json for CI)
+  config show         Print the resolved configuration (--origin adds sources)
+  config get/set      Read or store a single key (set --global for user config)
+  config validate     Check the configuration and explain invalid values
+  config init         Create cmt.yaml interactively (--global, --force)
+  config set-key      Store the API key in the OS keyring or a private file
   version             Display version information
   help                Display this help message
 
 Examples:
   cmt                        Generate commit message for staged changes
   cmt --prefix "TASK-123"     Add "TASK-123" prefix to commit message
-  cmt changelog              Generate changelog for all commits
+  cmt changelog              Generate changelog since the latest release tag
   cmt changelog v1.0..v2.0   Generate changelog between versions
+  cmt changelog --offline    Group conventional commits without calling the API
+  cmt changelog --write      Insert the new section at the top of CHANGELOG.md
+  cmt changelog --all-tags   Generate a section for every release tag
+  cmt changelog --format json  Print the changelog as JSON, also yaml, html or asciidoc
+  cmt changelog --package api  Limit the changelog to a package declared in cmt.yaml
+  cmt release --tag          Tag the next version with its changelog section
+  cmt pr develop             Describe the changes of the branch since develop
+  cmt reword main..          Review new messages for the commits since main
+  cmt explain a1b2c3d        Explain what commit a1b2c3d changes and why
+  cmt review --format sarif  Review staged changes and print SARIF for code scanning
+  cmt --model gpt-4.1        Override a config value for this run
+  cmt --profile careful      Use the "careful" profile from cmt.yaml
+  cmt config show --origin   Show configuration and where it came from
   cmt --version              Show version
   cmt --help                 Show this help
 
 Navigation:
   tab                 Switch focus between panes
-  j/k, ↑/↓            Scroll focused pane
+  j/k, ↑/↓            Scroll focused pane or move file selection
+  enter               View staged and unstaged hunks of selected file
+  n/p                 Select next/previous hunk in diff view
+  s/u                 Stage/unstage selected hunk in diff view
+  esc                 Leave diff view
   a                   Accept and commit
-  e                   Edit commit message
+  e                   Edit commit message (in external editor if configured)
+  f                   Edit commit message in a structured form
   r                   Regenerate commit message
   l                   Toggle application logs
   q, Ctrl+C           Quit without committing
 
 Environment:
-  OPENAI_API_KEY         Required: Your OpenAI API key
+  OPENAI_API_KEY         Your OpenAI API key (or use api.key_command / config set-key)
+  CMT_<KEY>              Optional: Override a config key, e.g. CMT_MODEL_NAME
+  GIT_EDITOR, VISUAL, EDITOR
+                         Optional: External editor used by 'e'
 `,
 		config.AppName,
 		config.Version,
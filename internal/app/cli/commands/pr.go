@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/pullrequest"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// prCmd generates the title and description of a pull request for the current branch
+type prCmd struct {
+	cfg       *config.Config
+	gitClient git.Client
+	gptClient gpt.Client
+	log       logger.Logger
+	root      func() string
+	out       io.Writer
+}
+
+// NewPRCommand creates a new pull request command
+func NewPRCommand(
+	cfg *config.Config,
+	gitClient git.Client,
+	gptClient gpt.Client,
+	log logger.Logger,
+) Command {
+	return &prCmd{
+		cfg:       cfg,
+		gitClient: gitClient,
+		gptClient: gptClient,
+		log:       log,
+		root:      config.RepoRoot,
+		out:       os.Stdout,
+	}
+}
+
+// Run executes the pull request command
+func (c *prCmd) Run(ctx context.Context, args []string) int {
+	noTemplate, args := hasFlag(args, "--no-template")
+	templatePath, args := flagValue(args, "--template")
+	output, args := flagValue(args, "--output")
+	base, args := flagValue(args, "--base")
+
+	if base == "" && len(args) == 1 {
+		base, args = args[0], nil
+	}
+	if len(args) > 0 {
+		fmt.Fprint(c.out, prUsage)
+		return 1
+	}
+	if base == "" {
+		base = pullrequest.DefaultBase(ctx, c.gitClient)
+	}
+	if strings.HasPrefix(base, "-") {
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s", errors.ErrInvalidRevision, base)))
+		return 1
+	}
+
+	c.log.Info().
+		Str("command", "pr").
+		Str("base", base).
+		Str("output", output).
+		Msg("Starting pull request generation")
+
+	commits, err := c.gitClient.Log(ctx, []string{base + "..HEAD"})
+	if err != nil {
+		c.log.Error().Str("command", "pr").Err(err).Msg("Failed to fetch git log")
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s..HEAD", err, base)))
+		return 1
+	}
+
+	diff, err := c.gitClient.BranchDiff(ctx, base)
+	if err != nil {
+		c.log.Error().Str("command", "pr").Err(err).Msg("Failed to fetch branch diff")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	template, err := c.template(templatePath, noTemplate)
+	if err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	pr, err := c.gptClient.FetchPullRequest(ctx, git.FormatMessages(commits), ignore.Load(c.cfg, c.log).PromptDiff(diff), template)
+	if err != nil {
+		c.log.Error().Str("command", "pr").Err(err).Msg("Failed to generate pull request description")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	result := pullrequest.Format(pr, template)
+
+	c.log.Info().
+		Str("command", "pr").
+		Int("commits", len(commits)).
+		Msg("Pull request description generated successfully")
+
+	if output == "" {
+		fmt.Fprintln(c.out, result)
+		return 0
+	}
+
+	if err := os.WriteFile(output, []byte(result+"\n"), 0o644); err != nil {
+		c.log.Error().Err(err).Str("path", output).Msg("Failed to write pull request description")
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s", errors.ErrFailedToWritePullRequest, output)))
+		return 1
+	}
+
+	fmt.Fprintf(c.out, "✅ Pull request description written to %s\n", output)
+	return 0
+}
+
+// template reads the given template, or the one found in the repository unless disabled
+func (c *prCmd) template(path string, disabled bool) (string, error) {
+	if disabled {
+		return "", nil
+	}
+
+	if path == "" {
+		found, ok := pullrequest.FindTemplate(c.root())
+		if !ok {
+			return "", nil
+		}
+		path = found
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		c.log.Error().Err(err).Str("path", path).Msg("Failed to read pull request template")
+		return "", fmt.Errorf("%w: %s", errors.ErrFailedToReadTemplate, path)
+	}
+
+	c.log.Debug().Str("path", path).Msg("Using pull request template")
+	return string(content), nil
+}
+
+// prUsage is the usage text of the pull request command
+const prUsage = `Usage:
+  cmt pr [BASE] [--template PATH | --no-template] [--output PATH]
+
+Options:
+  BASE, --base BASE   Branch the pull request merges into (default: origin/HEAD, init.defaultBranch or main)
+  --template PATH     Fill in this template instead of .github/pull_request_template.md
+  --no-template       Use the default layout even when the repository has a template
+  --output PATH       Write the title and description to a file instead of the console
+`
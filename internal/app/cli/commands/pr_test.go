@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_NewPRCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewPRCommand(config.DefaultConfig(), git.NewMockClient(ctrl), gpt.NewMockClient(ctrl), logger.NewMockLogger(ctrl))
+	assert.NotNil(t, cmd)
+}
+
+func Test_PRCmd_Run(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	commits := []git.Commit{
+		{Hash: "bbbbbbb222", Subject: "feat: Add retry"},
+		{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+	}
+	pr := gpt.PullRequest{
+		Title:   "Add retry to the API client",
+		Summary: "Retries failed requests.",
+		Changes: []string{"Add retry loop"},
+	}
+	diff := "diff --git a/main.go b/main.go"
+
+	tests := []struct {
+		name           string
+		args           []string
+		template       string
+		before         func(mockGit *git.MockClient, mockGPT *gpt.MockClient)
+		expected       string
+		expectedFile   string
+		expectedReturn int
+	}{
+		{
+			name: "Success with default base",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return(diff, nil)
+				mockGPT.EXPECT().
+					FetchPullRequest(gomock.Any(), "aaaaaaa fix: Handle nil\nbbbbbbb feat: Add retry", diff, "").
+					Return(pr, nil)
+			},
+			expected:       "Add retry to the API client\n\n## Summary\n\nRetries failed requests.\n\n## Changes\n\n- Add retry loop\n",
+			expectedReturn: 0,
+		},
+		{
+			name:     "Success with repository template",
+			args:     []string{"--base", "develop"},
+			template: "## What\n\n## Checklist\n\n- [ ] Tests",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"develop..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "develop").Return(diff, nil)
+				mockGPT.EXPECT().
+					FetchPullRequest(gomock.Any(), gomock.Any(), diff, "## What\n\n## Checklist\n\n- [ ] Tests").
+					Return(gpt.PullRequest{Title: "Add retry", Sections: map[string]string{"What": "Retries."}}, nil)
+			},
+			expected:       "Add retry\n\n## What\n\nRetries.\n\n## Checklist\n\n- [ ] Tests\n",
+			expectedReturn: 0,
+		},
+		{
+			name:     "Success without template",
+			args:     []string{"develop", "--no-template"},
+			template: "## What",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"develop..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "develop").Return(diff, nil)
+				mockGPT.EXPECT().FetchPullRequest(gomock.Any(), gomock.Any(), diff, "").Return(pr, nil)
+			},
+			expected:       "## Summary",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with excluded files",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return(diff+"\n+package main\ndiff --git a/go.sum b/go.sum\n+example.com/mod v1.0.0 h1:abc=", nil)
+				mockGPT.EXPECT().
+					FetchPullRequest(gomock.Any(), gomock.Any(), diff+"\n+package main\n\n1 file regenerated (contents omitted): go.sum", "").
+					Return(pr, nil)
+			},
+			expected:       "## Summary",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with output file",
+			args: []string{"--output", "pr.md"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return(diff, nil)
+				mockGPT.EXPECT().FetchPullRequest(gomock.Any(), gomock.Any(), diff, "").Return(pr, nil)
+			},
+			expected:       "Pull request description written to",
+			expectedFile:   "Add retry to the API client\n\n## Summary\n\nRetries failed requests.\n\n## Changes\n\n- Add retry loop\n",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with default branch of the remote",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().DefaultBranch(gomock.Any()).Return("trunk", nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"trunk..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "trunk").Return(diff, nil)
+				mockGPT.EXPECT().FetchPullRequest(gomock.Any(), gomock.Any(), diff, "").Return(pr, nil)
+			},
+			expected:       "## Summary",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with init.defaultBranch",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "init.defaultBranch").Return("develop", nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"develop..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "develop").Return(diff, nil)
+				mockGPT.EXPECT().FetchPullRequest(gomock.Any(), gomock.Any(), diff, "").Return(pr, nil)
+			},
+			expected:       "## Summary",
+			expectedReturn: 0,
+		},
+		{
+			name:           "Failure with base looking like an option",
+			args:           []string{"--base=--output=/tmp/x"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "invalid revision: --output=/tmp/x",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure without commits",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(nil, errors.ErrNoGitCommits)
+			},
+			expected:       "main..HEAD",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when branch diff fails",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return("", errors.ErrFailedToLoadGitDiff)
+			},
+			expected:       "failed to load git diff",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure with missing template",
+			args: []string{"--template", "missing.md"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return(diff, nil)
+			},
+			expected:       "failed to read pull request template",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when generation fails",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().BranchDiff(gomock.Any(), "main").Return(diff, nil)
+				mockGPT.EXPECT().FetchPullRequest(gomock.Any(), gomock.Any(), diff, "").Return(gpt.PullRequest{}, errors.ErrNoResponse)
+			},
+			expected:       "no response from GPT",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with unknown argument",
+			args:           []string{"main", "develop"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := t.TempDir()
+			if tt.template != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "pull_request_template.md"), []byte(tt.template), 0o644))
+			}
+			t.Chdir(root)
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit, mockGPT)
+			mockGit.EXPECT().DefaultBranch(gomock.Any()).Return("", nil).AnyTimes()
+			mockGit.EXPECT().ConfigValue(gomock.Any(), "init.defaultBranch").Return("", nil).AnyTimes()
+
+			var out bytes.Buffer
+			cfg := config.DefaultConfig()
+			cfg.Diff.Exclude = []string{"go.sum"}
+
+			cmd := NewPRCommand(cfg, mockGit, mockGPT, mockLogger).(*prCmd)
+			cmd.root = func() string { return root }
+			cmd.out = &out
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.expected)
+
+			if tt.expectedFile != "" {
+				content, err := os.ReadFile(filepath.Join(root, "pr.md"))
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedFile, string(content))
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cmt/internal/app/changelog"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/release"
+	"cmt/internal/config/logger"
+)
+
+// releaseCmd recommends the next semantic version and optionally tags it
+type releaseCmd struct {
+	gitClient git.Client
+	gptClient gpt.Client
+	log       logger.Logger
+	out       io.Writer
+}
+
+// NewReleaseCommand creates a new release command
+func NewReleaseCommand(
+	gitClient git.Client,
+	gptClient gpt.Client,
+	log logger.Logger,
+) Command {
+	return &releaseCmd{
+		gitClient: gitClient,
+		gptClient: gptClient,
+		log:       log,
+		out:       os.Stdout,
+	}
+}
+
+// Run executes the release command
+func (c *releaseCmd) Run(ctx context.Context, args []string) int {
+	createTag, args := hasFlag(args, "--tag")
+	offline, args := hasFlag(args, "--offline")
+	pre, args := flagValue(args, "--pre")
+
+	if len(args) > 0 {
+		fmt.Fprint(c.out, releaseUsage)
+		return 1
+	}
+
+	tags, err := c.gitClient.Tags(ctx)
+	if err != nil {
+		c.log.Debug().Err(err).Msg("Failed to read tags")
+	}
+
+	stable, latest := release.Baseline(tags)
+
+	var opts []string
+	if stable != "" {
+		opts = []string{stable + "..HEAD"}
+	}
+
+	c.log.Info().
+		Str("command", "release").
+		Str("since", stable).
+		Str("pre", pre).
+		Msg("Computing next version")
+
+	commits, err := c.gitClient.Log(ctx, opts)
+	if err != nil && !errors.Is(err, errors.ErrNoGitCommits) {
+		c.log.Error().Str("command", "release").Err(err).Msg("Failed to fetch git log")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	plan := release.NewPlan(stable, latest, commits, pre)
+	fmt.Fprint(c.out, plan.Rationale())
+
+	if !plan.IsRelease() || !createTag {
+		return 0
+	}
+
+	notes, err := c.notes(ctx, plan, commits, offline)
+	if err != nil {
+		c.log.Error().Str("command", "release").Err(err).Msg("Failed to generate release notes")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	name := plan.Next.String()
+	if err := c.gitClient.CreateTag(ctx, name, notes); err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	c.log.Info().Str("command", "release").Str("tag", name).Msg("Release tagged successfully")
+	fmt.Fprintf(c.out, "\n🏷️ Created tag %s\n", name)
+	return 0
+}
+
+// notes generates the changelog section used as the tag message
+func (c *releaseCmd) notes(ctx context.Context, plan release.Plan, commits []git.Commit, offline bool) (string, error) {
+	version := strings.TrimPrefix(plan.Next.String(), plan.Next.Prefix)
+
+	if offline {
+		return changelog.Sections(changelog.Render(commits, changelog.Options{Version: version})), nil
+	}
+
+	result, err := c.gptClient.FetchChangelog(ctx, git.FormatLog(commits))
+	if err != nil {
+		return "", err
+	}
+
+	return changelog.Sections(changelog.SetVersion(result, version)), nil
+}
+
+// releaseUsage is the usage text of the release command
+const releaseUsage = `Usage:
+  cmt release [--pre ID] [--tag] [--offline]
+
+Options:
+  --pre ID     Compute a pre-release such as 1.3.0-rc.1, continuing an existing one
+  --tag        Create an annotated tag with the changelog section as its message
+  --offline    Build the tag message from conventional commits without the API
+`
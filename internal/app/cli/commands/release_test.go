@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config/logger"
+)
+
+func Test_NewReleaseCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewReleaseCommand(git.NewMockClient(ctrl), gpt.NewMockClient(ctrl), logger.NewMockLogger(ctrl))
+	assert.NotNil(t, cmd)
+}
+
+func Test_ReleaseCmd_Run(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	commits := []git.Commit{
+		{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+		{Hash: "bbbbbbb222", Subject: "feat(api): Add endpoint"},
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		before         func(mockGit *git.MockClient, mockGPT *gpt.MockClient)
+		expected       string
+		expectedReturn int
+	}{
+		{
+			name: "Success with recommendation",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.0.0", "v1.2.0"}, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"v1.2.0..HEAD"}).Return(commits, nil)
+			},
+			expected:       "Current version: v1.2.0\nNext version:    v1.3.0 (minor)\n",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with offline tag",
+			args: []string{"--tag", "--offline", "--pre", "rc"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.2.0"}, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"v1.2.0..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().
+					CreateTag(gomock.Any(), "v1.3.0-rc.1", "## [1.3.0-rc.1]\n\n### Features\n\n- **feat(api):** Add endpoint (bbbbbbb)\n\n### Fixes\n\n- **fix:** Handle nil (aaaaaaa)").
+					Return(nil)
+			},
+			expected:       "🏷️ Created tag v1.3.0-rc.1",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with generated tag message",
+			args: []string{"--tag"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return(nil, nil)
+				mockGit.EXPECT().Log(gomock.Any(), nil).Return(commits, nil)
+				mockGPT.EXPECT().
+					FetchChangelog(gomock.Any(), gomock.Any()).
+					Return("# CHANGELOG\n\n## [X.Y.Z]\n\n### Features\n- **feat:** Add endpoint", nil)
+				mockGit.EXPECT().
+					CreateTag(gomock.Any(), "v0.1.0", "## [0.1.0]\n\n### Features\n- **feat:** Add endpoint").
+					Return(nil)
+			},
+			expected:       "🏷️ Created tag v0.1.0",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success without releasable changes",
+			args: []string{"--tag"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.2.0"}, nil)
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(nil, errors.ErrNoGitCommits)
+			},
+			expected:       "No release needed",
+			expectedReturn: 0,
+		},
+		{
+			name: "Failure when tag creation fails",
+			args: []string{"--tag", "--offline"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.2.0"}, nil)
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGit.EXPECT().CreateTag(gomock.Any(), "v1.3.0", gomock.Any()).Return(errors.ErrFailedToCreateTag)
+			},
+			expected:       "failed to create tag",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when changelog generation fails",
+			args: []string{"--tag"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Tags(gomock.Any()).Return([]string{"v1.2.0"}, nil)
+				mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(commits, nil)
+				mockGPT.EXPECT().FetchChangelog(gomock.Any(), gomock.Any()).Return("", errors.ErrNoResponse)
+			},
+			expected:       "no response from GPT",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with unknown argument",
+			args:           []string{"v1.0.0"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit, mockGPT)
+
+			var out bytes.Buffer
+			cmd := NewReleaseCommand(mockGit, mockGPT, mockLogger).(*releaseCmd)
+			cmd.out = &out
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/review"
+	reviewui "cmt/internal/app/ui/review"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// reviewCmd reviews the staged changes before they are committed
+type reviewCmd struct {
+	cfg       *config.Config
+	gitClient git.Client
+	gptClient gpt.Client
+	log       logger.Logger
+	spinner   spinner.Factory
+	view      func(reviewui.Input) error
+	out       io.Writer
+}
+
+// NewReviewCommand creates a new review command
+func NewReviewCommand(
+	cfg *config.Config,
+	gitClient git.Client,
+	gptClient gpt.Client,
+	log logger.Logger,
+	spinner spinner.Factory,
+) Command {
+	return &reviewCmd{
+		cfg:       cfg,
+		gitClient: gitClient,
+		gptClient: gptClient,
+		log:       log,
+		spinner:   spinner,
+		view:      runReview,
+		out:       os.Stdout,
+	}
+}
+
+// Run executes the review command
+func (c *reviewCmd) Run(ctx context.Context, args []string) int {
+	format, args := flagValue(args, "--format")
+	if len(args) > 0 {
+		fmt.Fprint(c.out, reviewUsage)
+		return 1
+	}
+
+	if format != "" {
+		if _, err := review.Format(nil, format); err != nil {
+			fmt.Fprintln(c.out, errors.Format(err))
+			return 1
+		}
+	}
+
+	c.log.Info().
+		Str("command", "review").
+		Str("format", format).
+		Msg("Starting review")
+
+	diff, err := c.gitClient.Diff(ctx)
+	if err != nil {
+		if errors.Is(err, errors.ErrNoGitChanges) {
+			fmt.Fprintln(c.out, "⚠️ no staged changes to review")
+			return 1
+		}
+		c.log.Error().Str("command", "review").Err(err).Msg("Failed to fetch staged diff")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	ignored := ignore.Load(c.cfg, c.log)
+
+	// The TUI reviews the changes itself, keeping its spinner out of output meant for other programs
+	if format == "" {
+		err = c.view(reviewui.Input{
+			Diff:      diff,
+			GPTClient: c.gptClient,
+			Ignore:    ignored,
+			Ctx:       ctx,
+			Spinner:   c.spinner,
+		})
+		if err != nil {
+			c.log.Error().Str("command", "review").Err(err).Msg("Failed to review staged changes")
+			fmt.Fprintln(c.out, errors.Format(err))
+			return 1
+		}
+		return 0
+	}
+
+	findings, err := c.gptClient.FetchReview(ctx, ignored.PromptDiff(diff))
+	if err != nil {
+		c.log.Error().Str("command", "review").Err(err).Msg("Failed to review staged changes")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	c.log.Info().
+		Str("command", "review").
+		Int("findings", len(findings)).
+		Msg("Review completed successfully")
+
+	result, err := review.Format(findings, format)
+	if err != nil {
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+	fmt.Fprintln(c.out, result)
+
+	// Fail CI jobs on findings that break behavior or security
+	if review.HasErrors(findings) {
+		return 1
+	}
+	return 0
+}
+
+// runReview shows the findings until the user quits and returns the error of the review
+func runReview(input reviewui.Input) error {
+	finalModel, err := tea.NewProgram(reviewui.NewModel(input), tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+
+	if model, ok := finalModel.(reviewui.Model); ok {
+		return model.GetError()
+	}
+	return nil
+}
+
+// reviewUsage is the usage text of the review command
+const reviewUsage = `Usage:
+  cmt review [--format sarif|json]
+
+Options:
+  --format FORMAT   Print the findings as sarif or json instead of opening the TUI,
+                    exiting with 1 when a finding has the error severity
+`
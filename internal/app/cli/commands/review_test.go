@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	reviewui "cmt/internal/app/ui/review"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_NewReviewCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewReviewCommand(config.DefaultConfig(), git.NewMockClient(ctrl), gpt.NewMockClient(ctrl), logger.NewMockLogger(ctrl), spinner.NewSpinner)
+	assert.NotNil(t, cmd)
+}
+
+func Test_ReviewCmd_Run(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	diff := "diff --git a/api.go b/api.go\n+++ b/api.go\n@@ -1 +1 @@\n+return cfg.Name"
+	warning := gpt.Finding{File: "api.go", Line: 1, Severity: gpt.SeverityWarning, Message: "cfg may be nil"}
+	failure := gpt.Finding{File: "api.go", Line: 1, Severity: gpt.SeverityError, Message: "cfg is nil on startup"}
+
+	tests := []struct {
+		name           string
+		args           []string
+		before         func(mockGit *git.MockClient, mockGPT *gpt.MockClient)
+		viewErr        error
+		expected       string
+		expectedView   bool
+		expectedReturn int
+	}{
+		{
+			name: "Success with the TUI",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return(diff, nil)
+			},
+			expectedView:   true,
+			expectedReturn: 0,
+		},
+		{
+			name: "Failure when review in the TUI fails",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return(diff, nil)
+			},
+			viewErr:        errors.ErrNoResponse,
+			expected:       "no response from GPT",
+			expectedView:   true,
+			expectedReturn: 1,
+		},
+		{
+			name: "Success with JSON format",
+			args: []string{"--format", "json"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return(diff, nil)
+				mockGPT.EXPECT().FetchReview(gomock.Any(), diff).Return([]gpt.Finding{warning}, nil)
+			},
+			expected:       "{\n  \"findings\": [\n    {\n      \"file\": \"api.go\",\n      \"line\": 1,\n      \"severity\": \"warning\",\n      \"message\": \"cfg may be nil\"\n    }\n  ]\n}\n",
+			expectedReturn: 0,
+		},
+		{
+			name: "Failure with error finding in SARIF format",
+			args: []string{"--format", "sarif"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return(diff, nil)
+				mockGPT.EXPECT().FetchReview(gomock.Any(), diff).Return([]gpt.Finding{failure}, nil)
+			},
+			expected:       `"level": "error"`,
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure without staged changes",
+			args: []string{},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return("", errors.ErrNoGitChanges)
+			},
+			expected:       "no staged changes to review",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when review fails",
+			args: []string{"--format", "json"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Diff(gomock.Any()).Return(diff, nil)
+				mockGPT.EXPECT().FetchReview(gomock.Any(), diff).Return(nil, errors.ErrNoResponse)
+			},
+			expected:       "no response from GPT",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with unknown format",
+			args:           []string{"--format", "xml"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "invalid review format: xml",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with unknown argument",
+			args:           []string{"HEAD"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Warn().Return(nopLogger.Warn()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit, mockGPT)
+
+			var out bytes.Buffer
+			viewed := false
+			cmd := NewReviewCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger, spinner.NewSpinner).(*reviewCmd)
+			cmd.out = &out
+			cmd.view = func(input reviewui.Input) error {
+				viewed = true
+				assert.Equal(t, diff, input.Diff)
+				assert.Equal(t, mockGPT, input.GPTClient)
+				assert.NotNil(t, input.Spinner)
+				return tt.viewErr
+			}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.expected)
+			assert.Equal(t, tt.expectedView, viewed)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/lint"
+	"cmt/internal/app/ui/reword"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// rewordCmd rewrites the messages of unpushed commits that are not conventional
+type rewordCmd struct {
+	cfg       *config.Config
+	gitClient git.Client
+	gptClient gpt.Client
+	log       logger.Logger
+	spinner   spinner.Factory
+	review    func(reword.Input) (reword.Output, error)
+	out       io.Writer
+}
+
+// NewRewordCommand creates a new reword command
+func NewRewordCommand(
+	cfg *config.Config,
+	gitClient git.Client,
+	gptClient gpt.Client,
+	log logger.Logger,
+	spinner spinner.Factory,
+) Command {
+	return &rewordCmd{
+		cfg:       cfg,
+		gitClient: gitClient,
+		gptClient: gptClient,
+		log:       log,
+		spinner:   spinner,
+		review:    runReword,
+		out:       os.Stdout,
+	}
+}
+
+// Run executes the reword command
+func (c *rewordCmd) Run(ctx context.Context, args []string) int {
+	base, ok := parseRewordRange(args)
+	if !ok {
+		fmt.Fprint(c.out, rewordUsage)
+		return 1
+	}
+
+	c.log.Info().
+		Str("command", "reword").
+		Str("base", base).
+		Msg("Starting reword")
+
+	pushed, err := c.gitClient.Pushed(ctx, base)
+	if err != nil {
+		c.log.Error().Str("command", "reword").Err(err).Msg("Failed to check pushed commits")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+	if pushed {
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s..HEAD", errors.ErrCommitsPushed, base)))
+		return 1
+	}
+
+	commits, err := c.gitClient.Log(ctx, []string{base + "..HEAD"})
+	if err != nil {
+		c.log.Error().Str("command", "reword").Err(err).Msg("Failed to fetch git log")
+		fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s..HEAD", err, base)))
+		return 1
+	}
+
+	proposals := lintCommits(commits)
+	if len(proposals) == 0 {
+		fmt.Fprintf(c.out, "✅ Every commit message in %s..HEAD is conventional\n", base)
+		return 0
+	}
+
+	matcher := ignore.Load(c.cfg, c.log)
+
+	spin := spinner.New(fmt.Sprintf("Generating %d commit messages…", len(proposals)))
+	spin.Start()
+	for i, p := range proposals {
+		diff, err := c.gitClient.CommitDiff(ctx, p.Commit.Hash)
+		if err == nil {
+			proposals[i].Message, err = c.gptClient.FetchCommitMessage(ctx, matcher.PromptDiff(diff))
+		}
+		if err != nil {
+			spin.Stop()
+			c.log.Error().Str("command", "reword").Str("commit", p.Commit.Hash).Err(err).Msg("Failed to generate commit message")
+			fmt.Fprintln(c.out, errors.Format(fmt.Errorf("%w: %s", err, p.Commit.ShortHash())))
+			return 1
+		}
+	}
+	spin.Stop()
+
+	output, err := c.review(reword.Input{
+		Proposals: proposals,
+		GitClient: c.gitClient,
+		GPTClient: c.gptClient,
+		Ignore:    matcher,
+		Ctx:       ctx,
+		Spinner:   c.spinner,
+	})
+	if err != nil {
+		fmt.Fprintf(c.out, "TUI error: %v\n", err)
+		return 1
+	}
+
+	if !output.Accepted {
+		fmt.Fprintln(c.out, "❌ Reword cancelled")
+		return 0
+	}
+
+	backup, err := c.gitClient.Rewrite(ctx, base, output.Messages)
+	if err != nil {
+		c.log.Error().Str("command", "reword").Err(err).Msg("Failed to rewrite commits")
+		fmt.Fprintln(c.out, errors.Format(err))
+		return 1
+	}
+
+	if backup == "" {
+		fmt.Fprintln(c.out, "No commits reworded")
+		return 0
+	}
+
+	c.log.Info().
+		Str("command", "reword").
+		Int("commits", len(output.Messages)).
+		Str("backup", backup).
+		Msg("Commits reworded successfully")
+
+	fmt.Fprintf(c.out, "🚀 Reworded %d commits\n", len(output.Messages))
+	fmt.Fprintf(c.out, "The previous branch is saved as %s, restore it with:\n  git reset --hard %s\n", backup, backup)
+	return 0
+}
+
+// lintCommits returns the commits whose message fails the linter, oldest first.
+// Merge commits keep the message git wrote for them
+func lintCommits(commits []git.Commit) []reword.Proposal {
+	var proposals []reword.Proposal
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if commit.IsMerge() {
+			continue
+		}
+
+		if problems := lint.Check(commit.Message); len(problems) > 0 {
+			proposals = append(proposals, reword.Proposal{Commit: commit, Problems: problems})
+		}
+	}
+	return proposals
+}
+
+// parseRewordRange returns the base of a base.., base..HEAD or base argument
+func parseRewordRange(args []string) (string, bool) {
+	if len(args) != 1 {
+		return "", false
+	}
+
+	base, head, found := strings.Cut(args[0], "..")
+	if base == "" || (found && head != "" && head != "HEAD") {
+		return "", false
+	}
+	return base, true
+}
+
+// runReword runs the review TUI until every proposal was answered or the user quits
+func runReword(input reword.Input) (reword.Output, error) {
+	finalModel, err := tea.NewProgram(reword.NewModel(input), tea.WithAltScreen()).Run()
+	if err != nil {
+		return reword.Output{}, err
+	}
+
+	model, ok := finalModel.(reword.Model)
+	if !ok {
+		return reword.Output{}, nil
+	}
+	return model.GetOutput(), nil
+}
+
+// rewordUsage is the usage text of the reword command
+const rewordUsage = `Usage:
+  cmt reword <BASE>..[HEAD]
+
+Rewrites the messages of the commits after BASE that are not conventional.
+Only commits that were not pushed can be reworded, the previous branch is
+kept under refs/cmt/backup/.
+`
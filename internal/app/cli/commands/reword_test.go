@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ui/reword"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_NewRewordCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := NewRewordCommand(config.DefaultConfig(), git.NewMockClient(ctrl), gpt.NewMockClient(ctrl), logger.NewMockLogger(ctrl), spinner.NewSpinner)
+	assert.NotNil(t, cmd)
+}
+
+func Test_RewordCmd_Run(t *testing.T) {
+	nopLogger := zerolog.Nop()
+	commits := []git.Commit{
+		{Hash: "ccccccc333", Parents: []string{"bbbbbbb222"}, Subject: "fixed stuff", Message: "fixed stuff\n"},
+		{Hash: "bbbbbbb222", Parents: []string{"aaaaaaa111"}, Subject: "feat: Add two", Message: "feat: Add two\n"},
+		{Hash: "aaaaaaa111", Parents: []string{"0000000000"}, Subject: "wip", Message: "wip\n"},
+	}
+	accepted := map[string]string{"aaaaaaa111": "feat: Add one", "ccccccc333": "fix: Handle empty input"}
+
+	tests := []struct {
+		name           string
+		args           []string
+		before         func(mockGit *git.MockClient, mockGPT *gpt.MockClient)
+		output         reword.Output
+		expected       string
+		expectedReturn int
+	}{
+		{
+			name: "Success with accepted messages",
+			args: []string{"main.."},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(false, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa111").Return("diff one", nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "ccccccc333").Return("diff three", nil)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff one").Return("feat: Add one", nil)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff three").Return("fix: Handle empty input", nil)
+				mockGit.EXPECT().Rewrite(gomock.Any(), "main", accepted).Return("refs/cmt/backup/feature/1700000000", nil)
+			},
+			output:         reword.Output{Accepted: true, Messages: accepted},
+			expected:       "git reset --hard refs/cmt/backup/feature/1700000000",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success when review is cancelled",
+			args: []string{"main..HEAD"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(false, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), gomock.Any()).Return("diff", nil).Times(2)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff").Return("feat: Add one", nil).Times(2)
+			},
+			expected:       "Reword cancelled",
+			expectedReturn: 0,
+		},
+		{
+			name: "Success with conventional history",
+			args: []string{"main"},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(false, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits[1:2], nil)
+			},
+			expected:       "Every commit message in main..HEAD is conventional",
+			expectedReturn: 0,
+		},
+		{
+			name: "Failure with pushed commits",
+			args: []string{"main.."},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(true, nil)
+			},
+			expected:       "commits were already pushed",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when generation fails",
+			args: []string{"main.."},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(false, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa111").Return("diff one", nil)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff one").Return("", errors.ErrNoResponse)
+			},
+			expected:       "no response from GPT",
+			expectedReturn: 1,
+		},
+		{
+			name: "Failure when rewrite fails",
+			args: []string{"main.."},
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Pushed(gomock.Any(), "main").Return(false, nil)
+				mockGit.EXPECT().Log(gomock.Any(), []string{"main..HEAD"}).Return(commits, nil)
+				mockGit.EXPECT().CommitDiff(gomock.Any(), gomock.Any()).Return("diff", nil).Times(2)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff").Return("feat: Add one", nil).Times(2)
+				mockGit.EXPECT().Rewrite(gomock.Any(), "main", accepted).Return("", errors.ErrFailedToRewrite)
+			},
+			output:         reword.Output{Accepted: true, Messages: accepted},
+			expected:       "failed to rewrite commits",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure with range not ending at HEAD",
+			args:           []string{"main..develop"},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+		{
+			name:           "Failure without range",
+			args:           []string{},
+			before:         func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {},
+			expected:       "Usage:",
+			expectedReturn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Info().Return(nopLogger.Info()).AnyTimes()
+			mockLogger.EXPECT().Warn().Return(nopLogger.Warn()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			tt.before(mockGit, mockGPT)
+
+			var out bytes.Buffer
+			var reviewed []reword.Proposal
+			cmd := NewRewordCommand(config.DefaultConfig(), mockGit, mockGPT, mockLogger, spinner.NewSpinner).(*rewordCmd)
+			cmd.out = &out
+			cmd.review = func(input reword.Input) (reword.Output, error) {
+				reviewed = input.Proposals
+				return tt.output, nil
+			}
+
+			result := cmd.Run(context.Background(), tt.args)
+
+			assert.Equal(t, tt.expectedReturn, result)
+			assert.Contains(t, out.String(), tt.expected)
+			if reviewed != nil {
+				assert.Equal(t, "wip", reviewed[0].Commit.Subject)
+				assert.Equal(t, "fixed stuff", reviewed[1].Commit.Subject)
+			}
+		})
+	}
+}
+
+func Test_lintCommits(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "ddddddd444", Parents: []string{"ccccccc333", "eeeeeee555"}, Message: "Merge branch 'dev'\n"},
+		{Hash: "ccccccc333", Parents: []string{"bbbbbbb222"}, Message: "fix: Handle empty input\n\nSigned-off-by: Jane Doe <jane@example.com>\n"},
+		{Hash: "bbbbbbb222", Parents: []string{"aaaaaaa111"}, Message: "feat: Add two\nwithout a blank line\n"},
+		{Hash: "aaaaaaa111", Parents: []string{"0000000000"}, Message: "wip\n"},
+	}
+
+	proposals := lintCommits(commits)
+
+	assert.Len(t, proposals, 2)
+	assert.Equal(t, "aaaaaaa111", proposals[0].Commit.Hash)
+	assert.Equal(t, "bbbbbbb222", proposals[1].Commit.Hash)
+	assert.Equal(t, []string{"body is not separated from the header by a blank line"}, proposals[1].Problems)
+}
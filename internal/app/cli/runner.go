@@ -22,6 +22,12 @@ type Params struct {
 	Version   commands.Command `name:"version"`
 	Changelog commands.Command `name:"changelog"`
 	Commit    commands.Command `name:"commit"`
+	Config    commands.Command `name:"config"`
+	Release   commands.Command `name:"release"`
+	PR        commands.Command `name:"pr"`
+	Reword    commands.Command `name:"reword"`
+	Explain   commands.Command `name:"explain"`
+	Review    commands.Command `name:"review"`
 }
 
 // runner implements the Runner interface
@@ -30,7 +36,14 @@ type runner struct {
 	version     commands.Command
 	changelog   commands.Command
 	commit      commands.Command
+	config      commands.Command
+	release     commands.Command
+	pr          commands.Command
+	reword      commands.Command
+	explain     commands.Command
+	review      commands.Command
 	dispatchMap map[string]commands.Command
+	withArgs    map[string]bool
 }
 
 // NewRunner creates a new command runner with a pre-built dispatch map
@@ -40,13 +53,27 @@ func NewRunner(p Params) Runner {
 		version:     p.Version,
 		changelog:   p.Changelog,
 		commit:      p.Commit,
+		config:      p.Config,
+		release:     p.Release,
+		pr:          p.PR,
+		reword:      p.Reword,
+		explain:     p.Explain,
+		review:      p.Review,
 		dispatchMap: make(map[string]commands.Command),
+		withArgs:    make(map[string]bool),
 	}
 
 	r.dispatchMap["changelog"] = r.changelog
 	r.dispatchMap["--changelog"] = r.changelog
 	r.dispatchMap["-c"] = r.changelog
 
+	r.dispatchMap["config"] = r.config
+	r.dispatchMap["release"] = r.release
+	r.dispatchMap["pr"] = r.pr
+	r.dispatchMap["reword"] = r.reword
+	r.dispatchMap["explain"] = r.explain
+	r.dispatchMap["review"] = r.review
+
 	r.dispatchMap["help"] = r.help
 	r.dispatchMap["--help"] = r.help
 	r.dispatchMap["-h"] = r.help
@@ -55,6 +82,10 @@ func NewRunner(p Params) Runner {
 	r.dispatchMap["--version"] = r.version
 	r.dispatchMap["-v"] = r.version
 
+	for _, command := range []string{"changelog", "--changelog", "-c", "config", "release", "pr", "reword", "explain", "review"} {
+		r.withArgs[command] = true
+	}
+
 	return r
 }
 
@@ -68,7 +99,7 @@ func (r *runner) Resolve(args []string) (commands.Command, []string, error) {
 
 	if cmd, ok := r.dispatchMap[command]; ok {
 		remainingArgs := []string{}
-		if command == "changelog" || command == "--changelog" || command == "-c" {
+		if r.withArgs[command] {
 			remainingArgs = args[1:]
 		}
 		return cmd, remainingArgs, nil
@@ -19,6 +19,12 @@ func Test_NewRunner(t *testing.T) {
 		Version:   commands.NewMockCommand(ctrl),
 		Changelog: commands.NewMockCommand(ctrl),
 		Commit:    commands.NewMockCommand(ctrl),
+		Config:    commands.NewMockCommand(ctrl),
+		Release:   commands.NewMockCommand(ctrl),
+		PR:        commands.NewMockCommand(ctrl),
+		Reword:    commands.NewMockCommand(ctrl),
+		Explain:   commands.NewMockCommand(ctrl),
+		Review:    commands.NewMockCommand(ctrl),
 	}
 
 	instance := NewRunner(params)
@@ -33,12 +39,24 @@ func Test_Resolve(t *testing.T) {
 	versionCmd := commands.NewMockCommand(ctrl)
 	changelogCmd := commands.NewMockCommand(ctrl)
 	commitCmd := commands.NewMockCommand(ctrl)
+	configCmd := commands.NewMockCommand(ctrl)
+	releaseCmd := commands.NewMockCommand(ctrl)
+	prCmd := commands.NewMockCommand(ctrl)
+	rewordCmd := commands.NewMockCommand(ctrl)
+	explainCmd := commands.NewMockCommand(ctrl)
+	reviewCmd := commands.NewMockCommand(ctrl)
 
 	params := Params{
 		Help:      helpCmd,
 		Version:   versionCmd,
 		Changelog: changelogCmd,
 		Commit:    commitCmd,
+		Config:    configCmd,
+		Release:   releaseCmd,
+		PR:        prCmd,
+		Reword:    rewordCmd,
+		Explain:   explainCmd,
+		Review:    reviewCmd,
 	}
 
 	instance := NewRunner(params)
@@ -148,6 +166,48 @@ func Test_Resolve(t *testing.T) {
 			expectedArgs:  []string{"v1.0..v2.0"},
 			expectedError: nil,
 		},
+		{
+			name:          "Success with config command",
+			args:          []string{"config", "show", "--origin"},
+			expectedCmd:   configCmd,
+			expectedArgs:  []string{"show", "--origin"},
+			expectedError: nil,
+		},
+		{
+			name:          "Success with release command",
+			args:          []string{"release", "--pre", "rc", "--tag"},
+			expectedCmd:   releaseCmd,
+			expectedArgs:  []string{"--pre", "rc", "--tag"},
+			expectedError: nil,
+		},
+		{
+			name:          "Success with pr command",
+			args:          []string{"pr", "develop", "--output", "pr.md"},
+			expectedCmd:   prCmd,
+			expectedArgs:  []string{"develop", "--output", "pr.md"},
+			expectedError: nil,
+		},
+		{
+			name:          "Success with reword command",
+			args:          []string{"reword", "main.."},
+			expectedCmd:   rewordCmd,
+			expectedArgs:  []string{"main.."},
+			expectedError: nil,
+		},
+		{
+			name:          "Success with explain command",
+			args:          []string{"explain", "HEAD~2"},
+			expectedCmd:   explainCmd,
+			expectedArgs:  []string{"HEAD~2"},
+			expectedError: nil,
+		},
+		{
+			name:          "Success with review command",
+			args:          []string{"review", "--format", "sarif"},
+			expectedCmd:   reviewCmd,
+			expectedArgs:  []string{"--format", "sarif"},
+			expectedError: nil,
+		},
 		{
 			name:          "Success with prefix flag long",
 			args:          []string{"--prefix", "feat:"},
@@ -0,0 +1,195 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+const (
+	// keyringService is the service name under which the token is stored in the OS keyring
+	keyringService = "cmt"
+	// keyringUser is the account name under which the token is stored in the OS keyring
+	keyringUser = "openai"
+	// credentialsFileName is the name of the fallback credentials file
+	credentialsFileName = "credentials"
+)
+
+// TokenSource represents a provider of the OpenAI API token
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// sourceFunc adapts a function to the TokenSource interface
+type sourceFunc func(ctx context.Context) (string, error)
+
+// Token returns the token provided by the function
+func (f sourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// chain implements TokenSource by querying sources in order
+type chain struct {
+	sources []namedSource
+	log     logger.Logger
+}
+
+// namedSource is a token source labelled for logging
+type namedSource struct {
+	name   string
+	source TokenSource
+}
+
+// NewTokenSource creates a token source trying, in order, the OPENAI_API_KEY
+// environment variable, the configured key command, the OS keyring and the
+// credentials file
+func NewTokenSource(cfg *config.Config, log logger.Logger) TokenSource {
+	sources := []namedSource{
+		{name: "env", source: sourceFunc(envToken)},
+	}
+
+	if cfg.API.KeyCommand != "" {
+		sources = append(sources, namedSource{name: "key_command", source: CommandSource(cfg.API.KeyCommand)})
+	}
+
+	sources = append(sources,
+		namedSource{name: "keyring", source: sourceFunc(keyringToken)},
+		namedSource{name: "file", source: FileSource(FilePath())},
+	)
+
+	return &chain{sources: sources, log: log}
+}
+
+// Token returns the first token found, failing fast on sources that are configured but broken
+func (c *chain) Token(ctx context.Context) (string, error) {
+	for _, s := range c.sources {
+		token, err := s.source.Token(ctx)
+		if errors.Is(err, errors.ErrAPITokenNotSet) {
+			continue
+		}
+		if err != nil {
+			c.log.Error().Str("source", s.name).Err(err).Msg("Failed to read API token")
+			return "", err
+		}
+
+		c.log.Debug().Str("source", s.name).Msg("API token resolved")
+		return token, nil
+	}
+
+	return "", errors.ErrAPITokenNotSet
+}
+
+// CommandSource returns a token source reading the token from the output of a
+// shell command, which the config only accepts from the user's own settings
+func CommandSource(command string) TokenSource {
+	return sourceFunc(func(ctx context.Context) (string, error) {
+		var stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Stderr = &stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v: %s", errors.ErrFailedToRunKeyCommand, err, strings.TrimSpace(stderr.String()))
+		}
+
+		token := firstLine(string(out))
+		if token == "" {
+			return "", fmt.Errorf("%w: command printed no token", errors.ErrFailedToRunKeyCommand)
+		}
+
+		return token, nil
+	})
+}
+
+// FileSource returns a token source reading the token from a file only readable by its owner
+func FileSource(path string) TokenSource {
+	return sourceFunc(func(ctx context.Context) (string, error) {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return "", errors.ErrAPITokenNotSet
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", errors.ErrFailedToReadCredentials, path, err)
+		}
+
+		if info.Mode().Perm()&0o077 != 0 {
+			return "", fmt.Errorf("%w: %s has mode %o, expected 600", errors.ErrInsecureCredentials, path, info.Mode().Perm())
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %v", errors.ErrFailedToReadCredentials, path, err)
+		}
+
+		token := firstLine(string(data))
+		if token == "" {
+			return "", errors.ErrAPITokenNotSet
+		}
+
+		return token, nil
+	})
+}
+
+// FilePath returns the path of the fallback credentials file
+func FilePath() string {
+	global := config.GlobalPath()
+	if global == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(global), credentialsFileName)
+}
+
+// Store saves the token in the OS keyring, falling back to the credentials
+// file when no keyring is available, and returns where it was stored
+func Store(token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.ErrAPITokenNotSet
+	}
+
+	if err := keyring.Set(keyringService, keyringUser, token); err == nil {
+		return "OS keyring", nil
+	}
+
+	path := FilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", errors.ErrFailedToStoreCredentials, path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", errors.ErrFailedToStoreCredentials, path, err)
+	}
+
+	return path, nil
+}
+
+// envToken reads the token from the environment
+func envToken(ctx context.Context) (string, error) {
+	return config.GetAPIToken()
+}
+
+// keyringToken reads the token from the OS keyring, treating an unavailable keyring as empty
+func keyringToken(ctx context.Context) (string, error) {
+	token, err := keyring.Get(keyringService, keyringUser)
+	if err != nil || token == "" {
+		return "", errors.ErrAPITokenNotSet
+	}
+	return token, nil
+}
+
+// firstLine returns the first line of s without surrounding whitespace
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,287 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_Module(t *testing.T) {
+	assert.NotNil(t, Module)
+}
+
+func Test_NewTokenSource(t *testing.T) {
+	nopLogger := zerolog.Nop()
+
+	tests := []struct {
+		name          string
+		env           string
+		keyCommand    string
+		keyring       string
+		file          string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "Success with environment variable first",
+			env:      "env-token",
+			keyring:  "keyring-token",
+			expected: "env-token",
+		},
+		{
+			name:       "Success with key command",
+			keyCommand: "echo command-token",
+			keyring:    "keyring-token",
+			expected:   "command-token",
+		},
+		{
+			name:     "Success with keyring",
+			keyring:  "keyring-token",
+			file:     "file-token",
+			expected: "keyring-token",
+		},
+		{
+			name:     "Success with credentials file",
+			file:     "file-token\n",
+			expected: "file-token",
+		},
+		{
+			name:          "Failure with failing key command",
+			keyCommand:    "exit 3",
+			keyring:       "keyring-token",
+			expectedError: errors.ErrFailedToRunKeyCommand,
+		},
+		{
+			name:          "Failure without any token",
+			expectedError: errors.ErrAPITokenNotSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			keyring.MockInit()
+			t.Setenv("OPENAI_API_KEY", tt.env)
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+			if tt.keyring != "" {
+				require.NoError(t, keyring.Set(keyringService, keyringUser, tt.keyring))
+			}
+			if tt.file != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(FilePath()), 0o700))
+				require.NoError(t, os.WriteFile(FilePath(), []byte(tt.file), 0o600))
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.API.KeyCommand = tt.keyCommand
+
+			mockLogger := logger.NewMockLogger(ctrl)
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+			mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+			token, err := NewTokenSource(cfg, mockLogger).Token(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Empty(t, token)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
+
+func Test_NewTokenSource_WithRepoKeyCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nopLogger := zerolog.Nop()
+
+	keyring.MockInit()
+	require.NoError(t, keyring.Set(keyringService, keyringUser, "keyring-token"))
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(originalWd))
+	})
+
+	marker := filepath.Join(dir, "ran")
+	repoConfig := "api:\n  key_command: touch " + marker + " && echo repo-token\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.ConfigFileName), []byte(repoConfig), 0o600))
+
+	_, err := config.Load(nil)
+	assert.ErrorIs(t, err, errors.ErrUntrustedConfigKey)
+
+	cfg, err := config.Resolve(nil)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.API.KeyCommand)
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+	mockLogger.EXPECT().Error().Return(nopLogger.Error()).AnyTimes()
+
+	token, err := NewTokenSource(cfg, mockLogger).Token(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "keyring-token", token)
+	assert.NoFileExists(t, marker)
+}
+
+func Test_CommandSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "Success with first line of output",
+			command:  "printf '  secret  \\nsecond line\\n'",
+			expected: "secret",
+		},
+		{
+			name:          "Failure with empty output",
+			command:       "true",
+			expectedError: errors.ErrFailedToRunKeyCommand,
+		},
+		{
+			name:          "Failure with non-zero exit",
+			command:       "echo denied >&2; exit 1",
+			expectedError: errors.ErrFailedToRunKeyCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := CommandSource(tt.command).Token(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
+
+func Test_FileSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		contents      string
+		mode          os.FileMode
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "Success",
+			contents: "file-token\n",
+			mode:     0o600,
+			expected: "file-token",
+		},
+		{
+			name:          "Failure with missing file",
+			expectedError: errors.ErrAPITokenNotSet,
+		},
+		{
+			name:          "Failure with insecure permissions",
+			contents:      "file-token\n",
+			mode:          0o644,
+			expectedError: errors.ErrInsecureCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), credentialsFileName)
+			if tt.contents != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.contents), tt.mode))
+				require.NoError(t, os.Chmod(path, tt.mode))
+			}
+
+			token, err := FileSource(path).Token(context.Background())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
+
+func Test_Store(t *testing.T) {
+	tests := []struct {
+		name          string
+		keyringErr    error
+		token         string
+		expectFile    bool
+		expectedError error
+	}{
+		{
+			name:  "Success with keyring",
+			token: "secret\n",
+		},
+		{
+			name:       "Success with file fallback",
+			keyringErr: assert.AnError,
+			token:      "secret",
+			expectFile: true,
+		},
+		{
+			name:          "Failure with empty token",
+			token:         "  ",
+			expectedError: errors.ErrAPITokenNotSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if tt.keyringErr != nil {
+				keyring.MockInitWithError(tt.keyringErr)
+			} else {
+				keyring.MockInit()
+			}
+
+			location, err := Store(tt.token)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.expectFile {
+				assert.Equal(t, FilePath(), location)
+				token, err := FileSource(FilePath()).Token(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, "secret", token)
+				return
+			}
+
+			stored, err := keyring.Get(keyringService, keyringUser)
+			assert.NoError(t, err)
+			assert.Equal(t, "secret", stored)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package credentials
+
+import (
+	"go.uber.org/fx"
+)
+
+var Module = fx.Options(
+	fx.Provide(NewTokenSource),
+)
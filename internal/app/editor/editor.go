@@ -0,0 +1,106 @@
+package editor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"cmt/internal/app/git"
+	"cmt/internal/config"
+)
+
+const (
+	// CommentChar marks lines that are stripped from the edited message
+	CommentChar = "#"
+
+	// FilePattern is the temp file name pattern for edited messages
+	FilePattern = "CMT_EDITMSG-*.txt"
+
+	template = `
+# Please enter the commit message for your changes. Lines starting
+# with '#' will be ignored, and an empty message keeps the previous one.
+`
+)
+
+// Resolve returns the editor command to use, following the same precedence as git:
+// the configured editor, $GIT_EDITOR, core.editor, $VISUAL and $EDITOR. The
+// configured editor never comes from the repository config, see config.IsTrustedKey
+func Resolve(ctx context.Context, cfg *config.Config, gitClient git.Client) string {
+	if cfg != nil && strings.TrimSpace(cfg.Editor) != "" {
+		return strings.TrimSpace(cfg.Editor)
+	}
+
+	if value := strings.TrimSpace(os.Getenv("GIT_EDITOR")); value != "" {
+		return value
+	}
+
+	if gitClient != nil {
+		if value, err := gitClient.ConfigValue(ctx, "core.editor"); err == nil && value != "" {
+			return value
+		}
+	}
+
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Command returns a command that opens the file in the editor through the shell,
+// so editors configured with arguments (e.g. "code --wait") work as in git
+func Command(editor, path string) *exec.Cmd {
+	// #nosec G204 -- the editor is configured by the user, as with git
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+}
+
+// WriteTempFile writes the message with the instruction comment to a temp file
+func WriteTempFile(message string) (string, error) {
+	file, err := os.CreateTemp("", FilePattern)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(message + "\n" + template); err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+// ReadTempFile reads the edited message, strips comments and removes the file
+func ReadTempFile(path string) (string, error) {
+	defer os.Remove(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return StripComments(string(content)), nil
+}
+
+// StripComments cleans up the message like git commit --cleanup=strip: comment lines and
+// trailing whitespace are removed and consecutive blank lines are collapsed
+func StripComments(text string) string {
+	var lines []string
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, CommentChar) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" && len(lines) > 0 && lines[len(lines)-1] == "" {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,155 @@
+package editor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/config"
+)
+
+func Test_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		editor   string
+		env      map[string]string
+		before   func(*git.MockClient)
+		expected string
+	}{
+		{
+			name:     "Success with configured editor",
+			editor:   "hx",
+			env:      map[string]string{"GIT_EDITOR": "vim", "VISUAL": "code", "EDITOR": "nano"},
+			before:   func(*git.MockClient) {},
+			expected: "hx",
+		},
+		{
+			name:     "Success with GIT_EDITOR",
+			env:      map[string]string{"GIT_EDITOR": "vim", "VISUAL": "code", "EDITOR": "nano"},
+			before:   func(*git.MockClient) {},
+			expected: "vim",
+		},
+		{
+			name: "Success with core.editor",
+			env:  map[string]string{"GIT_EDITOR": "", "VISUAL": "code", "EDITOR": "nano"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "core.editor").Return("emacs -nw", nil)
+			},
+			expected: "emacs -nw",
+		},
+		{
+			name: "Success with VISUAL",
+			env:  map[string]string{"GIT_EDITOR": "", "VISUAL": "code --wait", "EDITOR": "nano"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "core.editor").Return("", nil)
+			},
+			expected: "code --wait",
+		},
+		{
+			name: "Success with EDITOR when core.editor fails",
+			env:  map[string]string{"GIT_EDITOR": "", "VISUAL": "", "EDITOR": "nano"},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "core.editor").Return("", errors.ErrFailedToReadGitConfig)
+			},
+			expected: "nano",
+		},
+		{
+			name: "Success without any editor",
+			env:  map[string]string{"GIT_EDITOR": "", "VISUAL": "", "EDITOR": ""},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "core.editor").Return("", nil)
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			mockGit := git.NewMockClient(ctrl)
+			tt.before(mockGit)
+
+			cfg := config.DefaultConfig()
+			cfg.Editor = tt.editor
+
+			assert.Equal(t, tt.expected, Resolve(context.Background(), cfg, mockGit))
+		})
+	}
+}
+
+func Test_Command(t *testing.T) {
+	path := t.TempDir() + "/message.txt"
+
+	cmd := Command("printf edited >", path)
+	assert.NoError(t, cmd.Run())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", string(content))
+}
+
+func Test_TempFile(t *testing.T) {
+	path, err := WriteTempFile("feat: Add feature")
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "feat: Add feature\n")
+	assert.Contains(t, string(content), "# Please enter the commit message")
+
+	message, err := ReadTempFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "feat: Add feature", message)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = ReadTempFile(path)
+	assert.Error(t, err)
+}
+
+func Test_StripComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Success with comment lines",
+			text:     "feat: Add feature\n\n# comment\nBody line\n# another",
+			expected: "feat: Add feature\n\nBody line",
+		},
+		{
+			name:     "Success with trailing whitespace and blank lines",
+			text:     "\n\nfix: Fix bug   \n\n\n\nDetails\t\n\n",
+			expected: "fix: Fix bug\n\nDetails",
+		},
+		{
+			name:     "Success with indented hash",
+			text:     "docs: Update\n\n  # not a comment",
+			expected: "docs: Update\n\n  # not a comment",
+		},
+		{
+			name:     "Success with only comments",
+			text:     "# one\n# two\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripComments(tt.text))
+		})
+	}
+}
@@ -6,33 +6,76 @@ import (
 )
 
 var (
-	ErrAPITokenNotSet = errors.New("API token not set")
-
-	ErrFailedToReadConfig  = errors.New("failed to read config file")
-	ErrFailedToParseConfig = errors.New("failed to parse config file")
-	ErrInvalidTemperature  = errors.New("invalid temperature")
-	ErrInvalidMaxTokens    = errors.New("invalid max_tokens")
-	ErrInvalidTimeout      = errors.New("invalid timeout")
-	ErrInvalidRetryCount   = errors.New("invalid retry_count")
-	ErrInvalidCommitType   = errors.New("invalid commit type")
-	ErrMissingCommitType   = errors.New("missing required field 'type'")
-	ErrMissingCommitDesc   = errors.New("missing required field 'description'")
-
-	ErrNoResponse        = errors.New("no response from GPT")
-	ErrFailedToParseJSON = errors.New("failed to parse JSON response")
-
-	ErrFailedToLoadGitDiff = errors.New("failed to load git diff")
-	ErrFailedToLoadGitLog  = errors.New("failed to load git log")
-	ErrFailedToCommit      = errors.New("failed to commit changes")
-	ErrNoGitChanges        = errors.New("no changes to commit")
-	ErrNoGitCommits        = errors.New("no commits found")
-	ErrCommitMessageEmpty  = errors.New("commit message cannot be empty")
-	ErrUnknownCommand      = errors.New("unknown command")
+	ErrAPITokenNotSet           = errors.New("API token not set")
+	ErrFailedToRunKeyCommand    = errors.New("failed to run api.key_command")
+	ErrFailedToReadCredentials  = errors.New("failed to read credentials file")
+	ErrFailedToStoreCredentials = errors.New("failed to store API token")
+	ErrInsecureCredentials      = errors.New("credentials file is readable by other users")
+
+	ErrFailedToReadConfig     = errors.New("failed to read config file")
+	ErrFailedToParseConfig    = errors.New("failed to parse config file")
+	ErrInvalidTemperature     = errors.New("invalid temperature")
+	ErrInvalidMaxTokens       = errors.New("invalid max_tokens")
+	ErrInvalidTimeout         = errors.New("invalid timeout")
+	ErrInvalidRetryCount      = errors.New("invalid retry_count")
+	ErrInvalidTicketPattern   = errors.New("invalid ticket pattern")
+	ErrInvalidTicketPlacement = errors.New("invalid ticket placement")
+	ErrUnknownConfigKey       = errors.New("unknown config key")
+	ErrInvalidConfigValue     = errors.New("invalid config value")
+	ErrFailedToWriteConfig    = errors.New("failed to write config file")
+	ErrConfigFileExists       = errors.New("config file already exists")
+	ErrUnknownProfile         = errors.New("unknown profile")
+	ErrUntrustedConfigKey     = errors.New("not allowed in the repository config")
+	ErrInvalidSecretsMode     = errors.New("invalid secrets mode")
+	ErrInvalidMaxFileLines    = errors.New("invalid max_file_lines")
+	ErrInvalidGitBackend      = errors.New("invalid git backend")
+	ErrInvalidCommitType      = errors.New("invalid commit type")
+	ErrInvalidPackage         = errors.New("invalid package")
+	ErrUnknownPackage         = errors.New("unknown package")
+	ErrMissingCommitType      = errors.New("missing required field 'type'")
+	ErrMissingCommitDesc      = errors.New("missing required field 'description'")
+
+	ErrSecretsDetected         = errors.New("possible secrets detected")
+	ErrNoResponse              = errors.New("no response from GPT")
+	ErrFailedToParseJSON       = errors.New("failed to parse JSON response")
+	ErrMissingPullRequestTitle = errors.New("missing required field 'title'")
+
+	ErrNoChangelogSection       = errors.New("no version section in generated changelog")
+	ErrChangelogSectionExists   = errors.New("changelog already has a section for version")
+	ErrFailedToWriteChangelog   = errors.New("failed to write changelog")
+	ErrFailedToReadTemplate     = errors.New("failed to read pull request template")
+	ErrFailedToWritePullRequest = errors.New("failed to write pull request description")
+	ErrInvalidChangelogFormat   = errors.New("invalid changelog format")
+	ErrInvalidReviewFormat      = errors.New("invalid review format")
+	ErrInvalidVersion           = errors.New("invalid semantic version")
+
+	ErrFailedToLoadGitDiff   = errors.New("failed to load git diff")
+	ErrFailedToLoadGitLog    = errors.New("failed to load git log")
+	ErrFailedToLoadGitTags   = errors.New("failed to load git tags")
+	ErrFailedToCreateTag     = errors.New("failed to create tag")
+	ErrFailedToCommit        = errors.New("failed to commit changes")
+	ErrFailedToApplyPatch    = errors.New("failed to apply patch")
+	ErrPatchEmpty            = errors.New("patch cannot be empty")
+	ErrFailedToReadGitConfig = errors.New("failed to read git config")
+	ErrFailedToReadBranch    = errors.New("failed to read current branch")
+	ErrFailedToReadGitState  = errors.New("failed to read merge state")
+	ErrFailedToRewrite       = errors.New("failed to rewrite commits")
+	ErrCommitsPushed         = errors.New("commits were already pushed")
+	ErrDetachedHead          = errors.New("HEAD is detached")
+	ErrInvalidRevision       = errors.New("invalid revision")
+	ErrNoGitChanges          = errors.New("no changes to commit")
+	ErrNoGitCommits          = errors.New("no commits found")
+	ErrCommitMessageEmpty    = errors.New("commit message cannot be empty")
+	ErrUnknownCommand        = errors.New("unknown command")
+	ErrFailedToOpenRepo      = errors.New("failed to open git repository")
+	ErrUnsupportedByBackend  = errors.New("not supported by the native git backend")
 )
 
 var (
-	As  = errors.As
-	New = errors.New
+	As   = errors.As
+	Is   = errors.Is
+	Join = errors.Join
+	New  = errors.New
 )
 
 // Format returns a formatted error message
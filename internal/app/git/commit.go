@@ -0,0 +1,270 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// logFormat prints the fields of a commit separated by NUL, with -z ending each record with NUL
+const logFormat = "--format=%H%x00%P%x00%an%x00%ae%x00%aI%x00%D%x00%B"
+
+// logFields is the number of NUL separated fields printed per commit by logFormat
+const logFields = 7
+
+var (
+	// conventionalPattern matches a conventional commit header: type(scope)!: description
+	conventionalPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+	// trailerPattern matches a git trailer line such as Signed-off-by: Jane <jane@example.com>
+	trailerPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*|BREAKING CHANGE): (.*)$`)
+)
+
+// Trailer is a key/value line at the end of a commit message
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// Commit is a single commit of the history
+type Commit struct {
+	Hash     string
+	Parents  []string
+	Author   string
+	Email    string
+	Date     time.Time
+	Subject  string
+	Body     string
+	Trailers []Trailer
+	Refs     []string
+	// Message is the full message as written in the commit
+	Message string
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// IsMerge reports whether the commit has more than one parent
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Tags returns the names of the tags pointing at the commit
+func (c Commit) Tags() []string {
+	var tags []string
+	for _, ref := range c.Refs {
+		if tag, ok := strings.CutPrefix(ref, "tag: "); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Trailer returns the value of the first trailer with the given key, compared case-insensitively
+func (c Commit) Trailer(key string) (string, bool) {
+	for _, t := range c.Trailers {
+		if strings.EqualFold(t.Key, key) {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
+// Conventional parses the subject and trailers as a conventional commit
+func (c Commit) Conventional() Conventional {
+	return ParseConventional(c.Subject, c.Trailers)
+}
+
+// Conventional is the conventional commit reading of a commit message
+type Conventional struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	// BreakingNote is the text of the BREAKING CHANGE trailer, if any
+	BreakingNote string
+}
+
+// IsValid reports whether the subject follows the conventional commit format
+func (c Conventional) IsValid() bool {
+	return c.Type != ""
+}
+
+// ParseConventional parses a commit subject of the form type(scope)!: description;
+// subjects not following the format keep their text as the description
+func ParseConventional(subject string, trailers []Trailer) Conventional {
+	subject = strings.TrimSpace(subject)
+
+	var result Conventional
+	if m := conventionalPattern.FindStringSubmatch(subject); m != nil {
+		result = Conventional{
+			Type:        strings.ToLower(m[1]),
+			Scope:       m[2],
+			Description: m[4],
+			Breaking:    m[3] == "!",
+		}
+	} else {
+		result.Description = subject
+	}
+
+	for _, t := range trailers {
+		if t.Key == "BREAKING CHANGE" || t.Key == "BREAKING-CHANGE" {
+			result.Breaking = true
+			result.BreakingNote = t.Value
+			break
+		}
+	}
+
+	return result
+}
+
+// ParseMessage splits a commit message into its subject, body and trailing trailers
+func ParseMessage(message string) (string, string, []Trailer) {
+	message = strings.TrimSpace(message)
+
+	subject, rest, _ := strings.Cut(message, "\n")
+	subject = strings.TrimSpace(subject)
+	rest = strings.TrimSpace(rest)
+
+	if rest == "" {
+		return subject, "", nil
+	}
+
+	paragraphs := strings.Split(rest, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+
+	trailers, ok := parseTrailers(last)
+	if !ok {
+		return subject, rest, nil
+	}
+
+	body := strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+	return subject, body, trailers
+}
+
+// keepTrailers appends to message the trailers of original it does not already contain
+func keepTrailers(message, original string) string {
+	_, _, kept := ParseMessage(original)
+	_, _, trailers := ParseMessage(message)
+
+	var missing []string
+	for _, t := range kept {
+		if !slices.ContainsFunc(trailers, func(n Trailer) bool { return strings.EqualFold(n.Key, t.Key) && n.Value == t.Value }) {
+			missing = append(missing, t.Key+": "+t.Value)
+		}
+	}
+
+	message = strings.TrimRight(message, "\n")
+	if len(missing) == 0 {
+		return message
+	}
+
+	separator := "\n\n"
+	if len(trailers) > 0 {
+		separator = "\n"
+	}
+	return message + separator + strings.Join(missing, "\n")
+}
+
+// parseTrailers parses a paragraph made only of trailers, where indented lines continue the previous value
+func parseTrailers(paragraph string) ([]Trailer, bool) {
+	var trailers []Trailer
+
+	for _, line := range strings.Split(paragraph, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(trailers) > 0 {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+			continue
+		}
+
+		m := trailerPattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil, false
+		}
+		trailers = append(trailers, Trailer{Key: m[1], Value: strings.TrimSpace(m[2])})
+	}
+
+	return trailers, len(trailers) > 0
+}
+
+// ParseLog parses the output of git log -z with logFormat
+func ParseLog(output string) []Commit {
+	if output == "" {
+		return nil
+	}
+
+	fields := strings.Split(output, "\x00")
+
+	var commits []Commit
+	for i := 0; i+logFields <= len(fields); i += logFields {
+		record := fields[i : i+logFields]
+
+		date, _ := time.Parse(time.RFC3339, record[4])
+		subject, body, trailers := ParseMessage(record[6])
+
+		commits = append(commits, Commit{
+			Hash:     strings.TrimSpace(record[0]),
+			Parents:  strings.Fields(record[1]),
+			Author:   record[2],
+			Email:    record[3],
+			Date:     date,
+			Subject:  subject,
+			Body:     body,
+			Trailers: trailers,
+			Refs:     splitRefs(record[5]),
+			Message:  record[6],
+		})
+	}
+
+	return commits
+}
+
+// FormatLog renders commits as hash|subject|author|date lines
+func FormatLog(commits []Commit) string {
+	lines := make([]string, 0, len(commits))
+	for _, c := range commits {
+		lines = append(lines, fmt.Sprintf("%s|%s|%s|%s", c.ShortHash(), c.Subject, c.Author, c.Date.Format(time.DateOnly)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitRefs splits the %D decoration of a commit
+func splitRefs(decoration string) []string {
+	var refs []string
+	for _, ref := range strings.Split(decoration, ", ") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// FormatMessages lists the commits with their full messages for a prompt,
+// oldest first, indenting the bodies and leaving out merge commits
+func FormatMessages(commits []Commit) string {
+	var b strings.Builder
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		if c.IsMerge() {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s\n", c.ShortHash(), c.Subject)
+		if body := strings.TrimSpace(c.Body); body != "" {
+			for _, line := range strings.Split(body, "\n") {
+				if line = strings.TrimRight(line, " \t"); line != "" {
+					line = "    " + line
+				}
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
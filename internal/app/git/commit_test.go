@@ -0,0 +1,216 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []Commit
+	}{
+		{
+			name: "Success with subject containing delimiters",
+			output: "1111111111111111111111111111111111111111\x00\x00Jane Doe\x00jane@example.com\x002024-06-01T12:00:00Z\x00tag: v1.0.0\x00" +
+				"fix: Handle a|b\n\nBody with | pipes\n\nSigned-off-by: Jane Doe <jane@example.com>\n\x00",
+			expected: []Commit{
+				{
+					Hash:     "1111111111111111111111111111111111111111",
+					Parents:  []string{},
+					Author:   "Jane Doe",
+					Email:    "jane@example.com",
+					Date:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+					Subject:  "fix: Handle a|b",
+					Body:     "Body with | pipes",
+					Trailers: []Trailer{{Key: "Signed-off-by", Value: "Jane Doe <jane@example.com>"}},
+					Refs:     []string{"tag: v1.0.0"},
+					Message:  "fix: Handle a|b\n\nBody with | pipes\n\nSigned-off-by: Jane Doe <jane@example.com>\n",
+				},
+			},
+		},
+		{
+			name: "Success with merge commit",
+			output: "3333333333333333333333333333333333333333\x001111111111111111111111111111111111111111 2222222222222222222222222222222222222222\x00Jane Doe\x00jane@example.com\x002024-06-01T12:00:00Z\x00\x00" +
+				"Merge branch 'dev'\n\x00",
+			expected: []Commit{
+				{
+					Hash:    "3333333333333333333333333333333333333333",
+					Parents: []string{"1111111111111111111111111111111111111111", "2222222222222222222222222222222222222222"},
+					Author:  "Jane Doe",
+					Email:   "jane@example.com",
+					Date:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+					Subject: "Merge branch 'dev'",
+					Message: "Merge branch 'dev'\n",
+				},
+			},
+		},
+		{
+			name:     "Success with empty output",
+			output:   "",
+			expected: nil,
+		},
+		{
+			name:     "Success with truncated record",
+			output:   "1111111111111111111111111111111111111111\x00\x00Jane Doe",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseLog(tt.output))
+		})
+	}
+}
+
+func Test_ParseMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		subject  string
+		body     string
+		trailers []Trailer
+	}{
+		{
+			name:    "Success with subject only",
+			message: "feat: Add login\n",
+			subject: "feat: Add login",
+		},
+		{
+			name:    "Success with body",
+			message: "feat: Add login\n\nFirst paragraph.\n\nSecond paragraph.",
+			subject: "feat: Add login",
+			body:    "First paragraph.\n\nSecond paragraph.",
+		},
+		{
+			name:    "Success with trailers",
+			message: "feat!: Drop v1 API\n\nThe v1 endpoints are gone.\n\nBREAKING CHANGE: clients must use /v2\nRefs: JIRA-123\nCo-authored-by: John Doe <john@example.com>",
+			subject: "feat!: Drop v1 API",
+			body:    "The v1 endpoints are gone.",
+			trailers: []Trailer{
+				{Key: "BREAKING CHANGE", Value: "clients must use /v2"},
+				{Key: "Refs", Value: "JIRA-123"},
+				{Key: "Co-authored-by", Value: "John Doe <john@example.com>"},
+			},
+		},
+		{
+			name:     "Success with folded trailer",
+			message:  "fix: Retry\n\nBREAKING CHANGE: retries are now\n  enabled by default",
+			subject:  "fix: Retry",
+			trailers: []Trailer{{Key: "BREAKING CHANGE", Value: "retries are now enabled by default"}},
+		},
+		{
+			name:    "Success with last paragraph not made of trailers",
+			message: "fix: Retry\n\nNote: this is prose\nand continues here",
+			subject: "fix: Retry",
+			body:    "Note: this is prose\nand continues here",
+		},
+		{
+			name:    "Success with empty message",
+			message: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body, trailers := ParseMessage(tt.message)
+
+			assert.Equal(t, tt.subject, subject)
+			assert.Equal(t, tt.body, body)
+			assert.Equal(t, tt.trailers, trailers)
+		})
+	}
+}
+
+func Test_ParseConventional(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  string
+		trailers []Trailer
+		expected Conventional
+	}{
+		{
+			name:     "Success with type and scope",
+			subject:  "feat(api): Add endpoint",
+			expected: Conventional{Type: "feat", Scope: "api", Description: "Add endpoint"},
+		},
+		{
+			name:     "Success with type only",
+			subject:  "docs: Update readme",
+			expected: Conventional{Type: "docs", Description: "Update readme"},
+		},
+		{
+			name:     "Success with breaking marker",
+			subject:  "refactor(core)!: Rename config keys",
+			expected: Conventional{Type: "refactor", Scope: "core", Description: "Rename config keys", Breaking: true},
+		},
+		{
+			name:     "Success with breaking trailer",
+			subject:  "feat: Drop v1 API",
+			trailers: []Trailer{{Key: "Refs", Value: "JIRA-1"}, {Key: "BREAKING-CHANGE", Value: "use /v2"}},
+			expected: Conventional{Type: "feat", Description: "Drop v1 API", Breaking: true, BreakingNote: "use /v2"},
+		},
+		{
+			name:     "Success with upper-case type",
+			subject:  "Fix: Handle nil",
+			expected: Conventional{Type: "fix", Description: "Handle nil"},
+		},
+		{
+			name:     "Failure with free-form subject",
+			subject:  "Merge branch 'dev'",
+			expected: Conventional{Description: "Merge branch 'dev'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseConventional(tt.subject, tt.trailers)
+
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expected.Type != "", result.IsValid())
+		})
+	}
+}
+
+func Test_Commit_Accessors(t *testing.T) {
+	c := Commit{
+		Hash:     "1234567890abcdef1234567890abcdef12345678",
+		Parents:  []string{"a", "b"},
+		Author:   "Jane Doe",
+		Date:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Subject:  "feat(ui): Add form",
+		Trailers: []Trailer{{Key: "Refs", Value: "JIRA-1"}},
+		Refs:     []string{"HEAD -> main", "tag: v1.0.0", "origin/main", "tag: latest"},
+	}
+
+	assert.Equal(t, "1234567", c.ShortHash())
+	assert.True(t, c.IsMerge())
+	assert.Equal(t, []string{"v1.0.0", "latest"}, c.Tags())
+	assert.Equal(t, "ui", c.Conventional().Scope)
+
+	value, ok := c.Trailer("refs")
+	assert.True(t, ok)
+	assert.Equal(t, "JIRA-1", value)
+
+	_, ok = c.Trailer("Signed-off-by")
+	assert.False(t, ok)
+
+	assert.Equal(t, "1234567|feat(ui): Add form|Jane Doe|2024-06-01", FormatLog([]Commit{c}))
+}
+
+func Test_FormatMessages(t *testing.T) {
+	commits := []Commit{
+		{Hash: "ccccccc333", Subject: "Merge branch 'main'", Parents: []string{"a", "b"}},
+		{Hash: "bbbbbbb222", Subject: "fix: Handle nil", Body: "The config may be nil.\n\nCloses #12"},
+		{Hash: "aaaaaaa111", Subject: "feat: Add retry"},
+	}
+
+	expected := "aaaaaaa feat: Add retry\nbbbbbbb fix: Handle nil\n    The config may be nil.\n\n    Closes #12"
+
+	assert.Equal(t, expected, FormatMessages(commits))
+	assert.Empty(t, FormatMessages(nil))
+}
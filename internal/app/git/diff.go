@@ -0,0 +1,215 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// nullBlob is the object name git reports for the missing side of an added or deleted file
+const nullBlob = "0000000000000000000000000000000000000000"
+
+// Change describes a single staged file as reported by git diff --raw --numstat
+type Change struct {
+	Status  string
+	Path    string
+	OldPath string
+	OldMode string
+	NewMode string
+	OldBlob string
+	NewBlob string
+	Added   int
+	Deleted int
+	Binary  bool
+	OldSize int64
+	NewSize int64
+}
+
+// ParseChanges parses the NUL separated output of git diff --raw --numstat -z
+func ParseChanges(output string) []Change {
+	var changes []Change
+	index := make(map[string]int)
+
+	tokens := strings.Split(strings.TrimRight(output, "\x00"), "\x00")
+	next := func(i *int) string {
+		*i++
+		if *i < len(tokens) {
+			return tokens[*i]
+		}
+		return ""
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "" {
+			continue
+		}
+
+		if strings.HasPrefix(token, ":") {
+			fields := strings.Fields(token[1:])
+			if len(fields) < 5 {
+				continue
+			}
+
+			change := Change{
+				OldMode: fields[0],
+				NewMode: fields[1],
+				OldBlob: fields[2],
+				NewBlob: fields[3],
+				Status:  fields[4],
+				Path:    next(&i),
+			}
+			if strings.HasPrefix(change.Status, "R") || strings.HasPrefix(change.Status, "C") {
+				change.OldPath = change.Path
+				change.Path = next(&i)
+			}
+
+			index[change.Path] = len(changes)
+			changes = append(changes, change)
+			continue
+		}
+
+		parts := strings.SplitN(token, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+
+		path := parts[2]
+		if path == "" {
+			next(&i)
+			path = next(&i)
+		}
+
+		j, ok := index[path]
+		if !ok {
+			continue
+		}
+
+		if parts[0] == "-" && parts[1] == "-" {
+			changes[j].Binary = true
+			continue
+		}
+		changes[j].Added, _ = strconv.Atoi(parts[0])
+		changes[j].Deleted, _ = strconv.Atoi(parts[1])
+	}
+
+	return changes
+}
+
+// IsRename reports whether the file was moved or copied
+func (c Change) IsRename() bool {
+	return c.OldPath != ""
+}
+
+// IsLarge reports whether more lines changed than maxLines, where zero disables the limit
+func (c Change) IsLarge(maxLines int) bool {
+	return maxLines > 0 && c.Added+c.Deleted > maxLines
+}
+
+// Summary returns a one line description of a change whose content is not worth
+// sending, and false when the full diff of the file should be included instead
+func (c Change) Summary(maxLines int) (string, bool) {
+	contentChanged := c.Added+c.Deleted > 0
+
+	switch {
+	case c.Binary:
+		return fmt.Sprintf("%s: binary, %s", c.Path, c.sizes()), true
+	case c.IsLarge(maxLines):
+		return fmt.Sprintf("%s: large change, +%d -%d lines, %s", c.Path, c.Added, c.Deleted, c.sizes()), true
+	case c.IsRename() && !contentChanged:
+		verb := "renamed"
+		if strings.HasPrefix(c.Status, "C") {
+			verb = "copied"
+		}
+		return fmt.Sprintf("%s -> %s: %s without changes", c.OldPath, c.Path, verb), true
+	case c.OldMode != c.NewMode && c.OldBlob != nullBlob && c.NewBlob != nullBlob && !contentChanged:
+		return fmt.Sprintf("%s: mode %s -> %s", c.Path, c.OldMode, c.NewMode), true
+	default:
+		return "", false
+	}
+}
+
+// sizes describes the blob sizes on both sides of the change
+func (c Change) sizes() string {
+	switch {
+	case c.OldBlob == nullBlob:
+		return "added " + FormatSize(c.NewSize)
+	case c.NewBlob == nullBlob:
+		return "deleted " + FormatSize(c.OldSize)
+	default:
+		return FormatSize(c.OldSize) + " -> " + FormatSize(c.NewSize)
+	}
+}
+
+// FormatSize renders a byte count with a binary unit suffix
+func FormatSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%dB", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%dKB", (size+512)/1024)
+	default:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1024*1024))
+	}
+}
+
+// summarize splits changes into one line summaries and the changes whose full diff is kept
+func summarize(changes []Change, maxLines int) ([]string, []Change) {
+	var summaries []string
+	var kept []Change
+
+	for _, change := range changes {
+		if summary, ok := change.Summary(maxLines); ok {
+			summaries = append(summaries, summary)
+			continue
+		}
+		kept = append(kept, change)
+	}
+
+	return summaries, kept
+}
+
+// pathspecs returns the paths selecting the changes, including the source of renames
+func pathspecs(changes []Change) []string {
+	var paths []string
+	for _, change := range changes {
+		if change.IsRename() {
+			paths = append(paths, change.OldPath)
+		}
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
+
+// joinDiff assembles the stat overview, the summaries and the diff content sent to the model
+func joinDiff(stat string, summaries []string, content string) string {
+	sections := []string{strings.TrimRight(stat, "\n")}
+	if len(summaries) > 0 {
+		sections = append(sections, strings.Join(summaries, "\n"))
+	}
+	if content = strings.TrimSpace(content); content != "" {
+		sections = append(sections, content)
+	}
+
+	return strings.Trim(strings.Join(sections, "\n\n"), "\n")
+}
+
+// parseSizes parses the output of git cat-file --batch-check into sizes keyed by object name
+func parseSizes(output string) map[string]int64 {
+	sizes := make(map[string]int64)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		sizes[fields[0]] = size
+	}
+
+	return sizes
+}
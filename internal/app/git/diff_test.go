@@ -0,0 +1,137 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []Change
+	}{
+		{
+			name:     "Success with empty output",
+			output:   "",
+			expected: nil,
+		},
+		{
+			name:   "Success with modified text file",
+			output: ":100644 100644 aaa bbb M\x00main.go\x003\t1\tmain.go\x00",
+			expected: []Change{
+				{Status: "M", Path: "main.go", OldMode: "100644", NewMode: "100644", OldBlob: "aaa", NewBlob: "bbb", Added: 3, Deleted: 1},
+			},
+		},
+		{
+			name:   "Success with binary file",
+			output: ":000000 100644 " + nullBlob + " bbb A\x00logo.png\x00-\t-\tlogo.png\x00",
+			expected: []Change{
+				{Status: "A", Path: "logo.png", OldMode: "000000", NewMode: "100644", OldBlob: nullBlob, NewBlob: "bbb", Binary: true},
+			},
+		},
+		{
+			name:   "Success with rename",
+			output: ":100644 100644 aaa bbb R087\x00old/a.go\x00new/a.go\x002\t2\t\x00old/a.go\x00new/a.go\x00",
+			expected: []Change{
+				{Status: "R087", Path: "new/a.go", OldPath: "old/a.go", OldMode: "100644", NewMode: "100644", OldBlob: "aaa", NewBlob: "bbb", Added: 2, Deleted: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseChanges(tt.output))
+		})
+	}
+}
+
+func Test_Change_Summary(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   Change
+		maxLines int
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "Success with binary change",
+			change:   Change{Path: "image.png", OldBlob: "aaa", NewBlob: "bbb", Binary: true, OldSize: 34 * 1024, NewSize: 41 * 1024},
+			expected: "image.png: binary, 34KB -> 41KB",
+			ok:       true,
+		},
+		{
+			name:     "Success with added binary",
+			change:   Change{Path: "image.png", OldBlob: nullBlob, NewBlob: "bbb", Binary: true, NewSize: 512},
+			expected: "image.png: binary, added 512B",
+			ok:       true,
+		},
+		{
+			name:     "Success with large change",
+			change:   Change{Path: "data.json", OldBlob: "aaa", NewBlob: "bbb", Added: 1200, Deleted: 30, OldSize: 2 * 1024 * 1024, NewSize: 3 * 1024 * 1024},
+			maxLines: 500,
+			expected: "data.json: large change, +1200 -30 lines, 2.0MB -> 3.0MB",
+			ok:       true,
+		},
+		{
+			name:     "Success with large change when limit is disabled",
+			change:   Change{Path: "data.json", OldBlob: "aaa", NewBlob: "bbb", Added: 1200},
+			maxLines: 0,
+			ok:       false,
+		},
+		{
+			name:     "Success with pure rename",
+			change:   Change{Status: "R100", Path: "new.go", OldPath: "old.go", OldMode: "100644", NewMode: "100644", OldBlob: "aaa", NewBlob: "aaa"},
+			expected: "old.go -> new.go: renamed without changes",
+			ok:       true,
+		},
+		{
+			name:   "Success with rename and edits",
+			change: Change{Status: "R090", Path: "new.go", OldPath: "old.go", OldBlob: "aaa", NewBlob: "bbb", Added: 1, Deleted: 1},
+			ok:     false,
+		},
+		{
+			name:     "Success with mode change",
+			change:   Change{Status: "M", Path: "run.sh", OldMode: "100644", NewMode: "100755", OldBlob: "aaa", NewBlob: "aaa"},
+			expected: "run.sh: mode 100644 -> 100755",
+			ok:       true,
+		},
+		{
+			name:   "Success with regular edit",
+			change: Change{Status: "M", Path: "main.go", OldMode: "100644", NewMode: "100644", OldBlob: "aaa", NewBlob: "bbb", Added: 3},
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, ok := tt.change.Summary(tt.maxLines)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, summary)
+		})
+	}
+}
+
+func Test_FormatSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int64
+		expected string
+	}{
+		{name: "Success with bytes", size: 900, expected: "900B"},
+		{name: "Success with kilobytes", size: 34 * 1024, expected: "34KB"},
+		{name: "Success with megabytes", size: 5 * 1024 * 1024 / 2, expected: "2.5MB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatSize(tt.size))
+		})
+	}
+}
+
+func Test_ParseSizes(t *testing.T) {
+	sizes := parseSizes("aaa blob 10\nbbb blob 20\nccc missing\n")
+	assert.Equal(t, map[string]int64{"aaa": 10, "bbb": 20}, sizes)
+}
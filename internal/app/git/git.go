@@ -3,64 +3,211 @@ package git
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"cmt/internal/app/errors"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
+// BackupRefPrefix is the namespace of the refs keeping the commits replaced by a rewrite
+const BackupRefPrefix = "refs/cmt/backup/"
+
+// originHead is the symbolic ref naming the default branch of the origin remote
+const originHead = "refs/remotes/origin/HEAD"
+
 // Client represents a git client interface
 type Client interface {
 	Diff(ctx context.Context) (string, error)
+	BranchDiff(ctx context.Context, base string) (string, error)
+	CommitDiff(ctx context.Context, hash string) (string, error)
 	Status(ctx context.Context) (string, error)
-	Log(ctx context.Context, opts []string) (string, error)
+	UnstagedStatus(ctx context.Context) (string, error)
+	Log(ctx context.Context, opts []string) ([]Commit, error)
+	Tags(ctx context.Context) ([]string, error)
+	CreateTag(ctx context.Context, name, message string) error
 	Commit(ctx context.Context, message string) (string, error)
+	FileDiff(ctx context.Context, path string, staged bool) (string, error)
+	ApplyPatch(ctx context.Context, patch string, reverse bool) error
+	ConfigValue(ctx context.Context, key string) (string, error)
+	CurrentBranch(ctx context.Context) (string, error)
+	DefaultBranch(ctx context.Context) (string, error)
+	Operation(ctx context.Context) (Operation, error)
+	Pushed(ctx context.Context, base string) (bool, error)
+	Rewrite(ctx context.Context, base string, messages map[string]string) (string, error)
 }
 
 // client implements the git client interface
 type client struct {
 	executor Executor
+	cfg      *config.Config
 	log      logger.Logger
 }
 
-// NewGitClient creates a new git client
+// NewClient creates the git client of the configured backend
+func NewClient(
+	executor Executor,
+	cfg *config.Config,
+	log logger.Logger,
+) Client {
+	if cfg != nil && cfg.Git.Backend == config.GitBackendNative {
+		return OpenNativeClient(cfg, log)
+	}
+	return NewGitClient(executor, cfg, log)
+}
+
+// NewGitClient creates a new git client backed by the git binary
 func NewGitClient(
 	executor Executor,
+	cfg *config.Config,
 	log logger.Logger,
 ) Client {
 	return &client{
 		executor: executor,
+		cfg:      cfg,
 		log:      log,
 	}
 }
 
-// Diff returns the git diff
+// Diff returns the staged changes prepared for the model: a --stat overview,
+// one line summaries of binary, very large, renamed and mode-only changes, and
+// the minimal diff of every other file
 func (g *client) Diff(ctx context.Context) (string, error) {
-	args := []string{"diff", "--staged", "--minimal", "--ignore-all-space", "--ignore-blank-lines"}
+	return g.diff(ctx, "--staged")
+}
 
-	g.log.Debug().Strs("args", args).Msg("Running git diff command")
-	cmd := g.executor.Run(ctx, "git", args...)
+// BranchDiff returns the changes of HEAD since it diverged from base, as in
+// git diff base...HEAD, prepared for the model like the staged diff
+func (g *client) BranchDiff(ctx context.Context, base string) (string, error) {
+	return g.diff(ctx, base+"...HEAD")
+}
 
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &out
+// CommitDiff returns the changes of a commit against its first parent,
+// prepared for the model like the staged diff
+func (g *client) CommitDiff(ctx context.Context, hash string) (string, error) {
+	parent := hash + "^"
+	if _, err := g.output(ctx, nil, "rev-parse", "--verify", "--quiet", parent); err != nil {
+		// A root commit is compared with the empty tree
+		tree, err := g.output(ctx, strings.NewReader(""), "hash-object", "-t", "tree", "--stdin")
+		if err != nil {
+			g.log.Error().Err(err).Msg("Failed to resolve the empty tree")
+			return "", errors.ErrFailedToLoadGitDiff
+		}
+		parent = strings.TrimSpace(tree)
+	}
+	return g.diff(ctx, parent+".."+hash)
+}
 
-	if err := cmd.Run(); err != nil {
+// diff loads the changes selected by the revision argument of git diff
+func (g *client) diff(ctx context.Context, revision string) (string, error) {
+	raw, err := g.output(ctx, nil, "diff", revision, "--raw", "--numstat", "-M", "--no-abbrev", "-z")
+	if err != nil {
 		g.log.Error().Err(err).Msg("Failed to execute git diff command")
 		return "", errors.ErrFailedToLoadGitDiff
 	}
 
-	result := strings.TrimSpace(out.String())
-	if result == "" {
+	changes := ParseChanges(raw)
+	if len(changes) == 0 {
 		return "", errors.ErrNoGitChanges
 	}
 
-	g.log.Debug().Int("diff_length", len(result)).Msg("Git diff loaded successfully")
+	g.loadSizes(ctx, changes)
+
+	summaries, kept := summarize(changes, maxFileLines(g.cfg))
+
+	stat, err := g.output(ctx, nil, "diff", revision, "--stat", "-M")
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to execute git diff --stat command")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	var content string
+	if len(kept) > 0 {
+		// Pathspecs are literal so that file names holding glob characters only match themselves
+		args := append([]string{"--literal-pathspecs", "diff", revision, "--minimal", "--ignore-all-space", "--ignore-blank-lines", "-M", "--"}, pathspecs(kept)...)
+
+		content, err = g.output(ctx, nil, args...)
+		if err != nil {
+			g.log.Error().Err(err).Msg("Failed to execute git diff command")
+			return "", errors.ErrFailedToLoadGitDiff
+		}
+	}
+
+	result := joinDiff(stat, summaries, content)
+
+	g.log.Debug().
+		Int("files", len(changes)).
+		Int("summarized", len(summaries)).
+		Int("diff_length", len(result)).
+		Msg("Git diff loaded successfully")
 	return result, nil
 }
 
+// loadSizes fills in the blob sizes of the binary and large changes, which
+// are only used for their summaries, so failures are logged and ignored
+func (g *client) loadSizes(ctx context.Context, changes []Change) {
+	maxLines := maxFileLines(g.cfg)
+
+	var blobs []string
+	for _, change := range changes {
+		if !change.Binary && !change.IsLarge(maxLines) {
+			continue
+		}
+		for _, blob := range []string{change.OldBlob, change.NewBlob} {
+			if blob != nullBlob {
+				blobs = append(blobs, blob)
+			}
+		}
+	}
+
+	if len(blobs) == 0 {
+		return
+	}
+
+	out, err := g.output(ctx, strings.NewReader(strings.Join(blobs, "\n")+"\n"), "cat-file", "--batch-check")
+	if err != nil {
+		g.log.Warn().Err(err).Msg("Failed to read blob sizes")
+		return
+	}
+
+	sizes := parseSizes(out)
+	for i := range changes {
+		changes[i].OldSize = sizes[changes[i].OldBlob]
+		changes[i].NewSize = sizes[changes[i].NewBlob]
+	}
+}
+
+// maxFileLines returns the number of changed lines above which a file is summarized
+func maxFileLines(cfg *config.Config) int {
+	if cfg == nil {
+		return config.DefaultDiffMaxFileLines
+	}
+	return cfg.Diff.MaxFileLines
+}
+
+// output runs a git command and returns its standard output
+func (g *client) output(ctx context.Context, stdin io.Reader, args ...string) (string, error) {
+	g.log.Debug().Strs("args", args).Msg("Running git command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out, errOut bytes.Buffer
+	cmd.Stdin = stdin
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(errOut.String()))
+	}
+
+	return out.String(), nil
+}
+
 // Status returns the git status for staged files
 func (g *client) Status(ctx context.Context) (string, error) {
 	args := []string{"diff", "--staged", "--name-status"}
@@ -86,9 +233,31 @@ func (g *client) Status(ctx context.Context) (string, error) {
 	return result, nil
 }
 
-// Log returns the git log with detailed format: hash|subject|author|date
-func (g *client) Log(ctx context.Context, opts []string) (string, error) {
-	args := []string{"log", "--format=%h|%s|%an|%ar"}
+// UnstagedStatus returns the tracked files with unstaged changes in
+// git diff --name-status format, or an empty string when there are none
+func (g *client) UnstagedStatus(ctx context.Context) (string, error) {
+	args := []string{"diff", "--name-status"}
+
+	g.log.Debug().Strs("args", args).Msg("Running git status command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		g.log.Error().Err(err).Msg("Failed to execute git status command")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	result := strings.TrimSpace(out.String())
+	g.log.Debug().Int("status_length", len(result)).Msg("Git unstaged status loaded successfully")
+	return result, nil
+}
+
+// Log returns the commits selected by the git log options, newest first
+func (g *client) Log(ctx context.Context, opts []string) ([]Commit, error) {
+	args := []string{"log", "-z", logFormat}
 	args = append(args, opts...)
 
 	g.log.Debug().Strs("args", args).Msg("Running git log command")
@@ -96,21 +265,51 @@ func (g *client) Log(ctx context.Context, opts []string) (string, error) {
 
 	var out bytes.Buffer
 	cmd.Stdout = &out
-	cmd.Stderr = &out
 
 	if err := cmd.Run(); err != nil {
 		g.log.Error().Err(err).Msg("Failed to execute git log command")
-		return "", errors.ErrFailedToLoadGitLog
+		return nil, errors.ErrFailedToLoadGitLog
 	}
 
-	result := strings.TrimSpace(out.String())
-	if result == "" {
+	commits := ParseLog(out.String())
+	if len(commits) == 0 {
 		g.log.Info().Msg("No git commits found")
-		return "", errors.ErrNoGitCommits
+		return nil, errors.ErrNoGitCommits
 	}
 
-	g.log.Debug().Int("log_length", len(result)).Msg("Git log loaded successfully")
-	return result, nil
+	g.log.Debug().Int("commits", len(commits)).Msg("Git log loaded successfully")
+	return commits, nil
+}
+
+// Tags returns the names of the tags reachable from HEAD
+func (g *client) Tags(ctx context.Context) ([]string, error) {
+	args := []string{"tag", "--list", "--merged", "HEAD"}
+
+	g.log.Debug().Strs("args", args).Msg("Running git tag command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		g.log.Error().Err(err).Msg("Failed to execute git tag command")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+
+	tags := strings.Fields(out.String())
+	g.log.Debug().Int("tags", len(tags)).Msg("Git tags loaded successfully")
+	return tags, nil
+}
+
+// CreateTag creates an annotated tag on HEAD with the given message
+func (g *client) CreateTag(ctx context.Context, name, message string) error {
+	if _, err := g.output(ctx, strings.NewReader(message), "tag", "--annotate", "--file=-", name); err != nil {
+		g.log.Error().Err(err).Str("tag", name).Msg("Failed to execute git tag command")
+		return fmt.Errorf("%w: %s", errors.ErrFailedToCreateTag, name)
+	}
+
+	g.log.Debug().Str("tag", name).Msg("Tag created successfully")
+	return nil
 }
 
 // Commit commits the staged git changes
@@ -137,3 +336,319 @@ func (g *client) Commit(ctx context.Context, message string) (string, error) {
 	g.log.Debug().Msg("Successfully committed changes")
 	return result, nil
 }
+
+// FileDiff returns the staged or unstaged diff of a single file
+func (g *client) FileDiff(ctx context.Context, path string, staged bool) (string, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff"}
+	if staged {
+		args = append(args, "--staged")
+	}
+	args = append(args, "--", path)
+
+	g.log.Debug().Strs("args", args).Msg("Running git file diff command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out, errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		g.log.Error().Err(err).Str("stderr", errOut.String()).Msg("Failed to execute git file diff command")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	result := out.String()
+	g.log.Debug().Str("path", path).Bool("staged", staged).Int("diff_length", len(result)).Msg("Git file diff loaded successfully")
+	return result, nil
+}
+
+// ApplyPatch applies a patch to the index, or removes it from the index when reverse is set
+func (g *client) ApplyPatch(ctx context.Context, patch string, reverse bool) error {
+	if strings.TrimSpace(patch) == "" {
+		g.log.Error().Msg("Patch is empty")
+		return errors.ErrPatchEmpty
+	}
+
+	args := []string{"apply", "--cached", "--whitespace=nowarn"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	args = append(args, "-")
+
+	g.log.Debug().Strs("args", args).Msg("Running git apply command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var errOut bytes.Buffer
+	cmd.Stdin = strings.NewReader(patch)
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		g.log.Error().Err(err).Str("stderr", errOut.String()).Msg("Failed to execute git apply command")
+		return errors.ErrFailedToApplyPatch
+	}
+
+	g.log.Debug().Bool("reverse", reverse).Msg("Patch applied successfully")
+	return nil
+}
+
+// ConfigValue returns the value of a git config key, or an empty string when it is not set
+func (g *client) ConfigValue(ctx context.Context, key string) (string, error) {
+	args := []string{"config", "--get", key}
+
+	g.log.Debug().Strs("args", args).Msg("Running git config command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			g.log.Debug().Str("key", key).Msg("Git config key is not set")
+			return "", nil
+		}
+
+		g.log.Error().Err(err).Msg("Failed to execute git config command")
+		return "", errors.ErrFailedToReadGitConfig
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// CurrentBranch returns the short name of the checked out branch
+func (g *client) CurrentBranch(ctx context.Context) (string, error) {
+	args := []string{"symbolic-ref", "--quiet", "--short", "HEAD"}
+
+	g.log.Debug().Strs("args", args).Msg("Running git symbolic-ref command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			g.log.Debug().Msg("HEAD is detached")
+			return "", errors.ErrDetachedHead
+		}
+
+		g.log.Error().Err(err).Msg("Failed to execute git symbolic-ref command")
+		return "", errors.ErrFailedToReadBranch
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// DefaultBranch returns the branch origin/HEAD points at, or an empty string
+// when the remote HEAD is not known
+func (g *client) DefaultBranch(ctx context.Context) (string, error) {
+	args := []string{"symbolic-ref", "--quiet", "--short", originHead}
+
+	g.log.Debug().Strs("args", args).Msg("Running git symbolic-ref command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			g.log.Debug().Msg("Remote HEAD is not set")
+			return "", nil
+		}
+
+		g.log.Error().Err(err).Msg("Failed to execute git symbolic-ref command")
+		return "", errors.ErrFailedToReadBranch
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(out.String()), "origin/"), nil
+}
+
+// Operation returns the merge, squash or revert that the staged changes conclude
+func (g *client) Operation(ctx context.Context) (Operation, error) {
+	out, err := g.output(ctx, nil, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to locate git directory")
+		return Operation{}, errors.ErrFailedToReadGitState
+	}
+
+	dir := strings.TrimSpace(out)
+	op := parseOperation(func(name string) (string, bool) {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		return string(content), err == nil
+	})
+	if op.Kind == OperationRevert {
+		op.Unlisted = g.revertUnlisted(ctx, op.Heads)
+	}
+
+	g.log.Debug().Str("operation", string(op.Kind)).Int("heads", len(op.Heads)).Msg("Git operation detected")
+	return op, nil
+}
+
+// revertUnlisted reports whether the staged changes touch files that the
+// reverted commits do not change. Failures are logged and ignored
+func (g *client) revertUnlisted(ctx context.Context, heads []string) bool {
+	staged, err := g.output(ctx, nil, "diff", "--cached", "--name-only", "-z")
+	if err != nil {
+		g.log.Debug().Err(err).Msg("Failed to list staged files")
+		return false
+	}
+
+	var reverted []string
+	for _, head := range heads {
+		files, err := g.output(ctx, nil, "diff-tree", "--no-commit-id", "--name-only", "-r", "-z", "--root", head)
+		if err != nil {
+			g.log.Debug().Err(err).Str("hash", head).Msg("Failed to list reverted files")
+			return false
+		}
+		reverted = append(reverted, strings.Split(files, "\x00")...)
+	}
+
+	return unlistedPaths(strings.Split(staged, "\x00"), reverted)
+}
+
+// Pushed reports whether any commit of base..HEAD is reachable from a remote-tracking branch
+func (g *client) Pushed(ctx context.Context, base string) (bool, error) {
+	total, err := g.output(ctx, nil, "rev-list", "--count", base+"..HEAD")
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to count commits")
+		return false, errors.ErrFailedToLoadGitLog
+	}
+
+	local, err := g.output(ctx, nil, "rev-list", "--count", base+"..HEAD", "--not", "--remotes")
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to count unpushed commits")
+		return false, errors.ErrFailedToLoadGitLog
+	}
+
+	return strings.TrimSpace(total) != strings.TrimSpace(local), nil
+}
+
+// Rewrite replaces the messages of the commits of base..HEAD given by hash,
+// recreates the commits following them with the same trees and authors, and
+// moves HEAD to the result. The previous HEAD is kept in the returned backup
+// ref, and commits that were pushed are never rewritten
+func (g *client) Rewrite(ctx context.Context, base string, messages map[string]string) (string, error) {
+	pushed, err := g.Pushed(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	if pushed {
+		return "", errors.ErrCommitsPushed
+	}
+
+	head, err := g.output(ctx, nil, "rev-parse", "HEAD")
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to resolve HEAD")
+		return "", errors.ErrFailedToRewrite
+	}
+	head = strings.TrimSpace(head)
+
+	list, err := g.output(ctx, nil, "rev-list", "--reverse", "--topo-order", "--parents", base+"..HEAD")
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to list commits to rewrite")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	rewritten := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(list), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		hash, parents := fields[0], fields[1:]
+		changed := false
+		for i, p := range parents {
+			if n, ok := rewritten[p]; ok {
+				parents[i] = n
+				changed = true
+			}
+		}
+
+		message, reword := messages[hash]
+		if !changed && !reword {
+			continue
+		}
+
+		n, err := g.recommit(ctx, hash, parents, message)
+		if err != nil {
+			g.log.Error().Err(err).Str("hash", hash).Msg("Failed to rewrite commit")
+			return "", errors.ErrFailedToRewrite
+		}
+		rewritten[hash] = n
+	}
+
+	newHead, ok := rewritten[head]
+	if !ok {
+		return "", nil
+	}
+
+	backup := backupRef(ctx, g, head)
+	if _, err := g.output(ctx, nil, "update-ref", backup, head, ""); err != nil {
+		g.log.Error().Err(err).Str("ref", backup).Msg("Failed to create backup ref")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	if _, err := g.output(ctx, nil, "update-ref", "-m", "cmt reword", "HEAD", newHead, head); err != nil {
+		g.log.Error().Err(err).Msg("Failed to move HEAD to the rewritten commits")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	g.log.Debug().Int("rewritten", len(rewritten)).Str("backup", backup).Msg("Commits rewritten successfully")
+	return backup, nil
+}
+
+// recommit creates a copy of a commit with new parents and, unless empty, a
+// new message, keeping its tree, author and trailers
+func (g *client) recommit(ctx context.Context, hash string, parents []string, message string) (string, error) {
+	out, err := g.output(ctx, nil, "log", "-1", "--date=raw", "--format=%T%x00%an%x00%ae%x00%ad%x00%B", hash)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.SplitN(out, "\x00", 5)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("unexpected git log output for %s", hash)
+	}
+	if message == "" {
+		message = fields[4]
+	} else {
+		message = keepTrailers(message, fields[4])
+	}
+
+	args := []string{"commit-tree", fields[0]}
+	for _, p := range parents {
+		args = append(args, "-p", p)
+	}
+	args = append(args, "-F", "-")
+
+	g.log.Debug().Strs("args", args).Msg("Running git commit-tree command")
+	cmd := g.executor.Run(ctx, "git", args...)
+
+	var stdout, errOut bytes.Buffer
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+fields[1],
+		"GIT_AUTHOR_EMAIL="+fields[2],
+		"GIT_AUTHOR_DATE="+fields[3],
+	)
+	cmd.Stdin = strings.NewReader(strings.TrimRight(message, "\n") + "\n")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(errOut.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// backupRef returns a new ref name for the HEAD replaced by a rewrite, such
+// as refs/cmt/backup/feature/1700000000-1a2b3c4
+func backupRef(ctx context.Context, g Client, head string) string {
+	branch, err := g.CurrentBranch(ctx)
+	if err != nil {
+		branch = "HEAD"
+	}
+	return fmt.Sprintf("%s%s/%d-%s", BackupRefPrefix, branch, time.Now().Unix(), Commit{Hash: head}.ShortHash())
+}
@@ -40,6 +40,35 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// ApplyPatch mocks base method.
+func (m *MockClient) ApplyPatch(ctx context.Context, patch string, reverse bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPatch", ctx, patch, reverse)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPatch indicates an expected call of ApplyPatch.
+func (mr *MockClientMockRecorder) ApplyPatch(ctx, patch, reverse any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPatch", reflect.TypeOf((*MockClient)(nil).ApplyPatch), ctx, patch, reverse)
+}
+
+// BranchDiff mocks base method.
+func (m *MockClient) BranchDiff(ctx context.Context, base string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchDiff", ctx, base)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchDiff indicates an expected call of BranchDiff.
+func (mr *MockClientMockRecorder) BranchDiff(ctx, base any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchDiff", reflect.TypeOf((*MockClient)(nil).BranchDiff), ctx, base)
+}
+
 // Commit mocks base method.
 func (m *MockClient) Commit(ctx context.Context, message string) (string, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +84,80 @@ func (mr *MockClientMockRecorder) Commit(ctx, message any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockClient)(nil).Commit), ctx, message)
 }
 
+// CommitDiff mocks base method.
+func (m *MockClient) CommitDiff(ctx context.Context, hash string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitDiff", ctx, hash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitDiff indicates an expected call of CommitDiff.
+func (mr *MockClientMockRecorder) CommitDiff(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitDiff", reflect.TypeOf((*MockClient)(nil).CommitDiff), ctx, hash)
+}
+
+// ConfigValue mocks base method.
+func (m *MockClient) ConfigValue(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigValue", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigValue indicates an expected call of ConfigValue.
+func (mr *MockClientMockRecorder) ConfigValue(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigValue", reflect.TypeOf((*MockClient)(nil).ConfigValue), ctx, key)
+}
+
+// CreateTag mocks base method.
+func (m *MockClient) CreateTag(ctx context.Context, name, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, name, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockClientMockRecorder) CreateTag(ctx, name, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockClient)(nil).CreateTag), ctx, name, message)
+}
+
+// CurrentBranch mocks base method.
+func (m *MockClient) CurrentBranch(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentBranch", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentBranch indicates an expected call of CurrentBranch.
+func (mr *MockClientMockRecorder) CurrentBranch(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentBranch", reflect.TypeOf((*MockClient)(nil).CurrentBranch), ctx)
+}
+
+// DefaultBranch mocks base method.
+func (m *MockClient) DefaultBranch(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DefaultBranch", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefaultBranch indicates an expected call of DefaultBranch.
+func (mr *MockClientMockRecorder) DefaultBranch(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultBranch", reflect.TypeOf((*MockClient)(nil).DefaultBranch), ctx)
+}
+
 // Diff mocks base method.
 func (m *MockClient) Diff(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -70,11 +173,26 @@ func (mr *MockClientMockRecorder) Diff(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockClient)(nil).Diff), ctx)
 }
 
+// FileDiff mocks base method.
+func (m *MockClient) FileDiff(ctx context.Context, path string, staged bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileDiff", ctx, path, staged)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileDiff indicates an expected call of FileDiff.
+func (mr *MockClientMockRecorder) FileDiff(ctx, path, staged any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileDiff", reflect.TypeOf((*MockClient)(nil).FileDiff), ctx, path, staged)
+}
+
 // Log mocks base method.
-func (m *MockClient) Log(ctx context.Context, opts []string) (string, error) {
+func (m *MockClient) Log(ctx context.Context, opts []string) ([]Commit, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Log", ctx, opts)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].([]Commit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -85,6 +203,51 @@ func (mr *MockClientMockRecorder) Log(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockClient)(nil).Log), ctx, opts)
 }
 
+// Operation mocks base method.
+func (m *MockClient) Operation(ctx context.Context) (Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Operation", ctx)
+	ret0, _ := ret[0].(Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Operation indicates an expected call of Operation.
+func (mr *MockClientMockRecorder) Operation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Operation", reflect.TypeOf((*MockClient)(nil).Operation), ctx)
+}
+
+// Pushed mocks base method.
+func (m *MockClient) Pushed(ctx context.Context, base string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pushed", ctx, base)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pushed indicates an expected call of Pushed.
+func (mr *MockClientMockRecorder) Pushed(ctx, base any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pushed", reflect.TypeOf((*MockClient)(nil).Pushed), ctx, base)
+}
+
+// Rewrite mocks base method.
+func (m *MockClient) Rewrite(ctx context.Context, base string, messages map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewrite", ctx, base, messages)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rewrite indicates an expected call of Rewrite.
+func (mr *MockClientMockRecorder) Rewrite(ctx, base, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewrite", reflect.TypeOf((*MockClient)(nil).Rewrite), ctx, base, messages)
+}
+
 // Status mocks base method.
 func (m *MockClient) Status(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -99,3 +262,33 @@ func (mr *MockClientMockRecorder) Status(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockClient)(nil).Status), ctx)
 }
+
+// Tags mocks base method.
+func (m *MockClient) Tags(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockClientMockRecorder) Tags(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockClient)(nil).Tags), ctx)
+}
+
+// UnstagedStatus mocks base method.
+func (m *MockClient) UnstagedStatus(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnstagedStatus", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnstagedStatus indicates an expected call of UnstagedStatus.
+func (mr *MockClientMockRecorder) UnstagedStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnstagedStatus", reflect.TypeOf((*MockClient)(nil).UnstagedStatus), ctx)
+}
@@ -3,14 +3,19 @@ package git
 import (
 	"bytes"
 	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"cmt/internal/app/errors"
+	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
 
@@ -23,6 +28,14 @@ func fakeCommandWithOutput(output string) func(context.Context, string, ...strin
 	}
 }
 
+// fakeCommandWithNulOutput returns a function that creates an exec.Cmd that outputs the given
+// string, which may contain NUL bytes but no printf directives.
+func fakeCommandWithNulOutput(output string) func(context.Context, string, ...string) *exec.Cmd {
+	return func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.Command("printf", strings.ReplaceAll(output, "\x00", `\000`))
+	}
+}
+
 // fakeFailingCommand returns a function that creates an exec.Cmd that fails.
 func fakeFailingCommand() func(context.Context, string, ...string) *exec.Cmd {
 	return func(ctx context.Context, name string, args ...string) *exec.Cmd {
@@ -50,7 +63,7 @@ func Test_NewGitClient(t *testing.T) {
 	mockExecutor := NewMockExecutor(ctrl)
 	mockLogger := logger.NewMockLogger(ctrl)
 
-	gitClient := NewGitClient(mockExecutor, mockLogger)
+	gitClient := NewGitClient(mockExecutor, config.DefaultConfig(), mockLogger)
 	assert.NotNil(t, gitClient)
 
 	instance, ok := gitClient.(*client)
@@ -72,13 +85,19 @@ func Test_Diff(t *testing.T) {
 	// Allow any logger calls since we're testing git functionality, not logging
 	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
 	mockLogger.EXPECT().Error().AnyTimes().Return(nopLogger.Error())
+	mockLogger.EXPECT().Warn().AnyTimes().Return(nopLogger.Warn())
 	mockLogger.EXPECT().Info().AnyTimes().Return(nopLogger.Info())
 
 	gitClient := &client{
 		executor: mockExecutor,
+		cfg:      config.DefaultConfig(),
 		log:      mockLogger,
 	}
 
+	rawArgs := []any{"diff", "--staged", "--raw", "--numstat", "-M", "--no-abbrev", "-z"}
+	statArgs := []any{"diff", "--staged", "--stat", "-M"}
+	contentArgs := []any{"--literal-pathspecs", "diff", "--staged", "--minimal", "--ignore-all-space", "--ignore-blank-lines", "-M", "--"}
+
 	type result struct {
 		output string
 		err    error
@@ -90,42 +109,93 @@ func Test_Diff(t *testing.T) {
 		expected result
 	}{
 		{
-			name: "Success",
+			name: "Success with content and binary summary",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "diff", "--staged", "--minimal", "--ignore-all-space", "--ignore-blank-lines").
-					Return(nil).
-					DoAndReturn(fakeCommandWithOutput("mock diff output"))
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeCommandWithNulOutput(":100644 100644 aaa bbb M\x00main.go\x00:100644 100644 ccc ddd M\x00logo.png\x001\t0\tmain.go\x00-\t-\tlogo.png\x00"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "cat-file", "--batch-check").
+					DoAndReturn(fakeCommandWithOutput("ccc blob 34816\nddd blob 41984\n"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", statArgs...).
+					DoAndReturn(fakeCommandWithOutput(" main.go  | 1 +\n logo.png | Bin\n"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", append(contentArgs, "main.go")...).
+					DoAndReturn(fakeCommandWithOutput("diff --git a/main.go b/main.go\n+package main\n"))
 			},
 			expected: result{
-				output: "mock diff output",
-				err:    nil,
+				output: " main.go  | 1 +\n logo.png | Bin\n\nlogo.png: binary, 34KB -> 41KB\n\ndiff --git a/main.go b/main.go\n+package main",
+			},
+		},
+		{
+			name: "Success with only summaries",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeCommandWithNulOutput(":100644 100644 aaa aaa R100\x00old.go\x00new.go\x000\t0\t\x00old.go\x00new.go\x00"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", statArgs...).
+					DoAndReturn(fakeCommandWithOutput(" old.go => new.go | 0\n"))
+			},
+			expected: result{
+				output: " old.go => new.go | 0\n\nold.go -> new.go: renamed without changes",
+			},
+		},
+		{
+			name: "Success with glob characters in the path",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeCommandWithNulOutput(":100644 100644 aaa bbb M\x00main[1].go\x001\t0\tmain[1].go\x00"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", statArgs...).
+					DoAndReturn(fakeCommandWithOutput(" main[1].go | 1 +\n"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", append(contentArgs, "main[1].go")...).
+					DoAndReturn(fakeCommandWithOutput("diff --git a/main[1].go b/main[1].go\n+package main\n"))
+			},
+			expected: result{
+				output: " main[1].go | 1 +\n\ndiff --git a/main[1].go b/main[1].go\n+package main",
 			},
 		},
 		{
 			name: "Failure when diff command fails",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "diff", "--staged", "--minimal", "--ignore-all-space", "--ignore-blank-lines").
-					Return(nil).
+					Run(gomock.Any(), "git", rawArgs...).
 					DoAndReturn(fakeFailingCommand())
 			},
 			expected: result{
-				output: "",
-				err:    errors.New("failed to load git diff"),
+				err: errors.ErrFailedToLoadGitDiff,
 			},
 		},
 		{
 			name: "Failure with no changes",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "diff", "--staged", "--minimal", "--ignore-all-space", "--ignore-blank-lines").
-					Return(nil).
+					Run(gomock.Any(), "git", rawArgs...).
 					DoAndReturn(fakeEmptyCommand())
 			},
 			expected: result{
-				output: "",
-				err:    errors.ErrNoGitChanges,
+				err: errors.ErrNoGitChanges,
+			},
+		},
+		{
+			name: "Failure when content diff fails",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeCommandWithNulOutput(":100644 100644 aaa bbb M\x00main.go\x001\t0\tmain.go\x00"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", statArgs...).
+					DoAndReturn(fakeCommandWithOutput(" main.go | 1 +\n"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", append(contentArgs, "main.go")...).
+					DoAndReturn(fakeFailingCommand())
+			},
+			expected: result{
+				err: errors.ErrFailedToLoadGitDiff,
 			},
 		},
 	}
@@ -137,8 +207,7 @@ func Test_Diff(t *testing.T) {
 			output, err := gitClient.Diff(ctx)
 
 			if tt.expected.err != nil {
-				assert.Error(t, err)
-				assert.EqualError(t, err, tt.expected.err.Error())
+				assert.ErrorIs(t, err, tt.expected.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected.output, output)
@@ -147,7 +216,75 @@ func Test_Diff(t *testing.T) {
 	}
 }
 
-//nolint:dupl
+func Test_BranchDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	nopLogger := zerolog.Nop()
+
+	mockExecutor := NewMockExecutor(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
+	mockLogger.EXPECT().Error().AnyTimes().Return(nopLogger.Error())
+
+	gitClient := &client{
+		executor: mockExecutor,
+		cfg:      config.DefaultConfig(),
+		log:      mockLogger,
+	}
+
+	rawArgs := []any{"diff", "main...HEAD", "--raw", "--numstat", "-M", "--no-abbrev", "-z"}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected string
+		err      error
+	}{
+		{
+			name: "Success with changes since merge base",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeCommandWithNulOutput(":100644 100644 aaa bbb M\x00main.go\x001\t0\tmain.go\x00"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "diff", "main...HEAD", "--stat", "-M").
+					DoAndReturn(fakeCommandWithOutput(" main.go | 1 +\n"))
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "--literal-pathspecs", "diff", "main...HEAD", "--minimal", "--ignore-all-space", "--ignore-blank-lines", "-M", "--", "main.go").
+					DoAndReturn(fakeCommandWithOutput("diff --git a/main.go b/main.go\n+package main\n"))
+			},
+			expected: " main.go | 1 +\n\ndiff --git a/main.go b/main.go\n+package main",
+		},
+		{
+			name: "Failure with unknown base",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", rawArgs...).
+					DoAndReturn(fakeFailingCommand())
+			},
+			err: errors.ErrFailedToLoadGitDiff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			output, err := gitClient.BranchDiff(ctx, "main")
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, output)
+			}
+		})
+	}
+}
+
 func Test_Log(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -168,7 +305,7 @@ func Test_Log(t *testing.T) {
 	}
 
 	type result struct {
-		output string
+		output []Commit
 		err    error
 	}
 
@@ -181,25 +318,49 @@ func Test_Log(t *testing.T) {
 			name: "Success",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "log", "--format=%h|%s|%an|%ar", "v1.0.0..v1.2.0").
+					Run(gomock.Any(), "git", "log", "-z", logFormat, "v1.0.0..v1.2.0").
 					Return(nil).
-					DoAndReturn(fakeCommandWithOutput("mock log output"))
+					DoAndReturn(fakeCommandWithNulOutput(
+						"1111111111111111111111111111111111111111\x002222222222222222222222222222222222222222\x00Jane Doe\x00jane@example.com\x002024-06-01T12:00:00+02:00\x00HEAD -> main, tag: v1.2.0\x00feat(api): Add endpoint\n\nDetails\n\x00" +
+							"2222222222222222222222222222222222222222\x00\x00John Doe\x00john@example.com\x002024-05-01T09:30:00Z\x00\x00fix: Initial\n\x00",
+					))
 			},
 			expected: result{
-				output: "mock log output",
-				err:    nil,
+				output: []Commit{
+					{
+						Hash:    "1111111111111111111111111111111111111111",
+						Parents: []string{"2222222222222222222222222222222222222222"},
+						Author:  "Jane Doe",
+						Email:   "jane@example.com",
+						Date:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60)),
+						Subject: "feat(api): Add endpoint",
+						Body:    "Details",
+						Refs:    []string{"HEAD -> main", "tag: v1.2.0"},
+						Message: "feat(api): Add endpoint\n\nDetails\n",
+					},
+					{
+						Hash:    "2222222222222222222222222222222222222222",
+						Parents: []string{},
+						Author:  "John Doe",
+						Email:   "john@example.com",
+						Date:    time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+						Subject: "fix: Initial",
+						Message: "fix: Initial\n",
+					},
+				},
+				err: nil,
 			},
 		},
 		{
 			name: "Failure when log command fails",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "log", "--format=%h|%s|%an|%ar", "v1.0.0..v1.2.0").
+					Run(gomock.Any(), "git", "log", "-z", logFormat, "v1.0.0..v1.2.0").
 					Return(nil).
 					DoAndReturn(fakeFailingCommand())
 			},
 			expected: result{
-				output: "",
+				output: nil,
 				err:    errors.New("failed to load git log"),
 			},
 		},
@@ -207,12 +368,12 @@ func Test_Log(t *testing.T) {
 			name: "Failure with no commits",
 			before: func() {
 				mockExecutor.EXPECT().
-					Run(gomock.Any(), "git", "log", "--format=%h|%s|%an|%ar", "v1.0.0..v1.2.0").
+					Run(gomock.Any(), "git", "log", "-z", logFormat, "v1.0.0..v1.2.0").
 					Return(nil).
 					DoAndReturn(fakeEmptyCommand())
 			},
 			expected: result{
-				output: "",
+				output: nil,
 				err:    errors.ErrNoGitCommits,
 			},
 		},
@@ -324,6 +485,85 @@ func Test_Status(t *testing.T) {
 	}
 }
 
+func Test_UnstagedStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	nopLogger := zerolog.Nop()
+
+	mockExecutor := NewMockExecutor(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
+	mockLogger.EXPECT().Error().AnyTimes().Return(nopLogger.Error())
+
+	gitClient := &client{
+		executor: mockExecutor,
+		log:      mockLogger,
+	}
+
+	type result struct {
+		output string
+		err    error
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected result
+	}{
+		{
+			name: "Success",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "diff", "--name-status").
+					DoAndReturn(fakeCommandWithOutput("M\tmain.go"))
+			},
+			expected: result{
+				output: "M\tmain.go",
+			},
+		},
+		{
+			name: "Success with no changes",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "diff", "--name-status").
+					DoAndReturn(fakeEmptyCommand())
+			},
+			expected: result{
+				output: "",
+			},
+		},
+		{
+			name: "Failure when status command fails",
+			before: func() {
+				mockExecutor.EXPECT().
+					Run(gomock.Any(), "git", "diff", "--name-status").
+					DoAndReturn(fakeFailingCommand())
+			},
+			expected: result{
+				err: errors.ErrFailedToLoadGitDiff,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			output, err := gitClient.UnstagedStatus(ctx)
+
+			if tt.expected.err != nil {
+				assert.ErrorIs(t, err, tt.expected.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.output, output)
+		})
+	}
+}
+
 func Test_Commit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -409,3 +649,520 @@ func Test_Commit(t *testing.T) {
 		})
 	}
 }
+
+// dirExecutor runs commands inside the given directory
+type dirExecutor struct {
+	dir string
+}
+
+// Run creates a command bound to the executor directory
+func (e dirExecutor) Run(ctx context.Context, name string, arg ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Dir = e.dir
+	return cmd
+}
+
+// newTestRepo creates a temporary git repository and returns a client bound to it
+func newTestRepo(t *testing.T) (*client, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	nopLogger := zerolog.Nop()
+
+	ctrl := gomock.NewController(t)
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
+	mockLogger.EXPECT().Error().AnyTimes().Return(nopLogger.Error())
+	mockLogger.EXPECT().Info().AnyTimes().Return(nopLogger.Info())
+	mockLogger.EXPECT().Warn().AnyTimes().Return(nopLogger.Warn())
+
+	runGit(t, dir, "init", "--quiet")
+	runGit(t, dir, "config", "user.name", "Test")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "commit.gpgsign", "false")
+
+	return &client{
+		executor: dirExecutor{dir: dir},
+		log:      mockLogger,
+	}, dir
+}
+
+// runGit runs a git command inside the test repository and returns its output
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+
+	return string(out)
+}
+
+// writeFile writes a file inside the test repository
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func Test_FileDiff(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	writeFile(t, dir, "file.txt", "one\ntwo\nthree\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+
+	writeFile(t, dir, "file.txt", "one\ntwo\nthree\nfour\n")
+	runGit(t, dir, "add", "file.txt")
+	writeFile(t, dir, "file.txt", "zero\none\ntwo\nthree\nfour\n")
+
+	tests := []struct {
+		name     string
+		path     string
+		staged   bool
+		expected string
+	}{
+		{
+			name:     "Success with staged changes",
+			path:     "file.txt",
+			staged:   true,
+			expected: "+four",
+		},
+		{
+			name:     "Success with unstaged changes",
+			path:     "file.txt",
+			staged:   false,
+			expected: "+zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := gitClient.FileDiff(ctx, tt.path, tt.staged)
+
+			assert.NoError(t, err)
+			assert.Contains(t, output, tt.expected)
+		})
+	}
+
+	t.Run("Success with unchanged file", func(t *testing.T) {
+		writeFile(t, dir, "other.txt", "content\n")
+		runGit(t, dir, "add", "other.txt")
+		runGit(t, dir, "commit", "--quiet", "-m", "other")
+
+		output, err := gitClient.FileDiff(ctx, "other.txt", true)
+
+		assert.NoError(t, err)
+		assert.Empty(t, output)
+	})
+}
+
+func Test_ApplyPatch(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	lines := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	writeFile(t, dir, "file.txt", lines)
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+
+	writeFile(t, dir, "file.txt", "first\n"+lines+"last\n")
+
+	unstaged, err := gitClient.FileDiff(ctx, "file.txt", false)
+	assert.NoError(t, err)
+
+	fileDiff := ParseFileDiff(unstaged)
+	assert.Len(t, fileDiff.Hunks, 2)
+
+	t.Run("Success when staging a single hunk", func(t *testing.T) {
+		err := gitClient.ApplyPatch(ctx, fileDiff.Patch(1), false)
+		assert.NoError(t, err)
+
+		staged := runGit(t, dir, "diff", "--staged")
+		assert.Contains(t, staged, "+last")
+		assert.NotContains(t, staged, "+first")
+	})
+
+	t.Run("Success when unstaging a single hunk", func(t *testing.T) {
+		staged, err := gitClient.FileDiff(ctx, "file.txt", true)
+		assert.NoError(t, err)
+
+		err = gitClient.ApplyPatch(ctx, ParseFileDiff(staged).Patch(0), true)
+		assert.NoError(t, err)
+
+		assert.Empty(t, runGit(t, dir, "diff", "--staged"))
+	})
+
+	t.Run("Failure with patch that does not apply", func(t *testing.T) {
+		err := gitClient.ApplyPatch(ctx, "--- a/missing.txt\n+++ b/missing.txt\n@@ -1 +1 @@\n-a\n+b\n", false)
+		assert.ErrorIs(t, err, errors.ErrFailedToApplyPatch)
+	})
+
+	t.Run("Failure with empty patch", func(t *testing.T) {
+		err := gitClient.ApplyPatch(ctx, "", false)
+		assert.ErrorIs(t, err, errors.ErrPatchEmpty)
+	})
+}
+
+func Test_ConfigValue(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	runGit(t, dir, "config", "core.editor", "nano -w")
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{
+			name:     "Success with configured key",
+			key:      "core.editor",
+			expected: "nano -w",
+		},
+		{
+			name:     "Success with missing key",
+			key:      "cmt.missing",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := gitClient.ConfigValue(ctx, tt.key)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	t.Run("Failure when config command fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockExecutor := NewMockExecutor(ctrl)
+		mockExecutor.EXPECT().
+			Run(gomock.Any(), "git", "config", "--get", "core.editor").
+			Return(exec.Command("sh", "-c", "exit 2"))
+
+		failingClient := &client{executor: mockExecutor, log: gitClient.log}
+
+		value, err := failingClient.ConfigValue(ctx, "core.editor")
+
+		assert.ErrorIs(t, err, errors.ErrFailedToReadGitConfig)
+		assert.Empty(t, value)
+	})
+}
+
+func Test_CurrentBranch(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	writeFile(t, dir, "file.txt", "content\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+	runGit(t, dir, "checkout", "--quiet", "-b", "feature/PROJ-42-login")
+
+	t.Run("Success with branch", func(t *testing.T) {
+		branch, err := gitClient.CurrentBranch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "feature/PROJ-42-login", branch)
+	})
+
+	t.Run("Failure with detached HEAD", func(t *testing.T) {
+		runGit(t, dir, "checkout", "--quiet", "--detach")
+
+		branch, err := gitClient.CurrentBranch(ctx)
+
+		assert.ErrorIs(t, err, errors.ErrDetachedHead)
+		assert.Empty(t, branch)
+	})
+}
+
+func Test_DefaultBranch(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	t.Run("Success without remote", func(t *testing.T) {
+		branch, err := gitClient.DefaultBranch(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, branch)
+	})
+
+	t.Run("Success with origin/HEAD", func(t *testing.T) {
+		runGit(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/develop")
+
+		branch, err := gitClient.DefaultBranch(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "develop", branch)
+	})
+}
+
+func Test_Tags(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	t.Run("Failure without commits", func(t *testing.T) {
+		tags, err := gitClient.Tags(ctx)
+
+		assert.ErrorIs(t, err, errors.ErrFailedToLoadGitTags)
+		assert.Nil(t, tags)
+	})
+
+	writeFile(t, dir, "file.txt", "one\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+	runGit(t, dir, "tag", "v1.0.0")
+	runGit(t, dir, "checkout", "--quiet", "-b", "feature")
+	writeFile(t, dir, "file.txt", "two\n")
+	runGit(t, dir, "commit", "--quiet", "-am", "feature")
+	runGit(t, dir, "tag", "-a", "-m", "Feature preview", "v1.1.0-rc.1")
+	runGit(t, dir, "checkout", "--quiet", "-")
+
+	t.Run("Success with tags reachable from HEAD", func(t *testing.T) {
+		tags, err := gitClient.Tags(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"v1.0.0"}, tags)
+	})
+}
+
+func Test_CreateTag(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	writeFile(t, dir, "file.txt", "one\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+
+	t.Run("Success", func(t *testing.T) {
+		err := gitClient.CreateTag(ctx, "v1.0.0", "## [1.0.0]\n\n- **feat:** Add login")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tag", strings.TrimSpace(runGit(t, dir, "cat-file", "-t", "v1.0.0")))
+		assert.Contains(t, runGit(t, dir, "tag", "-l", "--format=%(contents)", "v1.0.0"), "- **feat:** Add login")
+	})
+
+	t.Run("Failure with existing tag", func(t *testing.T) {
+		err := gitClient.CreateTag(ctx, "v1.0.0", "again")
+
+		assert.ErrorIs(t, err, errors.ErrFailedToCreateTag)
+	})
+}
+
+func Test_Operation(t *testing.T) {
+	gitClient, dir := newTestRepo(t)
+	ctx := context.Background()
+
+	writeFile(t, dir, "file.txt", "content\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+	runGit(t, dir, "branch", "-M", "main")
+	runGit(t, dir, "checkout", "--quiet", "-b", "feature")
+	writeFile(t, dir, "feature.txt", "feature\n")
+	runGit(t, dir, "add", "feature.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "feat: Add feature")
+	feature := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+	runGit(t, dir, "checkout", "--quiet", "main")
+	writeFile(t, dir, "main.txt", "main\n")
+	runGit(t, dir, "add", "main.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "chore: Update main")
+
+	t.Run("Success without operation", func(t *testing.T) {
+		op, err := gitClient.Operation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OperationNone, op.Kind)
+	})
+
+	t.Run("Success with merge", func(t *testing.T) {
+		runGit(t, dir, "merge", "--quiet", "--no-commit", "--no-ff", "feature")
+		defer runGit(t, dir, "merge", "--abort")
+
+		op, err := gitClient.Operation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OperationMerge, op.Kind)
+		assert.Equal(t, []string{feature}, op.Heads)
+		assert.Equal(t, "Merge branch 'feature'", op.Subject())
+	})
+
+	t.Run("Success with squash", func(t *testing.T) {
+		runGit(t, dir, "merge", "--quiet", "--squash", "feature")
+		defer runGit(t, dir, "reset", "--quiet", "--hard")
+
+		op, err := gitClient.Operation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OperationSquash, op.Kind)
+		assert.Equal(t, []string{feature}, op.Heads)
+	})
+
+	t.Run("Success with revert", func(t *testing.T) {
+		head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+		runGit(t, dir, "revert", "--no-commit", "HEAD")
+		defer runGit(t, dir, "revert", "--abort")
+
+		op, err := gitClient.Operation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OperationRevert, op.Kind)
+		assert.Equal(t, []string{head}, op.Heads)
+		assert.False(t, op.Unlisted)
+	})
+
+	t.Run("Success with revert of several commits", func(t *testing.T) {
+		head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+		runGit(t, dir, "revert", "--no-commit", "HEAD~1", "HEAD")
+		defer runGit(t, dir, "reset", "--quiet", "--hard")
+
+		op, err := gitClient.Operation(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OperationRevert, op.Kind)
+		assert.Equal(t, []string{head}, op.Heads)
+		assert.True(t, op.Unlisted)
+	})
+
+	t.Run("Failure outside a repository", func(t *testing.T) {
+		outside := &client{executor: dirExecutor{dir: t.TempDir()}, log: gitClient.log}
+
+		_, err := outside.Operation(ctx)
+
+		assert.ErrorIs(t, err, errors.ErrFailedToReadGitState)
+	})
+}
+
+// newRewriteRepo creates a repository with a pushed initial commit on main
+// followed by three local commits, and returns their hashes oldest first
+func newRewriteRepo(t *testing.T) (*client, string, []string) {
+	t.Helper()
+
+	gitClient, dir := newTestRepo(t)
+
+	writeFile(t, dir, "file.txt", "one\n")
+	runGit(t, dir, "add", "file.txt")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+	runGit(t, dir, "branch", "-M", "main")
+	runGit(t, dir, "update-ref", "refs/remotes/origin/main", "HEAD")
+	runGit(t, dir, "checkout", "--quiet", "-b", "feature")
+
+	var hashes []string
+	for i, message := range []string{"wip", "feat: Add two", "fixed stuff"} {
+		writeFile(t, dir, "file.txt", strings.Repeat("line\n", i+2))
+		runGit(t, dir, "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "--quiet", "-am", message)
+		hashes = append(hashes, strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")))
+	}
+
+	return gitClient, dir, hashes
+}
+
+func Test_CommitDiff(t *testing.T) {
+	gitClient, dir, hashes := newRewriteRepo(t)
+
+	output, err := gitClient.CommitDiff(context.Background(), hashes[1])
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, " file.txt | 1 +")
+	assert.Contains(t, output, "diff --git a/file.txt b/file.txt")
+
+	root := strings.TrimSpace(runGit(t, dir, "rev-list", "--max-parents=0", "HEAD"))
+	output, err = gitClient.CommitDiff(context.Background(), root)
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "new file mode")
+}
+
+func Test_Pushed(t *testing.T) {
+	gitClient, dir, hashes := newRewriteRepo(t)
+	ctx := context.Background()
+
+	pushed, err := gitClient.Pushed(ctx, "main")
+	assert.NoError(t, err)
+	assert.False(t, pushed)
+
+	pushed, err = gitClient.Pushed(ctx, "main~1")
+	assert.ErrorIs(t, err, errors.ErrFailedToLoadGitLog)
+	assert.False(t, pushed)
+
+	runGit(t, dir, "update-ref", "refs/remotes/origin/feature", hashes[0])
+	pushed, err = gitClient.Pushed(ctx, "main")
+	assert.NoError(t, err)
+	assert.True(t, pushed)
+}
+
+func Test_Rewrite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success with reworded commits", func(t *testing.T) {
+		gitClient, dir, hashes := newRewriteRepo(t)
+		tree := runGit(t, dir, "rev-parse", "HEAD^{tree}")
+
+		backup, err := gitClient.Rewrite(ctx, "main", map[string]string{
+			hashes[0]: "feat: Add one",
+			hashes[2]: "fix: Repeat the line\n\nThe file needs four lines.",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(backup, "refs/cmt/backup/feature/"))
+		assert.Equal(t, hashes[2]+"\n", runGit(t, dir, "rev-parse", backup))
+		assert.Equal(t, tree, runGit(t, dir, "rev-parse", "HEAD^{tree}"))
+		assert.Equal(t, "fix: Repeat the line\x00feat: Add two\x00feat: Add one\x00initial\x00", runGit(t, dir, "log", "-z", "--format=%s", "feature"))
+		assert.Equal(t, "The file needs four lines.", strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%b")))
+		assert.Equal(t, "Jane Doe <jane@example.com>\n", runGit(t, dir, "log", "-1", "--format=%an <%ae>", "HEAD~1"))
+	})
+
+	t.Run("Success keeping trailers", func(t *testing.T) {
+		gitClient, dir, _ := newRewriteRepo(t)
+		runGit(t, dir, "commit", "--quiet", "--amend", "-m", "fixed stuff\n\nSigned-off-by: Jane Doe <jane@example.com>\nRefs: JIRA-1")
+		head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+		_, err := gitClient.Rewrite(ctx, "main", map[string]string{head: "fix: Repeat the line\n\nRefs: JIRA-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "fix: Repeat the line\n\nRefs: JIRA-1\nSigned-off-by: Jane Doe <jane@example.com>", strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%B")))
+	})
+
+	t.Run("Success with two rewrites in a row", func(t *testing.T) {
+		gitClient, _, hashes := newRewriteRepo(t)
+
+		first, err := gitClient.Rewrite(ctx, "main", map[string]string{hashes[0]: "feat: Add one"})
+		assert.NoError(t, err)
+
+		second, err := gitClient.Rewrite(ctx, "main", map[string]string{hashes[1]: "feat: Add two lines"})
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("Success without changes", func(t *testing.T) {
+		gitClient, dir, hashes := newRewriteRepo(t)
+
+		backup, err := gitClient.Rewrite(ctx, "main", nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, backup)
+		assert.Equal(t, hashes[2]+"\n", runGit(t, dir, "rev-parse", "HEAD"))
+	})
+
+	t.Run("Failure with pushed commits", func(t *testing.T) {
+		gitClient, dir, hashes := newRewriteRepo(t)
+		runGit(t, dir, "update-ref", "refs/remotes/origin/feature", hashes[0])
+
+		_, err := gitClient.Rewrite(ctx, "main", map[string]string{hashes[1]: "feat: Add two lines"})
+
+		assert.ErrorIs(t, err, errors.ErrCommitsPushed)
+		assert.Equal(t, hashes[2]+"\n", runGit(t, dir, "rev-parse", "HEAD"))
+	})
+}
@@ -7,6 +7,6 @@ import (
 var Module = fx.Options(
 	fx.Provide(
 		NewGitExecutor,
-		NewGitClient,
+		NewClient,
 	),
 )
@@ -0,0 +1,1149 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// nativeClient implements the git client interface with go-git, without
+// requiring the git binary
+type nativeClient struct {
+	open func() (*gogit.Repository, error)
+	repo *gogit.Repository
+	cfg  *config.Config
+	log  logger.Logger
+	mu   sync.Mutex
+}
+
+// NewNativeClient creates a go-git client for an open repository, which may
+// be backed by in-memory storage
+func NewNativeClient(
+	repo *gogit.Repository,
+	cfg *config.Config,
+	log logger.Logger,
+) Client {
+	return &nativeClient{
+		repo: repo,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// OpenNativeClient creates a go-git client that opens the repository
+// containing the working directory on first use
+func OpenNativeClient(
+	cfg *config.Config,
+	log logger.Logger,
+) Client {
+	return &nativeClient{
+		open: func() (*gogit.Repository, error) {
+			return gogit.PlainOpenWithOptions(".", &gogit.PlainOpenOptions{DetectDotGit: true})
+		},
+		cfg: cfg,
+		log: log,
+	}
+}
+
+// repository returns the repository, opening it on first use
+func (g *nativeClient) repository() (*gogit.Repository, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.repo != nil {
+		return g.repo, nil
+	}
+
+	repo, err := g.open()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to open git repository")
+		return nil, fmt.Errorf("%w: %v", errors.ErrFailedToOpenRepo, err)
+	}
+
+	g.repo = repo
+	return repo, nil
+}
+
+// Diff returns the staged changes prepared for the model in the same layout
+// as the git binary backend
+func (g *nativeClient) Diff(ctx context.Context) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := stagedPairs(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to compare index with HEAD")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	return g.diff(pairs)
+}
+
+// BranchDiff returns the changes of HEAD since it diverged from base, as in
+// git diff base...HEAD, prepared for the model like the staged diff
+func (g *nativeClient) BranchDiff(ctx context.Context, base string) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := branchPairs(repo, base)
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to compare HEAD with merge base")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	return g.diff(pairs)
+}
+
+// CommitDiff returns the changes of a commit against its first parent,
+// prepared for the model like the staged diff
+func (g *nativeClient) CommitDiff(ctx context.Context, hash string) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := commitPairs(repo, hash)
+	if err != nil {
+		g.log.Error().Err(err).Str("hash", hash).Msg("Failed to compare commit with its parent")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	return g.diff(pairs)
+}
+
+// diff summarizes the changed pairs and encodes the diff of the remaining ones
+func (g *nativeClient) diff(pairs []pair) (string, error) {
+	if len(pairs) == 0 {
+		return "", errors.ErrNoGitChanges
+	}
+
+	changes := make([]Change, 0, len(pairs))
+	for _, p := range pairs {
+		change, err := p.change()
+		if err != nil {
+			g.log.Error().Err(err).Str("path", p.path()).Msg("Failed to read file contents")
+			return "", errors.ErrFailedToLoadGitDiff
+		}
+		changes = append(changes, change)
+	}
+
+	summaries, kept := summarize(changes, maxFileLines(g.cfg))
+
+	keptPaths := make(map[string]bool, len(kept))
+	for _, change := range kept {
+		keptPaths[change.Path] = true
+	}
+
+	var keptPairs []pair
+	for _, p := range pairs {
+		if keptPaths[p.path()] {
+			keptPairs = append(keptPairs, p)
+		}
+	}
+
+	content, err := encodePatch(keptPairs)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to encode diff")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	result := joinDiff(formatStat(changes), summaries, content)
+
+	g.log.Debug().
+		Int("files", len(changes)).
+		Int("summarized", len(summaries)).
+		Int("diff_length", len(result)).
+		Msg("Git diff loaded successfully")
+	return result, nil
+}
+
+// Status returns the staged files in git diff --name-status format
+func (g *nativeClient) Status(ctx context.Context) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := stagedPairs(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to compare index with HEAD")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+	if len(pairs) == 0 {
+		return "", errors.ErrNoGitChanges
+	}
+
+	lines := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if p.isRename() {
+			lines = append(lines, fmt.Sprintf("%s\t%s\t%s", p.status(), p.from.path, p.to.path))
+			continue
+		}
+		lines = append(lines, p.status()+"\t"+p.path())
+	}
+
+	result := strings.Join(lines, "\n")
+	g.log.Debug().Int("status_length", len(result)).Msg("Git status loaded successfully")
+	return result, nil
+}
+
+// UnstagedStatus returns the tracked files with unstaged changes in
+// git diff --name-status format, or an empty string when there are none
+func (g *nativeClient) UnstagedStatus(ctx context.Context) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	pairs, err := unstagedPairs(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to compare working tree with index")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	lines := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		lines = append(lines, p.status()+"\t"+p.path())
+	}
+
+	result := strings.Join(lines, "\n")
+	g.log.Debug().Int("status_length", len(result)).Msg("Git unstaged status loaded successfully")
+	return result, nil
+}
+
+// Log returns the commits selected by the git log options, newest first.
+// Besides revisions, A..B ranges and pathspecs after --, only the -n,
+// -<number> and --max-count options are understood
+func (g *nativeClient) Log(ctx context.Context, opts []string) ([]Commit, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseLogOptions(opts)
+	if err != nil {
+		g.log.Error().Err(err).Strs("opts", opts).Msg("Unsupported git log options")
+		return nil, err
+	}
+
+	match, err := matchPathspecs(parsed.paths)
+	if err != nil {
+		g.log.Error().Err(err).Strs("opts", opts).Msg("Unsupported git log options")
+		return nil, err
+	}
+
+	if parsed.noWalk {
+		return g.listCommits(repo, parsed)
+	}
+
+	from, exclude, err := resolveRange(repo, parsed.revisions)
+	if err != nil {
+		g.log.Error().Err(err).Strs("opts", opts).Msg("Failed to resolve log range")
+		return nil, errors.ErrFailedToLoadGitLog
+	}
+	if from.IsZero() {
+		g.log.Info().Msg("No git commits found")
+		return nil, errors.ErrNoGitCommits
+	}
+
+	refs, err := decorations(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git references")
+		return nil, errors.ErrFailedToLoadGitLog
+	}
+
+	iter, err := repo.Log(&gogit.LogOptions{From: from, PathFilter: match})
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git log")
+		return nil, errors.ErrFailedToLoadGitLog
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if exclude[c.Hash] {
+			return nil
+		}
+		if parsed.limit >= 0 && len(commits) >= parsed.limit {
+			return storer.ErrStop
+		}
+
+		commits = append(commits, nativeCommit(c, refs[c.Hash]))
+		return nil
+	})
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git log")
+		return nil, errors.ErrFailedToLoadGitLog
+	}
+
+	if len(commits) == 0 {
+		g.log.Info().Msg("No git commits found")
+		return nil, errors.ErrNoGitCommits
+	}
+
+	g.log.Debug().Int("commits", len(commits)).Msg("Git log loaded successfully")
+	return commits, nil
+}
+
+// listCommits returns the given revisions without their history, newest
+// first like git log --no-walk
+func (g *nativeClient) listCommits(repo *gogit.Repository, parsed logOptions) ([]Commit, error) {
+	refs, err := decorations(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git references")
+		return nil, errors.ErrFailedToLoadGitLog
+	}
+
+	revisions := parsed.revisions
+	if len(revisions) == 0 {
+		revisions = []string{"HEAD"}
+	}
+
+	var found []*object.Commit
+	seen := make(map[plumbing.Hash]bool)
+	for _, rev := range revisions {
+		hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+		if err != nil {
+			g.log.Error().Err(err).Str("revision", rev).Msg("Failed to resolve revision")
+			return nil, errors.ErrFailedToLoadGitLog
+		}
+		if seen[*hash] {
+			continue
+		}
+		seen[*hash] = true
+
+		c, err := repo.CommitObject(*hash)
+		if err != nil {
+			g.log.Error().Err(err).Str("revision", rev).Msg("Failed to read commit")
+			return nil, errors.ErrFailedToLoadGitLog
+		}
+		found = append(found, c)
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Committer.When.After(found[j].Committer.When)
+	})
+	if parsed.limit >= 0 && len(found) > parsed.limit {
+		found = found[:parsed.limit]
+	}
+
+	if len(found) == 0 {
+		g.log.Info().Msg("No git commits found")
+		return nil, errors.ErrNoGitCommits
+	}
+
+	commits := make([]Commit, 0, len(found))
+	for _, c := range found {
+		commits = append(commits, nativeCommit(c, refs[c.Hash]))
+	}
+
+	g.log.Debug().Int("commits", len(commits)).Msg("Git log loaded successfully")
+	return commits, nil
+}
+
+// Commit records the index as a new commit authored by the configured user.
+// Tags returns the names of the tags reachable from HEAD
+func (g *nativeClient) Tags(ctx context.Context) ([]string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to resolve HEAD")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+
+	reachable := make(map[plumbing.Hash]bool)
+	iter, err := repo.Log(&gogit.LogOptions{From: head.Hash()})
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git log")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		reachable[c.Hash] = true
+		return nil
+	})
+	iter.Close()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git log")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+
+	refs, err := repo.Tags()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git tags")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+	defer refs.Close()
+
+	var tags []string
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repo.TagObject(hash); err == nil {
+			commit, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = commit.Hash
+		}
+
+		if reachable[hash] {
+			tags = append(tags, ref.Name().Short())
+		}
+		return nil
+	})
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git tags")
+		return nil, errors.ErrFailedToLoadGitTags
+	}
+
+	sort.Strings(tags)
+	g.log.Debug().Int("tags", len(tags)).Msg("Git tags loaded successfully")
+	return tags, nil
+}
+
+// CreateTag creates an annotated tag on HEAD with the given message, signed
+// by the user of the repository configuration
+func (g *nativeClient) CreateTag(ctx context.Context, name, message string) error {
+	repo, err := g.repository()
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to resolve HEAD")
+		return fmt.Errorf("%w: %s", errors.ErrFailedToCreateTag, name)
+	}
+
+	if _, err := repo.CreateTag(name, head.Hash(), &gogit.CreateTagOptions{Message: message}); err != nil {
+		g.log.Error().Err(err).Str("tag", name).Msg("Failed to create tag")
+		return fmt.Errorf("%w: %s", errors.ErrFailedToCreateTag, name)
+	}
+
+	g.log.Debug().Str("tag", name).Msg("Tag created successfully")
+	return nil
+}
+
+// commitHooks are the hooks the git binary runs when committing
+var commitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+
+// Commit commits the staged changes, concluding a merge in progress like the
+// git binary. Unlike the git binary, go-git does not run commit hooks, so a
+// warning names the installed hooks that are skipped
+func (g *nativeClient) Commit(ctx context.Context, message string) (string, error) {
+	if message == "" {
+		g.log.Error().Msg("Commit message is empty")
+		return "", errors.ErrCommitMessageEmpty
+	}
+
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to open worktree")
+		return "", errors.ErrFailedToCommit
+	}
+
+	op, err := g.Operation(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if hooks := installedHooks(repo, wt); len(hooks) > 0 {
+		g.log.Warn().Strs("hooks", hooks).Msg("Commit hooks are not run by the native git backend, set git.backend to cli to run them")
+	}
+
+	opts := &gogit.CommitOptions{}
+	if op.Kind == OperationMerge {
+		head, err := repo.Head()
+		if err != nil {
+			g.log.Error().Err(err).Msg("Failed to read HEAD")
+			return "", errors.ErrFailedToCommit
+		}
+		opts.Parents = []plumbing.Hash{head.Hash()}
+		for _, h := range op.Heads {
+			opts.Parents = append(opts.Parents, plumbing.NewHash(h))
+		}
+	}
+
+	hash, err := wt.Commit(message, opts)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to commit changes")
+		return "", fmt.Errorf("%w: %v", errors.ErrFailedToCommit, err)
+	}
+
+	if op.Kind != OperationNone {
+		g.clearOperation(repo)
+	}
+
+	branch, err := g.CurrentBranch(ctx)
+	if err != nil {
+		branch = "detached HEAD"
+	}
+
+	subject, _, _ := strings.Cut(message, "\n")
+	g.log.Debug().Str("hash", hash.String()).Msg("Successfully committed changes")
+	return fmt.Sprintf("[%s %s] %s\n", branch, hash.String()[:7], subject), nil
+}
+
+// installedHooks returns the commit hooks found in core.hooksPath or the
+// hooks directory of the repository
+func installedHooks(repo *gogit.Repository, wt *gogit.Worktree) []string {
+	var exists func(name string) bool
+
+	cfg, err := repo.Config()
+	if err == nil && cfg.Raw.Section("core").Option("hooksPath") != "" {
+		dir := cfg.Raw.Section("core").Option("hooksPath")
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(wt.Filesystem.Root(), dir)
+		}
+		exists = func(name string) bool {
+			_, err := os.Stat(filepath.Join(dir, name))
+			return err == nil
+		}
+	} else if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		fs := storage.Filesystem()
+		exists = func(name string) bool {
+			_, err := fs.Stat(fs.Join("hooks", name))
+			return err == nil
+		}
+	} else {
+		return nil
+	}
+
+	var hooks []string
+	for _, name := range commitHooks {
+		if exists(name) {
+			hooks = append(hooks, name)
+		}
+	}
+	return hooks
+}
+
+// FileDiff returns the staged or unstaged diff of a single file
+func (g *nativeClient) FileDiff(ctx context.Context, path string, staged bool) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	var p pair
+	if staged {
+		p, err = stagedPair(repo, path)
+	} else {
+		p, err = unstagedPair(repo, path)
+	}
+	if err != nil {
+		g.log.Error().Err(err).Str("path", path).Msg("Failed to load file diff")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	if !p.changed() {
+		return "", nil
+	}
+
+	result, err := encodePatch([]pair{p})
+	if err != nil {
+		g.log.Error().Err(err).Str("path", path).Msg("Failed to encode file diff")
+		return "", errors.ErrFailedToLoadGitDiff
+	}
+
+	g.log.Debug().Str("path", path).Bool("staged", staged).Int("diff_length", len(result)).Msg("Git file diff loaded successfully")
+	return result, nil
+}
+
+// ApplyPatch is not available without the git binary
+func (g *nativeClient) ApplyPatch(ctx context.Context, patch string, reverse bool) error {
+	g.log.Error().Msg("Applying patches is not supported by the native git backend")
+	return fmt.Errorf("%w: staging hunks, set git.backend to cli", errors.ErrUnsupportedByBackend)
+}
+
+// ConfigValue returns the value of a git config key from the repository,
+// global and system config, or an empty string when it is not set
+func (g *nativeClient) ConfigValue(ctx context.Context, key string) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := repo.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git config")
+		return "", errors.ErrFailedToReadGitConfig
+	}
+
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 {
+		return "", nil
+	}
+
+	section := cfg.Raw.Section(parts[0])
+	name := parts[len(parts)-1]
+	if len(parts) > 2 {
+		return section.Subsection(strings.Join(parts[1:len(parts)-1], ".")).Option(name), nil
+	}
+
+	return section.Option(name), nil
+}
+
+// CurrentBranch returns the short name of the checked out branch
+func (g *nativeClient) CurrentBranch(ctx context.Context) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read HEAD")
+		return "", errors.ErrFailedToReadBranch
+	}
+
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		g.log.Debug().Msg("HEAD is detached")
+		return "", errors.ErrDetachedHead
+	}
+
+	return head.Target().Short(), nil
+}
+
+// DefaultBranch returns the branch origin/HEAD points at, or an empty string
+// when the remote HEAD is not known
+func (g *nativeClient) DefaultBranch(ctx context.Context) (string, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := repo.Storer.Reference(plumbing.ReferenceName(originHead))
+	if err == plumbing.ErrReferenceNotFound {
+		g.log.Debug().Msg("Remote HEAD is not set")
+		return "", nil
+	}
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read remote HEAD")
+		return "", errors.ErrFailedToReadBranch
+	}
+
+	if ref.Type() != plumbing.SymbolicReference {
+		return "", nil
+	}
+	return strings.TrimPrefix(ref.Target().Short(), "origin/"), nil
+}
+
+// Operation returns the merge, squash or revert that the staged changes
+// conclude, which is always none for repositories not stored on disk
+func (g *nativeClient) Operation(ctx context.Context) (Operation, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return Operation{}, err
+	}
+
+	dir, ok := gitDir(repo)
+	if !ok {
+		return Operation{}, nil
+	}
+
+	op := parseOperation(func(name string) (string, bool) {
+		content, err := util.ReadFile(dir, name)
+		return string(content), err == nil
+	})
+	if op.Kind == OperationRevert {
+		op.Unlisted = g.revertUnlisted(repo, op.Heads)
+	}
+
+	g.log.Debug().Str("operation", string(op.Kind)).Int("heads", len(op.Heads)).Msg("Git operation detected")
+	return op, nil
+}
+
+// revertUnlisted reports whether the staged changes touch files that the
+// reverted commits do not change. Failures are logged and ignored
+func (g *nativeClient) revertUnlisted(repo *gogit.Repository, heads []string) bool {
+	staged, err := stagedPairs(repo)
+	if err != nil {
+		g.log.Debug().Err(err).Msg("Failed to list staged files")
+		return false
+	}
+
+	var reverted []string
+	for _, head := range heads {
+		pairs, err := commitPairs(repo, head)
+		if err != nil {
+			g.log.Debug().Err(err).Str("hash", head).Msg("Failed to list reverted files")
+			return false
+		}
+		reverted = append(reverted, pairPaths(pairs)...)
+	}
+
+	return unlistedPaths(pairPaths(staged), reverted)
+}
+
+// clearOperation removes the state files of the concluded merge, squash or revert
+func (g *nativeClient) clearOperation(repo *gogit.Repository) {
+	dir, ok := gitDir(repo)
+	if !ok {
+		return
+	}
+
+	for _, name := range []string{mergeHeadFile, mergeMsgFile, mergeModeFile, squashMsgFile, revertHeadFile} {
+		if err := dir.Remove(name); err != nil && !os.IsNotExist(err) {
+			g.log.Error().Err(err).Str("file", name).Msg("Failed to remove merge state")
+		}
+	}
+}
+
+// gitDir returns the git directory of a repository stored on disk
+func gitDir(repo *gogit.Repository) (billy.Filesystem, bool) {
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return nil, false
+	}
+	return storage.Filesystem(), true
+}
+
+// Pushed reports whether any commit of base..HEAD is reachable from a remote-tracking branch
+func (g *nativeClient) Pushed(ctx context.Context, base string) (bool, error) {
+	repo, err := g.repository()
+	if err != nil {
+		return false, err
+	}
+
+	head, exclude, err := resolveRange(repo, []string{base + "..HEAD"})
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to resolve commit range")
+		return false, errors.ErrFailedToLoadGitLog
+	}
+
+	remote, err := remoteCommits(repo)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to walk remote-tracking branches")
+		return false, errors.ErrFailedToLoadGitLog
+	}
+
+	pushed := false
+	err = walkRange(repo, head, exclude, func(c *object.Commit) error {
+		if remote[c.Hash] {
+			pushed = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to walk commit range")
+		return false, errors.ErrFailedToLoadGitLog
+	}
+
+	return pushed, nil
+}
+
+// Rewrite replaces the messages of the commits of base..HEAD given by hash,
+// recreates the commits following them with the same trees and authors, and
+// moves HEAD to the result. The previous HEAD is kept in the returned backup
+// ref, and commits that were pushed are never rewritten
+func (g *nativeClient) Rewrite(ctx context.Context, base string, messages map[string]string) (string, error) {
+	pushed, err := g.Pushed(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	if pushed {
+		return "", errors.ErrCommitsPushed
+	}
+
+	repo, err := g.repository()
+	if err != nil {
+		return "", err
+	}
+
+	head, exclude, err := resolveRange(repo, []string{base + "..HEAD"})
+	if err != nil {
+		g.log.Error().Err(err).Str("base", base).Msg("Failed to resolve commit range")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	cfg, err := repo.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to read git config")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	rewritten := make(map[plumbing.Hash]plumbing.Hash)
+
+	var rewrite func(hash plumbing.Hash) (plumbing.Hash, error)
+	rewrite = func(hash plumbing.Hash) (plumbing.Hash, error) {
+		if exclude[hash] {
+			return hash, nil
+		}
+		if n, ok := rewritten[hash]; ok {
+			return n, nil
+		}
+
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		parents := make([]plumbing.Hash, len(commit.ParentHashes))
+		changed := false
+		for i, p := range commit.ParentHashes {
+			if parents[i], err = rewrite(p); err != nil {
+				return plumbing.ZeroHash, err
+			}
+			changed = changed || parents[i] != p
+		}
+
+		message, reword := messages[hash.String()]
+		if !changed && !reword {
+			rewritten[hash] = hash
+			return hash, nil
+		}
+		if reword {
+			message = keepTrailers(message, commit.Message) + "\n"
+		} else {
+			message = commit.Message
+		}
+
+		committer := commit.Committer
+		if cfg.User.Name != "" {
+			committer.Name, committer.Email = cfg.User.Name, cfg.User.Email
+		}
+		committer.When = time.Now()
+
+		copied := &object.Commit{
+			Author:       commit.Author,
+			Committer:    committer,
+			Message:      message,
+			TreeHash:     commit.TreeHash,
+			ParentHashes: parents,
+		}
+
+		obj := repo.Storer.NewEncodedObject()
+		if err := copied.Encode(obj); err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		n, err := repo.Storer.SetEncodedObject(obj)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		rewritten[hash] = n
+		return n, nil
+	}
+
+	newHead, err := rewrite(head)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to rewrite commits")
+		return "", errors.ErrFailedToRewrite
+	}
+	if newHead == head {
+		return "", nil
+	}
+
+	backup := backupRef(ctx, g, head.String())
+	if _, err := repo.Storer.Reference(plumbing.ReferenceName(backup)); err == nil {
+		g.log.Error().Str("ref", backup).Msg("Backup ref already exists")
+		return "", errors.ErrFailedToRewrite
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(backup), head)); err != nil {
+		g.log.Error().Err(err).Str("ref", backup).Msg("Failed to create backup ref")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	name := plumbing.HEAD
+	if ref, err := repo.Storer.Reference(plumbing.HEAD); err == nil && ref.Type() == plumbing.SymbolicReference {
+		name = ref.Target()
+	}
+
+	err = repo.Storer.CheckAndSetReference(plumbing.NewHashReference(name, newHead), plumbing.NewHashReference(name, head))
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to move HEAD to the rewritten commits")
+		return "", errors.ErrFailedToRewrite
+	}
+
+	g.log.Debug().Str("backup", backup).Msg("Commits rewritten successfully")
+	return backup, nil
+}
+
+// remoteCommits returns the commits reachable from the remote-tracking branches
+func remoteCommits(repo *gogit.Repository) (map[plumbing.Hash]bool, error) {
+	reachable := make(map[plumbing.Hash]bool)
+
+	refs, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refs.Close()
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsRemote() || ref.Type() != plumbing.HashReference || reachable[ref.Hash()] {
+			return nil
+		}
+
+		iter, err := repo.Log(&gogit.LogOptions{From: ref.Hash()})
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		return iter.ForEach(func(c *object.Commit) error {
+			reachable[c.Hash] = true
+			return nil
+		})
+	})
+
+	return reachable, err
+}
+
+// walkRange calls fn for the commits reachable from head and not excluded
+func walkRange(repo *gogit.Repository, head plumbing.Hash, exclude map[plumbing.Hash]bool, fn func(*object.Commit) error) error {
+	if head.IsZero() {
+		return nil
+	}
+
+	iter, err := repo.Log(&gogit.LogOptions{From: head})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	return iter.ForEach(func(c *object.Commit) error {
+		if exclude[c.Hash] {
+			return nil
+		}
+		return fn(c)
+	})
+}
+
+// logOptions are the git log options understood by the native backend
+type logOptions struct {
+	limit     int
+	revisions []string
+	paths     []string
+	// noWalk lists the revisions themselves instead of their history
+	noWalk bool
+}
+
+// parseLogOptions extracts the commit limit, the revisions and the pathspecs
+// following -- from git log options
+func parseLogOptions(opts []string) (logOptions, error) {
+	result := logOptions{limit: -1}
+
+	for i := 0; i < len(opts); i++ {
+		opt := opts[i]
+
+		var value string
+		switch {
+		case opt == "--":
+			result.paths = append(result.paths, opts[i+1:]...)
+			i = len(opts)
+			continue
+		case opt == "--no-walk":
+			result.noWalk = true
+			continue
+		case opt == "-n" && i+1 < len(opts):
+			i++
+			value = opts[i]
+		case strings.HasPrefix(opt, "--max-count="):
+			value = strings.TrimPrefix(opt, "--max-count=")
+		case strings.HasPrefix(opt, "-n"):
+			value = strings.TrimPrefix(opt, "-n")
+		case strings.HasPrefix(opt, "-"):
+			value = strings.TrimPrefix(opt, "-")
+			if _, err := strconv.Atoi(value); err != nil {
+				return logOptions{}, fmt.Errorf("%w: git log %s", errors.ErrUnsupportedByBackend, opt)
+			}
+		default:
+			result.revisions = append(result.revisions, opt)
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return logOptions{}, fmt.Errorf("%w: git log %s", errors.ErrUnsupportedByBackend, opt)
+		}
+		result.limit = n
+	}
+
+	if len(result.revisions) > 1 && !result.noWalk {
+		return logOptions{}, fmt.Errorf("%w: git log with several revisions", errors.ErrUnsupportedByBackend)
+	}
+	if result.noWalk && len(result.paths) > 0 {
+		return logOptions{}, fmt.Errorf("%w: git log --no-walk with paths", errors.ErrUnsupportedByBackend)
+	}
+
+	return result, nil
+}
+
+// resolveRange returns the commit to start the log from and the commits
+// reachable from the excluded side of an A..B range
+func resolveRange(repo *gogit.Repository, revisions []string) (plumbing.Hash, map[plumbing.Hash]bool, error) {
+	exclude := make(map[plumbing.Hash]bool)
+
+	to := "HEAD"
+	if len(revisions) == 1 {
+		to = revisions[0]
+	}
+
+	if base, tip, ok := strings.Cut(to, ".."); ok {
+		to = tip
+		if to == "" {
+			to = "HEAD"
+		}
+
+		hash, err := repo.ResolveRevision(plumbing.Revision(base))
+		if err != nil {
+			return plumbing.ZeroHash, nil, err
+		}
+
+		iter, err := repo.Log(&gogit.LogOptions{From: *hash})
+		if err != nil {
+			return plumbing.ZeroHash, nil, err
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			exclude[c.Hash] = true
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return plumbing.ZeroHash, nil, err
+		}
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(to))
+	if err == plumbing.ErrReferenceNotFound && to == "HEAD" {
+		return plumbing.ZeroHash, exclude, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	return *hash, exclude, nil
+}
+
+// nativeCommit converts a go-git commit object with its decoration
+func nativeCommit(c *object.Commit, refs []string) Commit {
+	parents := make([]string, 0, len(c.ParentHashes))
+	for _, p := range c.ParentHashes {
+		parents = append(parents, p.String())
+	}
+
+	subject, body, trailers := ParseMessage(c.Message)
+
+	return Commit{
+		Hash:     c.Hash.String(),
+		Parents:  parents,
+		Author:   c.Author.Name,
+		Email:    c.Author.Email,
+		Date:     c.Author.When,
+		Subject:  subject,
+		Body:     body,
+		Trailers: trailers,
+		Refs:     refs,
+		Message:  c.Message,
+	}
+}
+
+// decorations maps commits to the refs pointing at them, named like git's %D
+// placeholder: "HEAD -> main", branches, remote branches and "tag: v1.0.0"
+func decorations(repo *gogit.Repository) (map[plumbing.Hash][]string, error) {
+	result := make(map[plumbing.Hash][]string)
+
+	head, err := repo.Head()
+	if err != nil && err != plumbing.ErrReferenceNotFound {
+		return nil, err
+	}
+
+	iter, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	branches := make(map[plumbing.Hash][]string)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		name := ref.Name()
+		hash := ref.Hash()
+
+		switch {
+		case name.IsTag():
+			if tag, err := repo.TagObject(hash); err == nil {
+				commit, err := tag.Commit()
+				if err != nil {
+					return nil
+				}
+				hash = commit.Hash
+			}
+			result[hash] = append(result[hash], "tag: "+name.Short())
+		case name.IsBranch():
+			label := name.Short()
+			if head != nil && head.Name() == name {
+				label = "HEAD -> " + label
+				branches[hash] = append([]string{label}, branches[hash]...)
+				return nil
+			}
+			branches[hash] = append(branches[hash], label)
+		case name.IsRemote():
+			branches[hash] = append(branches[hash], name.Short())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if head != nil && !head.Name().IsBranch() {
+		branches[head.Hash()] = append([]string{"HEAD"}, branches[head.Hash()]...)
+	}
+
+	for hash, names := range branches {
+		result[hash] = append(names, result[hash]...)
+	}
+
+	return result, nil
+}
+
+// sortPairs orders pairs by destination path
+func sortPairs(pairs []pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].path() < pairs[j].path()
+	})
+}
@@ -0,0 +1,651 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// binarySniffLength is the number of leading bytes checked for NUL, as git does
+	binarySniffLength = 8000
+	// statBarWidth is the widest +/- bar printed in the stat overview
+	statBarWidth = 50
+)
+
+// entry is a file in the HEAD tree, the index or the working tree
+type entry struct {
+	path    string
+	mode    filemode.FileMode
+	hash    plumbing.Hash
+	read    func() ([]byte, error)
+	content []byte
+	loaded  bool
+}
+
+// Hash returns the object name of the file contents
+func (e *entry) Hash() plumbing.Hash { return e.hash }
+
+// Mode returns the file mode
+func (e *entry) Mode() filemode.FileMode { return e.mode }
+
+// Path returns the repository-relative path
+func (e *entry) Path() string { return e.path }
+
+// bytes returns the file contents, reading them once
+func (e *entry) bytes() ([]byte, error) {
+	if !e.loaded {
+		content, err := e.read()
+		if err != nil {
+			return nil, err
+		}
+		e.content = content
+		e.loaded = true
+	}
+	return e.content, nil
+}
+
+// pair is a file before and after a change, where a nil side means the file
+// was added or deleted
+type pair struct {
+	from *entry
+	to   *entry
+}
+
+// path returns the destination path of the change
+func (p pair) path() string {
+	if p.to != nil {
+		return p.to.path
+	}
+	return p.from.path
+}
+
+// isRename reports whether the file moved
+func (p pair) isRename() bool {
+	return p.from != nil && p.to != nil && p.from.path != p.to.path
+}
+
+// changed reports whether the two sides differ
+func (p pair) changed() bool {
+	if p.from == nil || p.to == nil {
+		return p.from != p.to
+	}
+	return p.from.hash != p.to.hash || p.from.mode != p.to.mode || p.isRename()
+}
+
+// status returns the git --name-status letter of the change
+func (p pair) status() string {
+	switch {
+	case p.from == nil:
+		return "A"
+	case p.to == nil:
+		return "D"
+	case p.isRename():
+		return "R100"
+	default:
+		return "M"
+	}
+}
+
+// change describes the pair with line counts and sizes
+func (p pair) change() (Change, error) {
+	c := Change{
+		Status:  p.status(),
+		Path:    p.path(),
+		OldMode: "000000",
+		NewMode: "000000",
+		OldBlob: nullBlob,
+		NewBlob: nullBlob,
+	}
+
+	var fromContent, toContent []byte
+	var err error
+
+	if p.from != nil {
+		if fromContent, err = p.from.bytes(); err != nil {
+			return Change{}, err
+		}
+		c.OldMode = fmt.Sprintf("%06o", uint32(p.from.mode))
+		c.OldBlob = p.from.hash.String()
+		c.OldSize = int64(len(fromContent))
+	}
+
+	if p.to != nil {
+		if toContent, err = p.to.bytes(); err != nil {
+			return Change{}, err
+		}
+		c.NewMode = fmt.Sprintf("%06o", uint32(p.to.mode))
+		c.NewBlob = p.to.hash.String()
+		c.NewSize = int64(len(toContent))
+	}
+
+	if p.isRename() {
+		c.OldPath = p.from.path
+	}
+
+	if isBinary(fromContent) || isBinary(toContent) {
+		c.Binary = true
+		return c, nil
+	}
+
+	for _, d := range diff.Do(string(fromContent), string(toContent)) {
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			c.Added += countLines(d.Text)
+		case diffmatchpatch.DiffDelete:
+			c.Deleted += countLines(d.Text)
+		}
+	}
+
+	return c, nil
+}
+
+// stagedPairs compares the HEAD tree with the index
+func stagedPairs(repo *gogit.Repository) ([]pair, error) {
+	head, err := headEntries(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := indexEntries(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareEntries(head, staged), nil
+}
+
+// branchPairs compares the merge base of base and HEAD with the HEAD tree
+func branchPairs(repo *gogit.Repository, base string) ([]pair, error) {
+	headHash, err := repo.ResolveRevision(plumbing.Revision("HEAD"))
+	if err != nil {
+		return nil, err
+	}
+	baseHash, err := repo.ResolveRevision(plumbing.Revision(base))
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.CommitObject(*headHash)
+	if err != nil {
+		return nil, err
+	}
+	other, err := repo.CommitObject(*baseHash)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := head.MergeBase(other)
+	if err != nil {
+		return nil, err
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("no merge base between %s and HEAD", base)
+	}
+
+	from, err := commitEntries(repo, bases[0])
+	if err != nil {
+		return nil, err
+	}
+	to, err := commitEntries(repo, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareEntries(from, to), nil
+}
+
+// commitPairs compares a commit with its first parent, or with an empty tree
+// for a root commit
+func commitPairs(repo *gogit.Repository, rev string) ([]pair, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	from := make(map[string]*entry)
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if from, err = commitEntries(repo, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	to, err := commitEntries(repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareEntries(from, to), nil
+}
+
+// pairPaths returns the paths of the pairs, with both sides of renames
+func pairPaths(pairs []pair) []string {
+	var paths []string
+	for _, p := range pairs {
+		if p.isRename() {
+			paths = append(paths, p.from.path)
+		}
+		paths = append(paths, p.path())
+	}
+	return paths
+}
+
+// stagedPair compares a single path between the HEAD tree and the index
+func stagedPair(repo *gogit.Repository, path string) (pair, error) {
+	head, err := headEntries(repo)
+	if err != nil {
+		return pair{}, err
+	}
+
+	staged, err := indexEntries(repo)
+	if err != nil {
+		return pair{}, err
+	}
+
+	return pair{from: head[path], to: staged[path]}, nil
+}
+
+// unstagedPair compares a single path between the index and the working tree
+func unstagedPair(repo *gogit.Repository, path string) (pair, error) {
+	staged, err := indexEntries(repo)
+	if err != nil {
+		return pair{}, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return pair{}, err
+	}
+
+	working, err := workingEntry(wt.Filesystem, path)
+	if err != nil {
+		return pair{}, err
+	}
+
+	return pair{from: staged[path], to: working}, nil
+}
+
+// unstagedPairs compares every tracked file of the index with the working tree
+func unstagedPairs(repo *gogit.Repository) ([]pair, error) {
+	staged, err := indexEntries(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []pair
+	for path, e := range staged {
+		working, err := workingEntry(wt.Filesystem, path)
+		if err != nil {
+			return nil, err
+		}
+
+		p := pair{from: e, to: working}
+		if p.changed() {
+			pairs = append(pairs, p)
+		}
+	}
+
+	sortPairs(pairs)
+	return pairs, nil
+}
+
+// workingEntry reads a file of the working tree, or returns nil when it was removed
+func workingEntry(fs billy.Filesystem, path string) (*entry, error) {
+	info, err := fs.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if mode == filemode.Symlink {
+		target, err := fs.Readlink(path)
+		if err != nil {
+			return nil, err
+		}
+		content = []byte(target)
+	} else {
+		f, err := fs.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		content, err = io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &entry{
+		path:    path,
+		mode:    mode,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, content),
+		content: content,
+		loaded:  true,
+	}, nil
+}
+
+// headEntries returns the files of the HEAD commit, or none on an unborn branch
+func headEntries(repo *gogit.Repository) (map[string]*entry, error) {
+	ref, err := repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return make(map[string]*entry), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return commitEntries(repo, commit)
+}
+
+// commitEntries returns the files of the tree of a commit
+func commitEntries(repo *gogit.Repository, commit *object.Commit) (map[string]*entry, error) {
+	entries := make(map[string]*entry)
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		entries[f.Name] = &entry{path: f.Name, mode: f.Mode, hash: f.Hash, read: blobReader(repo, f.Hash)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// indexEntries returns the merged files of the index
+func indexEntries(repo *gogit.Repository) (map[string]*entry, error) {
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*entry, len(idx.Entries))
+	for _, e := range idx.Entries {
+		// conflicted paths are listed once per side with a non-zero stage
+		if e.Stage != 0 || e.Mode == filemode.Submodule {
+			continue
+		}
+		entries[e.Name] = &entry{path: e.Name, mode: e.Mode, hash: e.Hash, read: blobReader(repo, e.Hash)}
+	}
+
+	return entries, nil
+}
+
+// blobReader returns a function reading the contents of a blob
+func blobReader(repo *gogit.Repository, hash plumbing.Hash) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		blob, err := repo.BlobObject(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := blob.Reader()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return io.ReadAll(r)
+	}
+}
+
+// compareEntries returns the changed files between two snapshots, pairing
+// deleted and added files with identical contents as renames
+func compareEntries(from, to map[string]*entry) []pair {
+	var pairs []pair
+	var added []*entry
+	deleted := make(map[plumbing.Hash][]*entry)
+
+	for path, f := range from {
+		t, ok := to[path]
+		switch {
+		case !ok:
+			deleted[f.hash] = append(deleted[f.hash], f)
+		case f.hash != t.hash || f.mode != t.mode:
+			pairs = append(pairs, pair{from: f, to: t})
+		}
+	}
+
+	for path, t := range to {
+		if _, ok := from[path]; !ok {
+			added = append(added, t)
+		}
+	}
+
+	for _, t := range added {
+		if candidates := deleted[t.hash]; len(candidates) > 0 {
+			pairs = append(pairs, pair{from: candidates[0], to: t})
+			deleted[t.hash] = candidates[1:]
+			continue
+		}
+		pairs = append(pairs, pair{to: t})
+	}
+
+	for _, remaining := range deleted {
+		for _, f := range remaining {
+			pairs = append(pairs, pair{from: f})
+		}
+	}
+
+	sortPairs(pairs)
+	return pairs
+}
+
+// encodePatch renders pairs as a unified diff
+func encodePatch(pairs []pair) (string, error) {
+	if len(pairs) == 0 {
+		return "", nil
+	}
+
+	p := &patch{}
+	for _, pr := range pairs {
+		fp, err := newFilePatch(pr)
+		if err != nil {
+			return "", err
+		}
+		p.files = append(p.files, fp)
+	}
+
+	var buf bytes.Buffer
+	if err := fdiff.NewUnifiedEncoder(&buf, fdiff.DefaultContextLines).Encode(p); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// newFilePatch computes the line chunks of a pair
+func newFilePatch(p pair) (*filePatch, error) {
+	fp := &filePatch{}
+
+	var fromContent, toContent []byte
+	var err error
+
+	if p.from != nil {
+		fp.from = p.from
+		if fromContent, err = p.from.bytes(); err != nil {
+			return nil, err
+		}
+	}
+	if p.to != nil {
+		fp.to = p.to
+		if toContent, err = p.to.bytes(); err != nil {
+			return nil, err
+		}
+	}
+
+	if isBinary(fromContent) || isBinary(toContent) {
+		fp.binary = true
+		return fp, nil
+	}
+
+	for _, d := range diff.Do(string(fromContent), string(toContent)) {
+		op := fdiff.Equal
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		}
+		fp.chunks = append(fp.chunks, chunk{content: d.Text, op: op})
+	}
+
+	return fp, nil
+}
+
+// patch implements the go-git patch interface for the unified encoder
+type patch struct {
+	files []fdiff.FilePatch
+}
+
+// FilePatches returns the patches of every file
+func (p *patch) FilePatches() []fdiff.FilePatch { return p.files }
+
+// Message returns an empty message
+func (p *patch) Message() string { return "" }
+
+// filePatch is the change of a single file
+type filePatch struct {
+	from   fdiff.File
+	to     fdiff.File
+	chunks []fdiff.Chunk
+	binary bool
+}
+
+// IsBinary reports whether either side is binary
+func (f *filePatch) IsBinary() bool { return f.binary }
+
+// Files returns both sides of the change
+func (f *filePatch) Files() (fdiff.File, fdiff.File) { return f.from, f.to }
+
+// Chunks returns the line operations
+func (f *filePatch) Chunks() []fdiff.Chunk { return f.chunks }
+
+// chunk is a run of equal, added or deleted lines
+type chunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+// Content returns the lines of the chunk
+func (c chunk) Content() string { return c.content }
+
+// Type returns the operation of the chunk
+func (c chunk) Type() fdiff.Operation { return c.op }
+
+// formatStat renders changes like git diff --stat
+func formatStat(changes []Change) string {
+	names := make([]string, len(changes))
+	nameWidth, maxCount := 0, 0
+	added, deleted := 0, 0
+
+	for i, c := range changes {
+		names[i] = c.Path
+		if c.IsRename() {
+			names[i] = c.OldPath + " => " + c.Path
+		}
+		nameWidth = max(nameWidth, len(names[i]))
+		maxCount = max(maxCount, c.Added+c.Deleted)
+		added += c.Added
+		deleted += c.Deleted
+	}
+
+	countWidth := len(fmt.Sprint(maxCount))
+
+	var sb strings.Builder
+	for i, c := range changes {
+		if c.Binary {
+			fmt.Fprintf(&sb, " %-*s | Bin %d -> %d bytes\n", nameWidth, names[i], c.OldSize, c.NewSize)
+			continue
+		}
+
+		plus, minus := c.Added, c.Deleted
+		if maxCount > statBarWidth {
+			plus = (plus*statBarWidth + maxCount - 1) / maxCount
+			minus = (minus*statBarWidth + maxCount - 1) / maxCount
+		}
+		fmt.Fprintf(&sb, " %-*s | %*d %s%s\n", nameWidth, names[i], countWidth, c.Added+c.Deleted, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+
+	fmt.Fprintf(&sb, " %d %s changed", len(changes), plural(len(changes), "file"))
+	if added > 0 {
+		fmt.Fprintf(&sb, ", %d %s(+)", added, plural(added, "insertion"))
+	}
+	if deleted > 0 {
+		fmt.Fprintf(&sb, ", %d %s(-)", deleted, plural(deleted, "deletion"))
+	}
+
+	return sb.String()
+}
+
+// plural returns the noun with an s unless n is one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// isBinary reports whether the content contains a NUL byte near the start
+func isBinary(content []byte) bool {
+	if len(content) > binarySniffLength {
+		content = content[:binarySniffLength]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// countLines returns the number of lines in a diff chunk
+func countLines(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	n := strings.Count(text, "\n")
+	if !strings.HasSuffix(text, "\n") {
+		n++
+	}
+	return n
+}
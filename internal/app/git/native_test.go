@@ -0,0 +1,812 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// memoryRepo is an in-memory repository with a worktree used to exercise the native client
+type memoryRepo struct {
+	t    *testing.T
+	repo *gogit.Repository
+	wt   *gogit.Worktree
+}
+
+// newMemoryRepo creates an empty in-memory repository with a configured user
+func newMemoryRepo(t *testing.T) *memoryRepo {
+	t.Helper()
+
+	repo, err := gogit.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.User.Name = "Jane Doe"
+	cfg.User.Email = "jane@example.com"
+	cfg.Raw.Section("user").SetOption("name", "Jane Doe")
+	cfg.Raw.Section("core").SetOption("editor", "nano")
+	require.NoError(t, repo.SetConfig(cfg))
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	return &memoryRepo{t: t, repo: repo, wt: wt}
+}
+
+// write creates or overwrites a file in the worktree
+func (r *memoryRepo) write(path, content string) {
+	r.t.Helper()
+	require.NoError(r.t, util.WriteFile(r.wt.Filesystem, path, []byte(content), 0o644))
+}
+
+// stage adds a path to the index
+func (r *memoryRepo) stage(path string) {
+	r.t.Helper()
+	_, err := r.wt.Add(path)
+	require.NoError(r.t, err)
+}
+
+// commit records the index with a fixed author and date
+func (r *memoryRepo) commit(message string, when time.Time) {
+	r.t.Helper()
+	_, err := r.wt.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{Name: "Jane Doe", Email: "jane@example.com", When: when},
+	})
+	require.NoError(r.t, err)
+}
+
+// newTestNativeClient returns a native client on the repository with a silent logger
+func newTestNativeClient(t *testing.T, repo *gogit.Repository) Client {
+	ctrl := gomock.NewController(t)
+	nopLogger := zerolog.Nop()
+
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
+	mockLogger.EXPECT().Info().AnyTimes().Return(nopLogger.Info())
+	mockLogger.EXPECT().Error().AnyTimes().Return(nopLogger.Error())
+
+	return NewNativeClient(repo, config.DefaultConfig(), mockLogger)
+}
+
+func Test_NewClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExecutor := NewMockExecutor(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	tests := []struct {
+		name    string
+		backend string
+		checkFn func(*testing.T, Client)
+	}{
+		{
+			name:    "Success with git binary backend",
+			backend: config.GitBackendCLI,
+			checkFn: func(t *testing.T, c Client) {
+				_, ok := c.(*client)
+				assert.True(t, ok)
+			},
+		},
+		{
+			name:    "Success with native backend",
+			backend: config.GitBackendNative,
+			checkFn: func(t *testing.T, c Client) {
+				_, ok := c.(*nativeClient)
+				assert.True(t, ok)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Git.Backend = tt.backend
+
+			tt.checkFn(t, NewClient(mockExecutor, cfg, mockLogger))
+		})
+	}
+}
+
+func Test_NativeClient_Status(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		setup    func(*memoryRepo)
+		expected string
+		err      error
+	}{
+		{
+			name: "Success with added, modified, deleted and renamed files",
+			setup: func(r *memoryRepo) {
+				r.write("keep.txt", "one\n")
+				r.write("remove.txt", "gone\n")
+				r.write("old.txt", "moved\n")
+				r.stage(".")
+				r.commit("initial", time.Now())
+
+				r.write("keep.txt", "one\ntwo\n")
+				r.write("new.txt", "fresh\n")
+				require.NoError(t, r.wt.Filesystem.Rename("old.txt", "moved.txt"))
+				require.NoError(t, r.wt.Filesystem.Remove("remove.txt"))
+				require.NoError(t, r.wt.AddWithOptions(&gogit.AddOptions{All: true}))
+			},
+			expected: "M\tkeep.txt\nR100\told.txt\tmoved.txt\nA\tnew.txt\nD\tremove.txt",
+		},
+		{
+			name: "Success on an unborn branch",
+			setup: func(r *memoryRepo) {
+				r.write("main.go", "package main\n")
+				r.stage("main.go")
+			},
+			expected: "A\tmain.go",
+		},
+		{
+			name: "Failure with nothing staged",
+			setup: func(r *memoryRepo) {
+				r.write("main.go", "package main\n")
+				r.stage("main.go")
+				r.commit("initial", time.Now())
+			},
+			err: errors.ErrNoGitChanges,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newMemoryRepo(t)
+			tt.setup(r)
+
+			output, err := newTestNativeClient(t, r.repo).Status(ctx)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func Test_NativeClient_UnstagedStatus(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		setup    func(*memoryRepo)
+		expected string
+	}{
+		{
+			name: "Success with modified and deleted files",
+			setup: func(r *memoryRepo) {
+				r.write("keep.txt", "one\n")
+				r.write("remove.txt", "gone\n")
+				r.write("staged.txt", "one\n")
+				r.stage(".")
+				r.commit("initial", time.Now())
+
+				r.write("staged.txt", "one\ntwo\n")
+				r.stage("staged.txt")
+				r.write("keep.txt", "one\ntwo\n")
+				r.write("untracked.txt", "new\n")
+				require.NoError(t, r.wt.Filesystem.Remove("remove.txt"))
+			},
+			expected: "M\tkeep.txt\nD\tremove.txt",
+		},
+		{
+			name: "Success with a clean working tree",
+			setup: func(r *memoryRepo) {
+				r.write("main.go", "package main\n")
+				r.stage("main.go")
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newMemoryRepo(t)
+			tt.setup(r)
+
+			output, err := newTestNativeClient(t, r.repo).UnstagedStatus(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func Test_NativeClient_Diff(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("main.go", "package main\n\nfunc main() {}\n")
+	r.write("logo.png", "\x89PNG\x00\x01")
+	r.stage(".")
+	r.commit("initial", time.Now())
+
+	r.write("main.go", "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n")
+	r.write("logo.png", "\x89PNG\x00\x01\x02\x03")
+	r.stage(".")
+
+	output, err := newTestNativeClient(t, r.repo).Diff(ctx)
+
+	require.NoError(t, err)
+	assert.Contains(t, output, " logo.png | Bin 6 -> 8 bytes\n main.go  | 4 +++-\n 2 files changed, 3 insertions(+), 1 deletion(-)")
+	assert.Contains(t, output, "logo.png: binary, 6B -> 8B")
+	assert.Contains(t, output, "diff --git a/main.go b/main.go")
+	assert.Contains(t, output, "+\tprintln(\"hi\")")
+	assert.NotContains(t, output, "diff --git a/logo.png")
+}
+
+func Test_NativeClient_BranchDiff(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("main.go", "package main\n")
+	r.stage(".")
+	r.commit("initial", time.Now())
+
+	require.NoError(t, r.wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("main"), Create: true}))
+	r.write("base.go", "package main\n\nconst base = true\n")
+	r.stage(".")
+	r.commit("change on base", time.Now())
+
+	require.NoError(t, r.wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.Master}))
+	r.write("feature.go", "package main\n\nfunc feature() {}\n")
+	r.stage(".")
+	r.commit("add feature", time.Now())
+
+	client := newTestNativeClient(t, r.repo)
+
+	output, err := client.BranchDiff(ctx, "main")
+	require.NoError(t, err)
+	assert.Contains(t, output, "diff --git a/feature.go b/feature.go")
+	assert.Contains(t, output, "+func feature() {}")
+	assert.NotContains(t, output, "base.go")
+
+	_, err = client.BranchDiff(ctx, "unknown")
+	assert.ErrorIs(t, err, errors.ErrFailedToLoadGitDiff)
+
+	_, err = client.BranchDiff(ctx, "master")
+	assert.ErrorIs(t, err, errors.ErrNoGitChanges)
+}
+
+func Test_NativeClient_Log(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	r := newMemoryRepo(t)
+	r.write("a.txt", "a\n")
+	r.stage("a.txt")
+	r.commit("feat: Add a\n\nWith a body\n\nRefs: JIRA-1", now.Add(-3*24*time.Hour))
+	r.write("b.txt", "b\n")
+	r.stage("b.txt")
+	r.commit("fix: Add b", now.Add(-2*time.Hour))
+
+	head, err := r.repo.Head()
+	require.NoError(t, err)
+	first, err := r.repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	base := first.ParentHashes[0].String()
+
+	_, err = r.repo.CreateTag("v1.0.0", first.ParentHashes[0], nil)
+	require.NoError(t, err)
+
+	gitClient := newTestNativeClient(t, r.repo)
+
+	tests := []struct {
+		name     string
+		opts     []string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "Success with full history",
+			opts:     nil,
+			expected: []string{"fix: Add b", "feat: Add a"},
+		},
+		{
+			name:     "Success with limit",
+			opts:     []string{"-n", "1"},
+			expected: []string{"fix: Add b"},
+		},
+		{
+			name:     "Success with range",
+			opts:     []string{base + "..HEAD"},
+			expected: []string{"fix: Add b"},
+		},
+		{
+			name:     "Success with listed commits",
+			opts:     []string{"--no-walk", base, "HEAD", base},
+			expected: []string{"fix: Add b", "feat: Add a"},
+		},
+		{
+			name:     "Success with pathspec",
+			opts:     []string{"--", ":(top,glob)a.*"},
+			expected: []string{"feat: Add a"},
+		},
+		{
+			name: "Failure with unsupported option",
+			opts: []string{"--reverse"},
+			err:  errors.ErrUnsupportedByBackend,
+		},
+		{
+			name: "Failure with unsupported pathspec magic",
+			opts: []string{"--", ":(icase)A.txt"},
+			err:  errors.ErrUnsupportedByBackend,
+		},
+		{
+			name: "Failure with unknown revision",
+			opts: []string{"v9.9.9"},
+			err:  errors.ErrFailedToLoadGitLog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := gitClient.Log(ctx, tt.opts)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, commits, len(tt.expected))
+			for i, subject := range tt.expected {
+				assert.Equal(t, subject, commits[i].Subject)
+				assert.Len(t, commits[i].Hash, 40)
+				assert.Equal(t, "Jane Doe", commits[i].Author)
+				assert.Equal(t, "jane@example.com", commits[i].Email)
+			}
+		})
+	}
+
+	commits, err := gitClient.Log(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	assert.Equal(t, []string{"HEAD -> master"}, commits[0].Refs)
+	assert.Equal(t, []string{base}, commits[0].Parents)
+	assert.True(t, commits[0].Date.Equal(now.Add(-2*time.Hour)))
+
+	assert.Equal(t, []string{"tag: v1.0.0"}, commits[1].Refs)
+	assert.Equal(t, []string{"v1.0.0"}, commits[1].Tags())
+	assert.Empty(t, commits[1].Parents)
+	assert.Equal(t, "With a body", commits[1].Body)
+	assert.Equal(t, []Trailer{{Key: "Refs", Value: "JIRA-1"}}, commits[1].Trailers)
+}
+
+func Test_NativeClient_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	gitClient := newTestNativeClient(t, r.repo)
+
+	_, err := gitClient.Tags(ctx)
+	assert.ErrorIs(t, err, errors.ErrFailedToLoadGitTags)
+
+	r.write("a.txt", "a\n")
+	r.stage("a.txt")
+	r.commit("feat: Add a", time.Now())
+
+	head, err := r.repo.Head()
+	require.NoError(t, err)
+	_, err = r.repo.CreateTag("v1.0.0", head.Hash(), nil)
+	require.NoError(t, err)
+	_, err = r.repo.CreateTag("v1.1.0", head.Hash(), &gogit.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "Jane Doe", Email: "jane@example.com", When: time.Now()},
+		Message: "Release v1.1.0",
+	})
+	require.NoError(t, err)
+
+	tags, err := gitClient.Tags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0"}, tags)
+}
+
+func Test_NativeClient_CreateTag(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("a.txt", "a\n")
+	r.stage("a.txt")
+	r.commit("feat: Add a", time.Now())
+
+	gitClient := newTestNativeClient(t, r.repo)
+
+	require.NoError(t, gitClient.CreateTag(ctx, "v1.0.0", "Release notes"))
+
+	ref, err := r.repo.Tag("v1.0.0")
+	require.NoError(t, err)
+	tag, err := r.repo.TagObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "Release notes\n", tag.Message)
+	assert.Equal(t, "Jane Doe", tag.Tagger.Name)
+
+	err = gitClient.CreateTag(ctx, "v1.0.0", "again")
+	assert.ErrorIs(t, err, errors.ErrFailedToCreateTag)
+}
+
+func Test_NativeClient_Commit(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("main.go", "package main\n")
+	r.stage("main.go")
+
+	gitClient := newTestNativeClient(t, r.repo)
+
+	_, err := gitClient.Commit(ctx, "")
+	assert.ErrorIs(t, err, errors.ErrCommitMessageEmpty)
+
+	output, err := gitClient.Commit(ctx, "feat: Add main\n\nBody")
+	require.NoError(t, err)
+	assert.Contains(t, output, "[master ")
+	assert.Contains(t, output, "] feat: Add main")
+
+	commits, err := gitClient.Log(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "feat: Add main", commits[0].Subject)
+	assert.Equal(t, "Body", commits[0].Body)
+
+	_, err = gitClient.Status(ctx)
+	assert.ErrorIs(t, err, errors.ErrNoGitChanges)
+}
+
+func Test_NativeClient_Commit_Hooks(t *testing.T) {
+	ctx := context.Background()
+
+	_, dir := newTestRepo(t)
+	writeFile(t, dir, "file.txt", "content\n")
+	runGit(t, dir, "add", "file.txt")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git", "hooks"), 0o755))
+	writeFile(t, dir, filepath.Join(".git", "hooks", "pre-commit"), "#!/bin/sh\nexit 1\n")
+
+	repo, err := gogit.PlainOpen(dir)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	nopLogger := zerolog.Nop()
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug().AnyTimes().Return(nopLogger.Debug())
+	mockLogger.EXPECT().Warn().Times(1).Return(nopLogger.Warn())
+
+	_, err = NewNativeClient(repo, config.DefaultConfig(), mockLogger).Commit(ctx, "feat: Add file")
+
+	require.NoError(t, err)
+	assert.Equal(t, "feat: Add file", strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%s")))
+}
+
+func Test_NativeClient_Operation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success with in-memory repository", func(t *testing.T) {
+		op, err := newTestNativeClient(t, newMemoryRepo(t).repo).Operation(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, Operation{}, op)
+	})
+
+	t.Run("Success with merge commit", func(t *testing.T) {
+		_, dir := newTestRepo(t)
+		writeFile(t, dir, "file.txt", "content\n")
+		runGit(t, dir, "add", "file.txt")
+		runGit(t, dir, "commit", "--quiet", "-m", "initial")
+		runGit(t, dir, "branch", "-M", "main")
+		runGit(t, dir, "checkout", "--quiet", "-b", "feature")
+		writeFile(t, dir, "feature.txt", "feature\n")
+		runGit(t, dir, "add", "feature.txt")
+		runGit(t, dir, "commit", "--quiet", "-m", "feat: Add feature")
+		runGit(t, dir, "checkout", "--quiet", "main")
+		runGit(t, dir, "merge", "--quiet", "--no-commit", "--no-ff", "feature")
+		feature := strings.TrimSpace(runGit(t, dir, "rev-parse", "feature"))
+		base := strings.TrimSpace(runGit(t, dir, "rev-parse", "main"))
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+		client := newTestNativeClient(t, repo)
+
+		op, err := client.Operation(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, OperationMerge, op.Kind)
+		assert.Equal(t, []string{feature}, op.Heads)
+		assert.Equal(t, "Merge branch 'feature'", op.Subject())
+
+		_, err = client.Commit(ctx, "Merge branch 'feature'")
+		require.NoError(t, err)
+
+		assert.Equal(t, base+" "+feature, strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%P")))
+		assert.NoFileExists(t, filepath.Join(dir, ".git", "MERGE_HEAD"))
+		assert.NoFileExists(t, filepath.Join(dir, ".git", "MERGE_MSG"))
+
+		op, err = client.Operation(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, OperationNone, op.Kind)
+	})
+
+	t.Run("Success with revert commit", func(t *testing.T) {
+		_, dir := newTestRepo(t)
+		writeFile(t, dir, "file.txt", "content\n")
+		runGit(t, dir, "add", "file.txt")
+		runGit(t, dir, "commit", "--quiet", "-m", "feat: Add file")
+		head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+		writeFile(t, dir, "file.txt", "changed\n")
+		runGit(t, dir, "commit", "--quiet", "-am", "fix: Change file")
+		reverted := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+		runGit(t, dir, "revert", "--no-commit", "HEAD")
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+		client := newTestNativeClient(t, repo)
+
+		op, err := client.Operation(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, OperationRevert, op.Kind)
+		assert.Equal(t, []string{reverted}, op.Heads)
+		assert.False(t, op.Unlisted)
+
+		_, err = client.Commit(ctx, "revert: fix: Change file")
+		require.NoError(t, err)
+
+		assert.Equal(t, reverted, strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%P")))
+		assert.Equal(t, "", runGit(t, dir, "diff", head, "HEAD"))
+		assert.NoFileExists(t, filepath.Join(dir, ".git", "REVERT_HEAD"))
+	})
+
+	t.Run("Success with revert of several commits", func(t *testing.T) {
+		_, dir := newTestRepo(t)
+		for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+			writeFile(t, dir, name, name+"\n")
+			runGit(t, dir, "add", name)
+			runGit(t, dir, "commit", "--quiet", "-m", "feat: Add "+name)
+		}
+		runGit(t, dir, "revert", "--no-commit", "HEAD~1", "HEAD")
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+
+		op, err := newTestNativeClient(t, repo).Operation(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, OperationRevert, op.Kind)
+		assert.True(t, op.Unlisted)
+	})
+}
+
+func Test_NativeClient_CommitDiff(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("main.go", "package main\n")
+	r.stage(".")
+	r.commit("initial", time.Now())
+	r.write("main.go", "package main\n\nfunc main() {}\n")
+	r.stage(".")
+	r.commit("add main", time.Now())
+
+	client := newTestNativeClient(t, r.repo)
+
+	output, err := client.CommitDiff(ctx, "HEAD")
+	require.NoError(t, err)
+	assert.Contains(t, output, "+func main() {}")
+
+	output, err = client.CommitDiff(ctx, "HEAD~1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "+package main")
+
+	_, err = client.CommitDiff(ctx, "unknown")
+	assert.ErrorIs(t, err, errors.ErrFailedToLoadGitDiff)
+}
+
+func Test_NativeClient_Rewrite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success with reworded commits", func(t *testing.T) {
+		_, dir, hashes := newRewriteRepo(t)
+		tree := runGit(t, dir, "rev-parse", "HEAD^{tree}")
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+		client := newTestNativeClient(t, repo)
+
+		pushed, err := client.Pushed(ctx, "main")
+		require.NoError(t, err)
+		assert.False(t, pushed)
+
+		backup, err := client.Rewrite(ctx, "main", map[string]string{hashes[1]: "feat: Add two lines"})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(backup, "refs/cmt/backup/feature/"))
+		assert.Equal(t, hashes[2]+"\n", runGit(t, dir, "rev-parse", backup))
+		assert.Equal(t, tree, runGit(t, dir, "rev-parse", "HEAD^{tree}"))
+		assert.Equal(t, hashes[0]+"\n", runGit(t, dir, "rev-parse", "HEAD~2"))
+		assert.Equal(t, "fixed stuff\x00feat: Add two lines\x00wip\x00initial\x00", runGit(t, dir, "log", "-z", "--format=%s", "feature"))
+		assert.Equal(t, "Jane Doe <jane@example.com>\n", runGit(t, dir, "log", "-1", "--format=%an <%ae>", "HEAD~1"))
+	})
+
+	t.Run("Success keeping trailers", func(t *testing.T) {
+		_, dir, _ := newRewriteRepo(t)
+		runGit(t, dir, "commit", "--quiet", "--amend", "-m", "fixed stuff\n\nSigned-off-by: Jane Doe <jane@example.com>")
+		head := strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+
+		_, err = newTestNativeClient(t, repo).Rewrite(ctx, "main", map[string]string{head: "fix: Repeat the line"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "fix: Repeat the line\n\nSigned-off-by: Jane Doe <jane@example.com>", strings.TrimSpace(runGit(t, dir, "log", "-1", "--format=%B")))
+	})
+
+	t.Run("Failure with pushed commits", func(t *testing.T) {
+		_, dir, hashes := newRewriteRepo(t)
+		runGit(t, dir, "update-ref", "refs/remotes/origin/feature", hashes[0])
+
+		repo, err := gogit.PlainOpen(dir)
+		require.NoError(t, err)
+
+		_, err = newTestNativeClient(t, repo).Rewrite(ctx, "main", map[string]string{hashes[1]: "feat: Add two lines"})
+
+		assert.ErrorIs(t, err, errors.ErrCommitsPushed)
+		assert.Equal(t, hashes[2]+"\n", runGit(t, dir, "rev-parse", "HEAD"))
+	})
+}
+
+func Test_NativeClient_FileDiff(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	r.write("main.go", "one\n")
+	r.stage("main.go")
+	r.commit("initial", time.Now())
+	r.write("main.go", "one\ntwo\n")
+	r.stage("main.go")
+	r.write("main.go", "one\ntwo\nthree\n")
+
+	gitClient := newTestNativeClient(t, r.repo)
+
+	staged, err := gitClient.FileDiff(ctx, "main.go", true)
+	require.NoError(t, err)
+	assert.Contains(t, staged, "+two")
+	assert.NotContains(t, staged, "+three")
+
+	unstaged, err := gitClient.FileDiff(ctx, "main.go", false)
+	require.NoError(t, err)
+	assert.Contains(t, unstaged, "+three")
+	assert.NotContains(t, unstaged, "+two")
+
+	unchanged, err := gitClient.FileDiff(ctx, "missing.go", true)
+	require.NoError(t, err)
+	assert.Empty(t, unchanged)
+}
+
+func Test_NativeClient_ApplyPatch(t *testing.T) {
+	r := newMemoryRepo(t)
+
+	err := newTestNativeClient(t, r.repo).ApplyPatch(context.Background(), "patch", false)
+	assert.ErrorIs(t, err, errors.ErrUnsupportedByBackend)
+}
+
+func Test_NativeClient_ConfigValue(t *testing.T) {
+	ctx := context.Background()
+	r := newMemoryRepo(t)
+	gitClient := newTestNativeClient(t, r.repo)
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+	}{
+		{name: "Success with set key", key: "core.editor", expected: "nano"},
+		{name: "Success with user name", key: "user.name", expected: "Jane Doe"},
+		{name: "Success with unset key", key: "commit.template", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := gitClient.ConfigValue(ctx, tt.key)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_NativeClient_CurrentBranch(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	gitClient := newTestNativeClient(t, r.repo)
+
+	branch, err := gitClient.CurrentBranch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "master", branch)
+
+	r.write("main.go", "package main\n")
+	r.stage("main.go")
+	r.commit("initial", time.Now())
+
+	head, err := r.repo.Head()
+	require.NoError(t, err)
+	require.NoError(t, r.wt.Checkout(&gogit.CheckoutOptions{Hash: head.Hash()}))
+
+	_, err = gitClient.CurrentBranch(ctx)
+	assert.ErrorIs(t, err, errors.ErrDetachedHead)
+}
+
+func Test_NativeClient_DefaultBranch(t *testing.T) {
+	ctx := context.Background()
+
+	r := newMemoryRepo(t)
+	gitClient := newTestNativeClient(t, r.repo)
+
+	branch, err := gitClient.DefaultBranch(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, branch)
+
+	ref := plumbing.NewSymbolicReference(originHead, "refs/remotes/origin/develop")
+	require.NoError(t, r.repo.Storer.SetReference(ref))
+
+	branch, err = gitClient.DefaultBranch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "develop", branch)
+}
+
+func Test_ParseLogOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []string
+		limit     int
+		revisions []string
+		paths     []string
+		expectErr bool
+	}{
+		{name: "Success without options", opts: nil, limit: -1},
+		{name: "Success with -n", opts: []string{"-n", "20"}, limit: 20},
+		{name: "Success with attached -n", opts: []string{"-n5"}, limit: 5},
+		{name: "Success with number", opts: []string{"-3"}, limit: 3},
+		{name: "Success with max count and range", opts: []string{"--max-count=2", "v1.0.0..HEAD"}, limit: 2, revisions: []string{"v1.0.0..HEAD"}},
+		{name: "Success with pathspecs", opts: []string{"v1.0.0..HEAD", "--", ":(top,glob)services/api", "-n"}, limit: -1, revisions: []string{"v1.0.0..HEAD"}, paths: []string{":(top,glob)services/api", "-n"}},
+		{name: "Success with listed revisions", opts: []string{"--no-walk", "main", "dev"}, limit: -1, revisions: []string{"main", "dev"}},
+		{name: "Failure with unsupported flag", opts: []string{"--merges"}, expectErr: true},
+		{name: "Failure with listed revisions and pathspecs", opts: []string{"--no-walk", "main", "--", "api"}, expectErr: true},
+		{name: "Failure with several revisions", opts: []string{"main", "dev"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseLogOptions(tt.opts)
+
+			if tt.expectErr {
+				assert.ErrorIs(t, err, errors.ErrUnsupportedByBackend)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.limit, result.limit)
+			assert.Equal(t, tt.revisions, result.revisions)
+			assert.Equal(t, tt.paths, result.paths)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OperationKind is the git operation that the staged changes conclude
+type OperationKind string
+
+const (
+	// OperationNone means the staged changes make an ordinary commit
+	OperationNone OperationKind = ""
+	// OperationMerge means a merge stopped before committing and MERGE_HEAD is set
+	OperationMerge OperationKind = "merge"
+	// OperationSquash means git merge --squash staged the changes and wrote SQUASH_MSG
+	OperationSquash OperationKind = "squash"
+	// OperationRevert means git revert --no-commit staged the inverse of a commit
+	OperationRevert OperationKind = "revert"
+)
+
+// State files written by git into the git directory while an operation is in progress
+const (
+	mergeHeadFile  = "MERGE_HEAD"
+	mergeMsgFile   = "MERGE_MSG"
+	mergeModeFile  = "MERGE_MODE"
+	squashMsgFile  = "SQUASH_MSG"
+	revertHeadFile = "REVERT_HEAD"
+	// sequencerTodoFile lists the commits of a revert of several commits stopped by a conflict
+	sequencerTodoFile = "sequencer/todo"
+)
+
+var (
+	// squashCommitPattern matches the commit lines listed in SQUASH_MSG
+	squashCommitPattern = regexp.MustCompile(`(?m)^commit ([0-9a-f]{40,64})\b`)
+	// todoRevertPattern matches the revert commands of the sequencer todo list
+	todoRevertPattern = regexp.MustCompile(`(?m)^revert ([0-9a-f]{7,64})\b`)
+	// revertedPattern matches the sentence git adds to the message of a revert
+	revertedPattern = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-f]{7,64})\b`)
+)
+
+// Operation is the merge, squash or revert in progress in the repository
+type Operation struct {
+	Kind OperationKind
+	// Heads are the commits being merged or reverted, or the squashed commits newest first
+	Heads []string
+	// Unlisted reports that a revert stages changes to files the reverted commits do not
+	// touch, as after git revert --no-commit of several commits, which only records the last
+	Unlisted bool
+	// Message is the commit message prepared by git
+	Message string
+}
+
+// Subject returns the first line of the message prepared by git, such as
+// Merge branch 'feature', ignoring comment lines
+func (o Operation) Subject() string {
+	for _, line := range strings.Split(o.Message, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+
+	if o.Kind == OperationMerge && len(o.Heads) > 0 {
+		return "Merge commit '" + Commit{Hash: o.Heads[0]}.ShortHash() + "'"
+	}
+	return ""
+}
+
+// parseOperation detects the operation in progress from the state files of
+// the git directory, read returns false when a file does not exist
+func parseOperation(read func(name string) (string, bool)) Operation {
+	if head, ok := read(revertHeadFile); ok {
+		message, _ := read(mergeMsgFile)
+
+		// A revert of several commits keeps only the current one in REVERT_HEAD
+		heads := strings.Fields(head)
+		todo, _ := read(sequencerTodoFile)
+		if matches := todoRevertPattern.FindAllStringSubmatch(todo, -1); len(matches) > 1 {
+			heads = submatches(matches)
+		} else if matches := revertedPattern.FindAllStringSubmatch(message, -1); len(matches) > 1 {
+			heads = submatches(matches)
+		}
+
+		return Operation{Kind: OperationRevert, Heads: heads, Message: message}
+	}
+
+	if heads, ok := read(mergeHeadFile); ok {
+		message, _ := read(mergeMsgFile)
+		return Operation{Kind: OperationMerge, Heads: strings.Fields(heads), Message: message}
+	}
+
+	if message, ok := read(squashMsgFile); ok {
+		heads := submatches(squashCommitPattern.FindAllStringSubmatch(message, -1))
+		return Operation{Kind: OperationSquash, Heads: heads, Message: message}
+	}
+
+	return Operation{}
+}
+
+// submatches returns the first group of every match
+func submatches(matches [][]string) []string {
+	var result []string
+	for _, match := range matches {
+		result = append(result, match[1])
+	}
+	return result
+}
+
+// unlistedPaths reports whether a staged path is missing from the paths
+// changed by the reverted commits
+func unlistedPaths(staged, reverted []string) bool {
+	listed := make(map[string]bool, len(reverted))
+	for _, path := range reverted {
+		listed[path] = true
+	}
+
+	for _, path := range staged {
+		if path != "" && !listed[path] {
+			return true
+		}
+	}
+	return false
+}
+
+// RevertMessage returns the conventional commit message of a commit reverting
+// the given ones: revert: followed by the reverted subject, the sentence git
+// adds to reverts and a Refs trailer. Several reverted commits are counted in
+// the subject and each gets its own sentence
+func RevertMessage(reverted ...Commit) string {
+	if len(reverted) == 1 {
+		return fmt.Sprintf("revert: %s\n\nThis reverts commit %s.\n\nRefs: %s",
+			reverted[0].Subject, reverted[0].Hash, reverted[0].ShortHash())
+	}
+
+	sentences := make([]string, 0, len(reverted))
+	refs := make([]string, 0, len(reverted))
+	for _, c := range reverted {
+		sentences = append(sentences, fmt.Sprintf("This reverts commit %s (%s).", c.Hash, c.Subject))
+		refs = append(refs, c.ShortHash())
+	}
+
+	return fmt.Sprintf("revert: %d commits\n\n%s\n\nRefs: %s",
+		len(reverted), strings.Join(sentences, "\n"), strings.Join(refs, ", "))
+}
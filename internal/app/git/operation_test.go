@@ -0,0 +1,108 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseOperation(t *testing.T) {
+	hashA := "aaaaaaa" + strings.Repeat("1", 33)
+	hashB := "bbbbbbb" + strings.Repeat("2", 33)
+	squashMsg := "Squashed commit of the following:\n\ncommit " + hashB + "\nAuthor: Jane Doe <jane@example.com>\n\n    feat: Add endpoint\n\ncommit " + hashA + "\nAuthor: Jane Doe <jane@example.com>\n\n    fix: Handle nil\n"
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected Operation
+		subject  string
+	}{
+		{
+			name:     "Success without operation",
+			files:    map[string]string{},
+			expected: Operation{},
+		},
+		{
+			name: "Success with merge",
+			files: map[string]string{
+				"MERGE_HEAD": hashB + "\n",
+				"MERGE_MSG":  "Merge branch 'feature'\n\n# Conflicts:\n#\tapi.go\n",
+			},
+			expected: Operation{Kind: OperationMerge, Heads: []string{hashB}, Message: "Merge branch 'feature'\n\n# Conflicts:\n#\tapi.go\n"},
+			subject:  "Merge branch 'feature'",
+		},
+		{
+			name:     "Success with merge without message",
+			files:    map[string]string{"MERGE_HEAD": hashA + "\n" + hashB + "\n"},
+			expected: Operation{Kind: OperationMerge, Heads: []string{hashA, hashB}},
+			subject:  "Merge commit '" + hashA[:7] + "'",
+		},
+		{
+			name: "Success with revert",
+			files: map[string]string{
+				"REVERT_HEAD": hashA + "\n",
+				"MERGE_MSG":   "Revert \"fix: Handle nil\"\n\nThis reverts commit " + hashA + ".\n",
+			},
+			expected: Operation{Kind: OperationRevert, Heads: []string{hashA}, Message: "Revert \"fix: Handle nil\"\n\nThis reverts commit " + hashA + ".\n"},
+			subject:  "Revert \"fix: Handle nil\"",
+		},
+		{
+			name: "Success with revert of several commits stopped by a conflict",
+			files: map[string]string{
+				"REVERT_HEAD":    hashA + "\n",
+				"MERGE_MSG":      "Revert \"fix: Handle nil\"\n\nThis reverts commit " + hashA + ".\n",
+				"sequencer/todo": "revert aaaaaaa fix: Handle nil\nrevert bbbbbbb feat: Add endpoint\n",
+			},
+			expected: Operation{Kind: OperationRevert, Heads: []string{"aaaaaaa", "bbbbbbb"}, Message: "Revert \"fix: Handle nil\"\n\nThis reverts commit " + hashA + ".\n"},
+			subject:  "Revert \"fix: Handle nil\"",
+		},
+		{
+			name: "Success with revert message listing several commits",
+			files: map[string]string{
+				"REVERT_HEAD": hashB + "\n",
+				"MERGE_MSG":   "Revert 2 commits\n\nThis reverts commit " + hashA + ".\nThis reverts commit " + hashB + ".\n",
+			},
+			expected: Operation{Kind: OperationRevert, Heads: []string{hashA, hashB}, Message: "Revert 2 commits\n\nThis reverts commit " + hashA + ".\nThis reverts commit " + hashB + ".\n"},
+			subject:  "Revert 2 commits",
+		},
+		{
+			name:     "Success with squash",
+			files:    map[string]string{"SQUASH_MSG": squashMsg},
+			expected: Operation{Kind: OperationSquash, Heads: []string{hashB, hashA}, Message: squashMsg},
+			subject:  "Squashed commit of the following:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := parseOperation(func(name string) (string, bool) {
+				content, ok := tt.files[name]
+				return content, ok
+			})
+
+			assert.Equal(t, tt.expected, op)
+			assert.Equal(t, tt.subject, op.Subject())
+		})
+	}
+}
+
+func Test_RevertMessage(t *testing.T) {
+	reverted := Commit{Hash: "aaaaaaa" + strings.Repeat("1", 33), Subject: "feat(api): Add endpoint"}
+
+	expected := "revert: feat(api): Add endpoint\n\nThis reverts commit " + reverted.Hash + ".\n\nRefs: aaaaaaa"
+
+	assert.Equal(t, expected, RevertMessage(reverted))
+
+	other := Commit{Hash: "bbbbbbb" + strings.Repeat("2", 33), Subject: "fix: Handle nil"}
+
+	expected = "revert: 2 commits\n\nThis reverts commit " + other.Hash + " (fix: Handle nil).\nThis reverts commit " + reverted.Hash + " (feat(api): Add endpoint).\n\nRefs: bbbbbbb, aaaaaaa"
+
+	assert.Equal(t, expected, RevertMessage(other, reverted))
+}
+
+func Test_UnlistedPaths(t *testing.T) {
+	assert.False(t, unlistedPaths([]string{"api.go", ""}, []string{"api.go", "main.go"}))
+	assert.True(t, unlistedPaths([]string{"api.go", "main.go"}, []string{"api.go"}))
+	assert.False(t, unlistedPaths(nil, nil))
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"strings"
+)
+
+// FileDiff represents the diff of a single file split into hunks
+type FileDiff struct {
+	Header []string
+	Hunks  []Hunk
+}
+
+// Hunk represents a single block of changes within a file diff
+type Hunk struct {
+	Header string
+	Lines  []string
+}
+
+// ParseFileDiff splits a single file diff into its header and hunks
+func ParseFileDiff(diff string) FileDiff {
+	var result FileDiff
+
+	if strings.TrimSpace(diff) == "" {
+		return result
+	}
+
+	var current *Hunk
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		if strings.HasPrefix(line, "@@") {
+			result.Hunks = append(result.Hunks, Hunk{Header: line})
+			current = &result.Hunks[len(result.Hunks)-1]
+			continue
+		}
+
+		if current == nil {
+			result.Header = append(result.Header, line)
+			continue
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+
+	return result
+}
+
+// Patch returns a patch containing the file header and the hunk at the given index
+func (f FileDiff) Patch(index int) string {
+	if index < 0 || index >= len(f.Hunks) {
+		return ""
+	}
+
+	hunk := f.Hunks[index]
+
+	var sb strings.Builder
+	for _, line := range f.Header {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(hunk.Header)
+	sb.WriteString("\n")
+
+	for _, line := range hunk.Lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
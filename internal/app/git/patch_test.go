@@ -0,0 +1,129 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testFileDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++// first
+ var x = 1
+ func main() {
+@@ -10,2 +11,3 @@ func helper() {
+ 	return
++	// second
+ }`
+
+func Test_ParseFileDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		expected FileDiff
+	}{
+		{
+			name: "Success with multiple hunks",
+			diff: testFileDiff,
+			expected: FileDiff{
+				Header: []string{
+					"diff --git a/main.go b/main.go",
+					"index 1111111..2222222 100644",
+					"--- a/main.go",
+					"+++ b/main.go",
+				},
+				Hunks: []Hunk{
+					{
+						Header: "@@ -1,3 +1,4 @@",
+						Lines:  []string{" package main", "+// first", " var x = 1", " func main() {"},
+					},
+					{
+						Header: "@@ -10,2 +11,3 @@ func helper() {",
+						Lines:  []string{" \treturn", "+\t// second", " }"},
+					},
+				},
+			},
+		},
+		{
+			name: "Success with no newline marker",
+			diff: "--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-old\n+new\n\\ No newline at end of file\n",
+			expected: FileDiff{
+				Header: []string{"--- a/a.txt", "+++ b/a.txt"},
+				Hunks: []Hunk{
+					{
+						Header: "@@ -1 +1 @@",
+						Lines:  []string{"-old", "+new", "\\ No newline at end of file"},
+					},
+				},
+			},
+		},
+		{
+			name:     "Success with empty diff",
+			diff:     "",
+			expected: FileDiff{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseFileDiff(tt.diff)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_FileDiff_Patch(t *testing.T) {
+	fileDiff := ParseFileDiff(testFileDiff)
+
+	tests := []struct {
+		name     string
+		index    int
+		expected string
+	}{
+		{
+			name:  "Success with first hunk",
+			index: 0,
+			expected: "diff --git a/main.go b/main.go\n" +
+				"index 1111111..2222222 100644\n" +
+				"--- a/main.go\n" +
+				"+++ b/main.go\n" +
+				"@@ -1,3 +1,4 @@\n" +
+				" package main\n" +
+				"+// first\n" +
+				" var x = 1\n" +
+				" func main() {\n",
+		},
+		{
+			name:  "Success with second hunk",
+			index: 1,
+			expected: "diff --git a/main.go b/main.go\n" +
+				"index 1111111..2222222 100644\n" +
+				"--- a/main.go\n" +
+				"+++ b/main.go\n" +
+				"@@ -10,2 +11,3 @@ func helper() {\n" +
+				" \treturn\n" +
+				"+\t// second\n" +
+				" }\n",
+		},
+		{
+			name:     "Failure with out of range index",
+			index:    2,
+			expected: "",
+		},
+		{
+			name:     "Failure with negative index",
+			index:    -1,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fileDiff.Patch(tt.index))
+		})
+	}
+}
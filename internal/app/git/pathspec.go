@@ -0,0 +1,59 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/glob"
+)
+
+// matchPathspecs compiles git glob pathspecs relative to the repository root
+// into a path filter, returning nil when every path matches. A pathspec
+// without wildcards also matches the files below it
+func matchPathspecs(specs []string) (func(string) bool, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(specs))
+	for _, spec := range specs {
+		pattern := spec
+		if rest, ok := strings.CutPrefix(spec, ":("); ok {
+			magic, value, found := strings.Cut(rest, ")")
+			for _, word := range strings.Split(magic, ",") {
+				if !found || (word != "top" && word != "glob") {
+					return nil, fmt.Errorf("%w: pathspec %s", errors.ErrUnsupportedByBackend, spec)
+				}
+			}
+			pattern = value
+		}
+
+		patterns = append(patterns, compilePathspec(strings.Trim(pattern, "/")))
+	}
+
+	return func(path string) bool {
+		for _, p := range patterns {
+			if p.MatchString(path) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// compilePathspec converts a glob pathspec into a regular expression where
+// ** crosses directories and * or ? stay within one
+func compilePathspec(pattern string) *regexp.Regexp {
+	if pattern == "" || pattern == "." {
+		return regexp.MustCompile("")
+	}
+
+	suffix := "(/|$)"
+	if strings.ContainsAny(pattern, "*?") {
+		suffix = "$"
+	}
+
+	return regexp.MustCompile("^" + glob.Expression(pattern) + suffix)
+}
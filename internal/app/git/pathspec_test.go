@@ -0,0 +1,42 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_MatchPathspecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		specs    []string
+		path     string
+		expected bool
+	}{
+		{name: "Success with directory", specs: []string{":(top,glob)services/api"}, path: "services/api/main.go", expected: true},
+		{name: "Success with double star", specs: []string{"services/api/**"}, path: "services/api/cmd/main.go", expected: true},
+		{name: "Success with leading double star", specs: []string{":(glob)**/go.mod"}, path: "services/api/go.mod", expected: true},
+		{name: "Success with any of several specs", specs: []string{"web", "services/api"}, path: "services/api/main.go", expected: true},
+		{name: "Success with repository root", specs: []string{"."}, path: "README.md", expected: true},
+		{name: "Failure with sibling sharing prefix", specs: []string{"services/api"}, path: "services/api-gateway/main.go", expected: false},
+		{name: "Failure with star crossing directories", specs: []string{"services/*"}, path: "services/api/main.go", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := matchPathspecs(tt.specs)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, match(tt.path))
+		})
+	}
+
+	match, err := matchPathspecs(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, match)
+
+	_, err = matchPathspecs([]string{":(exclude)web"})
+	assert.ErrorIs(t, err, errors.ErrUnsupportedByBackend)
+}
@@ -0,0 +1,61 @@
+package glob
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Expression(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "Success with star within a directory",
+			pattern:  "*.go",
+			path:     "main.go",
+			expected: true,
+		},
+		{
+			name:     "Success with star not crossing directories",
+			pattern:  "*.go",
+			path:     "cmd/main.go",
+			expected: false,
+		},
+		{
+			name:     "Success with double star crossing directories",
+			pattern:  "services/**",
+			path:     "services/api/main.go",
+			expected: true,
+		},
+		{
+			name:     "Success with leading double star",
+			pattern:  "**/gen/*.go",
+			path:     "gen/types.go",
+			expected: true,
+		},
+		{
+			name:     "Success with question mark",
+			pattern:  "v?.txt",
+			path:     "v1.txt",
+			expected: true,
+		},
+		{
+			name:     "Success quoting regular expression characters",
+			pattern:  "main[1].go",
+			path:     "main1.go",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile("^" + Expression(tt.pattern) + "$")
+			assert.Equal(t, tt.expected, re.MatchString(tt.path))
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sashabaranov/go-openai"
 
+	"cmt/internal/app/credentials"
 	"cmt/internal/app/errors"
+	"cmt/internal/app/secrets"
 	"cmt/internal/config"
 	"cmt/internal/config/logger"
 )
@@ -19,6 +22,8 @@ import (
 const (
 	commitSystemPromt = `You are an expert at writing Conventional Commit messages following the v1.0.0 specification.
 Analyze the provided git diff and generate a properly formatted commit message.
+The diff starts with a --stat overview. Binary, very large, renamed and mode-only
+files are summarized in one line each instead of showing their content.
 
 RULES:
 1. Type: Choose the most appropriate type based on the change:
@@ -59,6 +64,29 @@ Return ONLY valid JSON in this format:
   "body": "optional detailed explanation"
 }`
 
+	squashSystemPrompt = `You are an expert at writing Conventional Commit messages following the v1.0.0 specification.
+The staged changes combine the commits listed under COMMITS into a single commit,
+as a merge or a squash. Generate one properly formatted commit message that
+summarizes all of them, using the DIFF to check what actually changed.
+The diff starts with a --stat overview. Binary, very large, renamed and mode-only
+files are summarized in one line each instead of showing their content.
+
+RULES:
+1. Type: the type of the most significant change, feat when any commit adds a feature
+2. Scope: a one word noun for the area most commits touch, or empty when they span several areas
+3. Description: start with an uppercase letter, use the imperative mood, no period at the end
+4. Body: short "- " bullet points of the notable changes, one per line, without
+   commit hashes and without repeating the description. Wrap at 72 characters
+5. Mention breaking changes of any commit in the body
+
+Return ONLY valid JSON in this format:
+{
+  "type": "feat, fix, build, chore, ci, docs, style, refactor, perf, test, revert",
+  "scope": "scope of the change (use one word)",
+  "description": "a brief description of what was changed in imperative mood",
+  "body": "bullet points of the notable changes"
+}`
+
 	changelogSystemPrompt = `You are an experienced Software Engineer tasked with generating a concise and clear CHANGELOG for a set of commits in Markdown format.
 Follow these instructions:
 – Keep the content concise, using short bullet points for each entry
@@ -96,6 +124,71 @@ Follow the format below:
 - **revert:** ...
 ### Breaking Changes
 - **BREAKING CHANGES:** ...`
+
+	pullRequestSystemPrompt = `You are an experienced Software Engineer writing the title and description of a pull request for reviewers.
+You receive the commits of the branch, the combined diff against the base branch and, optionally, the pull request template of the repository.
+The diff starts with a --stat overview. Binary, very large, renamed and mode-only
+files are summarized in one line each instead of showing their content.
+
+RULES:
+1. Title: a short imperative summary of the whole branch, no more than 72 characters, no period at the end
+2. Summary: one or two sentences explaining what the pull request does and why
+3. Changes: short bullet points of the notable changes, grouped by area, without repeating the commit list
+4. Testing: how the changes were or should be verified, based on the tests in the diff
+5. Breaking changes: what users must change when upgrading, or an empty string when nothing breaks
+6. Do not invent tickets, links or results that are not in the input
+
+When a TEMPLATE is given, fill it in section by section: the keys of "sections"
+are the exact heading texts of the template without the leading # characters,
+and each value is the Markdown content of that section. Keep checklists of the
+template and tick only the items the changes satisfy. Leave out sections that
+do not apply.
+
+Return ONLY valid JSON in this format:
+{
+  "title": "pull request title",
+  "summary": "what and why",
+  "changes": ["notable change"],
+  "testing": "testing notes",
+  "breaking_changes": "breaking changes or an empty string",
+  "sections": {"Template heading": "content"}
+}`
+
+	explainSystemPrompt = `You are an experienced Software Engineer explaining a single commit to a colleague who does not know this part of the code base.
+You receive the commit message and the diff of the commit.
+The diff starts with a --stat overview. Binary, very large, renamed and mode-only
+files are summarized in one line each instead of showing their content.
+
+RULES:
+1. Start with one or two sentences in plain language saying what the commit changes
+2. Explain why the change was likely made, based on the message and the code, and say so when the reason is a guess
+3. Describe the behavior before and after the change, naming the functions, files or settings involved
+4. Point out what the change might affect: callers, configuration, data, performance or compatibility
+5. Keep it short: a few paragraphs or bullet points, no more than 300 words
+6. Do not invent tickets, links or results that are not in the input
+
+Return plain Markdown without a title.`
+
+	reviewSystemPrompt = `You are a senior Software Engineer reviewing staged changes before they are committed.
+You receive the staged diff of the repository.
+The diff starts with a --stat overview. Binary, very large, renamed and mode-only
+files are summarized in one line each instead of showing their content.
+
+RULES:
+1. Report only real problems in the added or changed lines: bugs, security issues, data loss, race conditions, missing error handling, broken edge cases, misleading names or comments
+2. Do not report style preferences, formatting or anything a linter or formatter would fix
+3. "file" is the path of the file after the change, as in the +++ b/ line of the diff
+4. "line" is the line number in the new version of the file, counted from the start of the +N part of the @@ hunk header; use 0 when the finding is about the whole file
+5. "severity" is "error" for problems that break behavior or security, "warning" for likely bugs and risky code, "info" for improvements worth considering
+6. "message" explains the problem and how to fix it in one or two sentences
+7. Return an empty list when there is nothing worth reporting; never invent problems
+
+Return ONLY valid JSON in this format:
+{
+  "findings": [
+    {"file": "path/to/file.go", "line": 42, "severity": "warning", "message": "what is wrong and how to fix it"}
+  ]
+}`
 )
 
 type commitType string
@@ -131,7 +224,11 @@ var commitTypes = []commitType{
 // Client represents the GPT model client interface
 type Client interface {
 	FetchCommitMessage(ctx context.Context, diff string) (string, error)
+	FetchSquashMessage(ctx context.Context, commits, diff string) (string, error)
 	FetchChangelog(ctx context.Context, commits string) (string, error)
+	FetchPullRequest(ctx context.Context, commits, diff, template string) (PullRequest, error)
+	FetchExplanation(ctx context.Context, message, diff string) (string, error)
+	FetchReview(ctx context.Context, diff string) ([]Finding, error)
 }
 
 // API represents the OpenAI API client interface
@@ -141,43 +238,83 @@ type API interface {
 
 // client implements the Client interface
 type client struct {
-	cfg *config.Config
-	api API
-	log logger.Logger
+	cfg    *config.Config
+	api    API
+	tokens credentials.TokenSource
+	log    logger.Logger
+	mu     sync.Mutex
 }
 
-// NewGPTClient creates a new GPT model client
-func NewGPTClient(cfg *config.Config, log logger.Logger) (Client, error) {
-	token, err := config.GetAPIToken()
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to get API token")
+// NewGPTClient creates a new GPT model client that resolves the API token on first use
+func NewGPTClient(cfg *config.Config, tokens credentials.TokenSource, log logger.Logger) Client {
+	return &client{
+		cfg:    cfg,
+		tokens: tokens,
+		log:    log,
+	}
+}
+
+// apiClient returns the OpenAI API client, creating it with the resolved token on first use
+func (g *client) apiClient(ctx context.Context) (API, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.api != nil {
+		return g.api, nil
+	}
+
+	token, err := g.tokens.Token(ctx)
+	if err != nil && (g.cfg.API.BaseURL == "" || !errors.Is(err, errors.ErrAPITokenNotSet)) {
+		g.log.Error().Err(err).Msg("Failed to get API token")
 		return nil, err
 	}
 
 	clientCfg := openai.DefaultConfig(token)
+	if g.cfg.API.BaseURL != "" {
+		clientCfg.BaseURL = g.cfg.API.BaseURL
+	}
 	clientCfg.HTTPClient = &http.Client{
-		Timeout: cfg.API.Timeout,
+		Timeout: g.cfg.API.Timeout,
 	}
 
-	return &client{
-		cfg: cfg,
-		api: openai.NewClientWithConfig(clientCfg),
-		log: log,
-	}, nil
+	g.api = openai.NewClientWithConfig(clientCfg)
+
+	return g.api, nil
 }
 
 // FetchCommitMessage generates a commit message from a git diff
 func (g *client) FetchCommitMessage(ctx context.Context, diff string) (string, error) {
 	g.log.Info().Int("diff_size", len(diff)).Msg("Generating commit message")
+	return g.fetchCommitMessage(ctx, commitSystemPromt, "", diff)
+}
+
+// FetchSquashMessage generates one commit message summarizing the merged or
+// squashed commits from their messages and the staged diff
+func (g *client) FetchSquashMessage(ctx context.Context, commits, diff string) (string, error) {
+	g.log.Info().
+		Int("commits_size", len(commits)).
+		Int("diff_size", len(diff)).
+		Msg("Generating squash commit message")
+	return g.fetchCommitMessage(ctx, squashSystemPrompt, "COMMITS:\n"+commits+"\n\nDIFF:\n", diff)
+}
+
+// fetchCommitMessage sends the header followed by the diff with the system
+// prompt and parses the conventional commit message of the response
+func (g *client) fetchCommitMessage(ctx context.Context, prompt, header, diff string) (string, error) {
+	diff, err := g.guardSecrets(diff)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Refusing to send diff containing secrets")
+		return "", err
+	}
 
 	messages := []openai.ChatCompletionMessage{
 		{
 			Role:    openai.ChatMessageRoleSystem,
-			Content: commitSystemPromt,
+			Content: prompt,
 		},
 		{
 			Role:    openai.ChatMessageRoleUser,
-			Content: diff,
+			Content: header + diff,
 		},
 	}
 
@@ -198,6 +335,25 @@ func (g *client) FetchCommitMessage(ctx context.Context, diff string) (string, e
 	return message, nil
 }
 
+// guardSecrets scans the diff for credentials and aborts or redacts according to the secrets mode
+func (g *client) guardSecrets(diff string) (string, error) {
+	if g.cfg.Secrets.Mode == config.SecretsModeOff {
+		return diff, nil
+	}
+
+	findings := secrets.Scan(diff)
+	if len(findings) == 0 {
+		return diff, nil
+	}
+
+	if g.cfg.Secrets.Mode == config.SecretsModeRedact {
+		g.log.Warn().Int("findings", len(findings)).Msg("Redacted possible secrets from diff")
+		return secrets.Redact(diff, findings), nil
+	}
+
+	return "", &secrets.Error{Findings: findings}
+}
+
 // FetchChangelog generates a changelog from git commits
 func (g *client) FetchChangelog(ctx context.Context, commits string) (string, error) {
 	g.log.Info().Int("commits_size", len(commits)).Msg("Generating changelog")
@@ -224,6 +380,128 @@ func (g *client) FetchChangelog(ctx context.Context, commits string) (string, er
 	return content, nil
 }
 
+// FetchPullRequest generates the title and description of a pull request from
+// its commits and combined diff, filling in the template when one is given
+func (g *client) FetchPullRequest(ctx context.Context, commits, diff, template string) (PullRequest, error) {
+	g.log.Info().
+		Int("commits_size", len(commits)).
+		Int("diff_size", len(diff)).
+		Bool("template", template != "").
+		Msg("Generating pull request description")
+
+	diff, err := g.guardSecrets(diff)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Refusing to send diff containing secrets")
+		return PullRequest{}, err
+	}
+
+	content := fmt.Sprintf("COMMITS:\n%s\n\nDIFF:\n%s", commits, diff)
+	if template != "" {
+		content += "\n\nTEMPLATE:\n" + template
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: pullRequestSystemPrompt,
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: content,
+		},
+	}
+
+	g.log.Debug().Msg("Fetching pull request description from GPT")
+	response, err := g.fetch(ctx, messages)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to fetch pull request description")
+		return PullRequest{}, err
+	}
+
+	pr, err := parsePullRequestResponse(response)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to parse pull request response")
+		return PullRequest{}, err
+	}
+
+	g.log.Info().Str("title", pr.Title).Msg("Successfully generated pull request description")
+	return pr, nil
+}
+
+// FetchExplanation explains in plain language what a commit changes and why it
+// might matter from its message and diff
+func (g *client) FetchExplanation(ctx context.Context, message, diff string) (string, error) {
+	g.log.Info().
+		Int("message_size", len(message)).
+		Int("diff_size", len(diff)).
+		Msg("Generating commit explanation")
+
+	diff, err := g.guardSecrets(diff)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Refusing to send diff containing secrets")
+		return "", err
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: explainSystemPrompt,
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: fmt.Sprintf("MESSAGE:\n%s\n\nDIFF:\n%s", message, diff),
+		},
+	}
+
+	g.log.Debug().Msg("Fetching commit explanation from GPT")
+	content, err := g.fetch(ctx, messages)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to fetch commit explanation")
+		return "", err
+	}
+
+	g.log.Info().Int("explanation_size", len(content)).Msg("Successfully generated commit explanation")
+	return strings.TrimSpace(content), nil
+}
+
+// FetchReview reviews the staged diff and returns the problems it finds
+func (g *client) FetchReview(ctx context.Context, diff string) ([]Finding, error) {
+	g.log.Info().Int("diff_size", len(diff)).Msg("Reviewing staged changes")
+
+	diff, err := g.guardSecrets(diff)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Refusing to send diff containing secrets")
+		return nil, err
+	}
+
+	messages := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: reviewSystemPrompt,
+		},
+		{
+			Role:    openai.ChatMessageRoleUser,
+			Content: diff,
+		},
+	}
+
+	g.log.Debug().Msg("Fetching review from GPT")
+	content, err := g.fetch(ctx, messages)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to fetch review")
+		return nil, err
+	}
+
+	findings, err := parseReviewResponse(content)
+	if err != nil {
+		g.log.Error().Err(err).Msg("Failed to parse review response")
+		return nil, err
+	}
+
+	g.log.Info().Int("findings", len(findings)).Msg("Successfully reviewed staged changes")
+	return findings, nil
+}
+
 // fetch sends a request to the GPT API and returns the response content
 func (g *client) fetch(ctx context.Context, messages []openai.ChatCompletionMessage) (string, error) {
 	g.log.Debug().
@@ -231,6 +509,11 @@ func (g *client) fetch(ctx context.Context, messages []openai.ChatCompletionMess
 		Int("max_tokens", g.cfg.Model.MaxTokens).
 		Msg("Sending GPT request")
 
+	api, err := g.apiClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	var resp openai.ChatCompletionResponse
 	var respErr error
 
@@ -261,7 +544,7 @@ func (g *client) fetch(ctx context.Context, messages []openai.ChatCompletionMess
 		}
 
 		var err error
-		resp, err = g.api.CreateChatCompletion(
+		resp, err = api.CreateChatCompletion(
 			ctx,
 			openai.ChatCompletionRequest{
 				Model:               g.cfg.Model.Name,
@@ -318,19 +601,9 @@ func shouldRetry(err error) bool {
 
 // parseCommitMessageResponse parses the GPT response into a conventional commit message
 func parseCommitMessageResponse(text string) (string, error) {
-	text = strings.TrimSpace(text)
-	if strings.HasPrefix(text, "```json") {
-		text = strings.TrimPrefix(text, "```json")
-		text = strings.TrimSuffix(text, "```")
-		text = strings.TrimSpace(text)
-	}
+	text = stripCodeFence(text)
 
-	var schema struct {
-		Type        string `json:"type"`
-		Scope       string `json:"scope"`
-		Description string `json:"description"`
-		Body        string `json:"body"`
-	}
+	var schema CommitMessage
 
 	if err := json.Unmarshal([]byte(text), &schema); err != nil {
 		return "", fmt.Errorf("%w: %v", errors.ErrFailedToParseJSON, err)
@@ -340,27 +613,55 @@ func parseCommitMessageResponse(text string) (string, error) {
 		return "", err
 	}
 
-	scope := ""
-	if schema.Scope != "" {
-		scope = fmt.Sprintf("(%s)", schema.Scope)
+	return schema.String(), nil
+}
+
+// parsePullRequestResponse parses the GPT response into a pull request description
+func parsePullRequestResponse(text string) (PullRequest, error) {
+	var pr PullRequest
+	if err := json.Unmarshal([]byte(stripCodeFence(text)), &pr); err != nil {
+		return PullRequest{}, fmt.Errorf("%w: %v", errors.ErrFailedToParseJSON, err)
 	}
 
-	header := fmt.Sprintf("%s%s: %s", schema.Type, scope, schema.Description)
-	message := header
-	if schema.Body != "" {
-		message = fmt.Sprintf("%s\n\n%s", header, schema.Body)
+	pr.Title = strings.TrimSpace(pr.Title)
+	if pr.Title == "" {
+		return PullRequest{}, errors.ErrMissingPullRequestTitle
 	}
 
-	return message, nil
+	return pr, nil
+}
+
+// parseReviewResponse parses the GPT response into review findings, dropping
+// findings without a message
+func parseReviewResponse(text string) ([]Finding, error) {
+	var review Review
+	if err := json.Unmarshal([]byte(stripCodeFence(text)), &review); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrFailedToParseJSON, err)
+	}
+
+	findings := []Finding{}
+	for _, finding := range review.Findings {
+		if finding = finding.normalize(); finding.Message != "" {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// stripCodeFence removes a Markdown json code fence around a response
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "```json") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimSuffix(text, "```")
+		text = strings.TrimSpace(text)
+	}
+	return text
 }
 
 // validate validates the parsed commit message schema
-func validate(schema struct {
-	Type        string `json:"type"`
-	Scope       string `json:"scope"`
-	Description string `json:"description"`
-	Body        string `json:"body"`
-}) error {
+func validate(schema CommitMessage) error {
 	if schema.Type == "" {
 		return errors.ErrMissingCommitType
 	}
@@ -378,13 +679,7 @@ func validate(schema struct {
 	}
 
 	if !isValidType {
-		validTypes := make([]string, len(commitTypes))
-
-		for i, t := range commitTypes {
-			validTypes[i] = string(t)
-		}
-
-		return fmt.Errorf("%w: %q (must be one of: %v)", errors.ErrInvalidCommitType, schema.Type, validTypes)
+		return fmt.Errorf("%w: %q (must be one of: %v)", errors.ErrInvalidCommitType, schema.Type, CommitTypes())
 	}
 
 	return nil
@@ -71,6 +71,66 @@ func (mr *MockClientMockRecorder) FetchCommitMessage(ctx, diff any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCommitMessage", reflect.TypeOf((*MockClient)(nil).FetchCommitMessage), ctx, diff)
 }
 
+// FetchExplanation mocks base method.
+func (m *MockClient) FetchExplanation(ctx context.Context, message, diff string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchExplanation", ctx, message, diff)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchExplanation indicates an expected call of FetchExplanation.
+func (mr *MockClientMockRecorder) FetchExplanation(ctx, message, diff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchExplanation", reflect.TypeOf((*MockClient)(nil).FetchExplanation), ctx, message, diff)
+}
+
+// FetchPullRequest mocks base method.
+func (m *MockClient) FetchPullRequest(ctx context.Context, commits, diff, template string) (PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPullRequest", ctx, commits, diff, template)
+	ret0, _ := ret[0].(PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPullRequest indicates an expected call of FetchPullRequest.
+func (mr *MockClientMockRecorder) FetchPullRequest(ctx, commits, diff, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequest", reflect.TypeOf((*MockClient)(nil).FetchPullRequest), ctx, commits, diff, template)
+}
+
+// FetchReview mocks base method.
+func (m *MockClient) FetchReview(ctx context.Context, diff string) ([]Finding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReview", ctx, diff)
+	ret0, _ := ret[0].([]Finding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchReview indicates an expected call of FetchReview.
+func (mr *MockClientMockRecorder) FetchReview(ctx, diff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReview", reflect.TypeOf((*MockClient)(nil).FetchReview), ctx, diff)
+}
+
+// FetchSquashMessage mocks base method.
+func (m *MockClient) FetchSquashMessage(ctx context.Context, commits, diff string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchSquashMessage", ctx, commits, diff)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchSquashMessage indicates an expected call of FetchSquashMessage.
+func (mr *MockClientMockRecorder) FetchSquashMessage(ctx, commits, diff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchSquashMessage", reflect.TypeOf((*MockClient)(nil).FetchSquashMessage), ctx, commits, diff)
+}
+
 // MockAPI is a mock of API interface.
 type MockAPI struct {
 	ctrl     *gomock.Controller
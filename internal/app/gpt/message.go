@@ -0,0 +1,76 @@
+package gpt
+
+import (
+	"fmt"
+	"strings"
+
+	"cmt/internal/app/git"
+)
+
+// CommitMessage represents the parts of a conventional commit message
+type CommitMessage struct {
+	Type        string `json:"type"`
+	Scope       string `json:"scope"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+	Breaking    bool   `json:"breaking,omitempty"`
+}
+
+// Header returns the commit header in the form type(scope)!: description,
+// or the description alone when there is no type
+func (c CommitMessage) Header() string {
+	if c.Type == "" {
+		return c.Description
+	}
+
+	scope := ""
+	if c.Scope != "" {
+		scope = fmt.Sprintf("(%s)", c.Scope)
+	}
+
+	breaking := ""
+	if c.Breaking {
+		breaking = "!"
+	}
+
+	return fmt.Sprintf("%s%s%s: %s", c.Type, scope, breaking, c.Description)
+}
+
+// String returns the full commit message with the body separated by a blank line
+func (c CommitMessage) String() string {
+	header := c.Header()
+	if c.Body == "" {
+		return header
+	}
+
+	return fmt.Sprintf("%s\n\n%s", header, c.Body)
+}
+
+// ParseCommitMessage splits a commit message into its conventional commit parts
+func ParseCommitMessage(text string) CommitMessage {
+	text = strings.TrimSpace(text)
+
+	header, body, _ := strings.Cut(text, "\n")
+	body = strings.TrimSpace(body)
+
+	conv := git.ParseConventional(header, nil)
+
+	return CommitMessage{
+		Type:        conv.Type,
+		Scope:       conv.Scope,
+		Breaking:    conv.Breaking,
+		Description: conv.Description,
+		Body:        body,
+	}
+}
+
+// CommitTypes returns the commit types accepted for generated messages
+func CommitTypes() []string {
+	types := make([]string, len(commitTypes))
+
+	for i, t := range commitTypes {
+		types[i] = string(t)
+	}
+
+	return types
+}
@@ -0,0 +1,99 @@
+package gpt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CommitMessage_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  CommitMessage
+		expected string
+	}{
+		{
+			name:     "Success without scope",
+			message:  CommitMessage{Type: "feat", Description: "Add feature"},
+			expected: "feat: Add feature",
+		},
+		{
+			name:     "Success with scope",
+			message:  CommitMessage{Type: "fix", Scope: "api", Description: "Fix endpoint"},
+			expected: "fix(api): Fix endpoint",
+		},
+		{
+			name:     "Success with body",
+			message:  CommitMessage{Type: "feat", Scope: "ui", Description: "Add button", Body: "Details"},
+			expected: "feat(ui): Add button\n\nDetails",
+		},
+		{
+			name:     "Success with breaking marker",
+			message:  CommitMessage{Type: "refactor", Scope: "api", Description: "Drop v1", Breaking: true},
+			expected: "refactor(api)!: Drop v1",
+		},
+		{
+			name:     "Success without type",
+			message:  CommitMessage{Description: "Update stuff", Body: "More details"},
+			expected: "Update stuff\n\nMore details",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.message.String())
+		})
+	}
+}
+
+func Test_ParseCommitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected CommitMessage
+	}{
+		{
+			name:     "Success with scope and body",
+			text:     "feat(auth): Add OAuth2 login\n\nSupports Google and GitHub.",
+			expected: CommitMessage{Type: "feat", Scope: "auth", Description: "Add OAuth2 login", Body: "Supports Google and GitHub."},
+		},
+		{
+			name:     "Success without scope",
+			text:     "docs: Update readme",
+			expected: CommitMessage{Type: "docs", Description: "Update readme"},
+		},
+		{
+			name:     "Success with breaking marker",
+			text:     "refactor(api)!: Drop v1 endpoints",
+			expected: CommitMessage{Type: "refactor", Scope: "api", Description: "Drop v1 endpoints", Breaking: true},
+		},
+		{
+			name:     "Success with free-form message",
+			text:     "Update stuff\n\nMore details",
+			expected: CommitMessage{Description: "Update stuff", Body: "More details"},
+		},
+		{
+			name:     "Success with empty message",
+			text:     "",
+			expected: CommitMessage{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := ParseCommitMessage(tt.text)
+
+			assert.Equal(t, tt.expected, parsed)
+			assert.Equal(t, strings.TrimSpace(tt.text), parsed.String())
+		})
+	}
+}
+
+func Test_CommitTypes(t *testing.T) {
+	types := CommitTypes()
+
+	assert.Len(t, types, len(commitTypes))
+	assert.Equal(t, "feat", types[0])
+	assert.Contains(t, types, "revert")
+}
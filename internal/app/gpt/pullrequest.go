@@ -0,0 +1,12 @@
+package gpt
+
+// PullRequest represents the generated title and description of a pull request
+type PullRequest struct {
+	Title    string   `json:"title"`
+	Summary  string   `json:"summary"`
+	Changes  []string `json:"changes"`
+	Testing  string   `json:"testing"`
+	Breaking string   `json:"breaking_changes"`
+	// Sections holds the content of each template section keyed by its heading
+	Sections map[string]string `json:"sections"`
+}
@@ -0,0 +1,45 @@
+package gpt
+
+import "strings"
+
+// Severities of review findings, from most to least important
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Finding is a single problem reported by the review of a diff
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Review is the response of the model to a review request
+type Review struct {
+	Findings []Finding `json:"findings"`
+}
+
+// normalize trims the fields of the finding and maps unknown severities to a warning
+func (f Finding) normalize() Finding {
+	f.File = strings.TrimPrefix(strings.TrimSpace(f.File), "b/")
+	f.Message = strings.TrimSpace(f.Message)
+	f.Severity = strings.ToLower(strings.TrimSpace(f.Severity))
+
+	switch f.Severity {
+	case SeverityError, SeverityWarning, SeverityInfo:
+	case "critical", "high":
+		f.Severity = SeverityError
+	case "note", "low", "suggestion":
+		f.Severity = SeverityInfo
+	default:
+		f.Severity = SeverityWarning
+	}
+
+	if f.Line < 0 {
+		f.Line = 0
+	}
+	return f
+}
@@ -0,0 +1,290 @@
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"cmt/internal/app/glob"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// FileName is the name of the ignore file at the repository root
+const FileName = ".cmtignore"
+
+// rule is a single compiled ignore pattern
+type rule struct {
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+// Matcher decides which paths are excluded from the diff sent to the model
+type Matcher struct {
+	rules []rule
+}
+
+// Load builds a matcher from the configured globs followed by the .cmtignore file at the repository root
+func Load(cfg *config.Config, log logger.Logger) *Matcher {
+	var patterns []string
+	if cfg != nil {
+		patterns = append(patterns, cfg.Diff.Exclude...)
+	}
+
+	path := filepath.Join(config.RepoRoot(), FileName)
+	filePatterns, err := readFile(path)
+	if err != nil {
+		log.Warn().Err(err).Str("path", path).Msg("Failed to read ignore file")
+	}
+	patterns = append(patterns, filePatterns...)
+
+	log.Debug().Int("patterns", len(patterns)).Msg("Loaded diff exclusion patterns")
+	return New(patterns)
+}
+
+// New compiles gitignore-style patterns into a matcher
+func New(patterns []string) *Matcher {
+	m := &Matcher{}
+
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+
+		negate := strings.HasPrefix(p, "!")
+		p = strings.TrimPrefix(p, "!")
+
+		m.rules = append(m.rules, rule{pattern: compile(p), negate: negate})
+	}
+
+	return m
+}
+
+// Match reports whether the path is excluded, letting later patterns override earlier ones
+func (m *Matcher) Match(path string) bool {
+	if m == nil {
+		return false
+	}
+
+	excluded := false
+	for _, r := range m.rules {
+		if r.pattern.MatchString(path) {
+			excluded = !r.negate
+		}
+	}
+
+	return excluded
+}
+
+// FilterDiff splits a unified diff into the parts sent to the model and the
+// excluded paths. The --stat lines and file summaries before the first file
+// are filtered like the file sections
+func (m *Matcher) FilterDiff(diff string) (string, []string) {
+	if m == nil || len(m.rules) == 0 {
+		return diff, nil
+	}
+
+	overview, content := splitOverview(diff)
+
+	var blocks []string
+	var excluded []string
+	filtered := false
+
+	for _, block := range strings.Split(overview, "\n\n") {
+		var lines []string
+		named, kept := 0, 0
+		for _, line := range strings.Split(block, "\n") {
+			path, summary := overviewPath(line)
+			if path != "" {
+				named++
+				if m.Match(path) {
+					if summary {
+						excluded = append(excluded, path)
+					}
+					filtered = true
+					continue
+				}
+				kept++
+			}
+			lines = append(lines, line)
+		}
+		if block = strings.Trim(strings.Join(lines, "\n"), "\n"); block != "" && (named == 0 || kept > 0) {
+			blocks = append(blocks, block)
+		}
+	}
+
+	var sections []string
+	for _, section := range splitDiff(content) {
+		path := sectionPath(section)
+		if path != "" && m.Match(path) {
+			excluded = append(excluded, path)
+			filtered = true
+			continue
+		}
+		sections = append(sections, section)
+	}
+
+	if !filtered {
+		return diff, nil
+	}
+	if content = strings.Join(sections, "\n"); content != "" {
+		blocks = append(blocks, content)
+	}
+
+	return strings.Join(blocks, "\n\n"), excluded
+}
+
+// Summary describes the excluded paths in a single line for the model
+func Summary(excluded []string) string {
+	if len(excluded) == 0 {
+		return ""
+	}
+
+	noun := "files"
+	if len(excluded) == 1 {
+		noun = "file"
+	}
+
+	return fmt.Sprintf("%d %s regenerated (contents omitted): %s", len(excluded), noun, strings.Join(excluded, ", "))
+}
+
+// PromptDiff returns the filtered diff followed by the summary of excluded paths
+func (m *Matcher) PromptDiff(diff string) string {
+	filtered, excluded := m.FilterDiff(diff)
+
+	summary := Summary(excluded)
+	switch {
+	case summary == "":
+		return filtered
+	case strings.TrimSpace(filtered) == "":
+		return summary
+	default:
+		return filtered + "\n\n" + summary
+	}
+}
+
+// readFile returns the patterns of an ignore file, or none when it does not exist
+func readFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+
+	return patterns, scanner.Err()
+}
+
+// compile converts a gitignore-style glob into a regular expression
+func compile(pattern string) *regexp.Regexp {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	prefix, suffix := "(^|/)", "(/|$)"
+	if anchored {
+		prefix = "^"
+	}
+	if dirOnly {
+		suffix = "/"
+	}
+
+	return regexp.MustCompile(prefix + glob.Expression(pattern) + suffix)
+}
+
+// splitOverview splits a diff into the overview preceding the first file
+// section, such as a --stat block and file summaries, and the file sections
+func splitOverview(diff string) (string, string) {
+	if strings.HasPrefix(diff, "diff --git ") {
+		return "", diff
+	}
+
+	i := strings.Index(diff, "\ndiff --git ")
+	if i < 0 {
+		if strings.Contains(diff, "\n@@ ") {
+			return "", diff
+		}
+		return diff, ""
+	}
+	return diff[:i], diff[i+1:]
+}
+
+// overviewPath returns the destination path named by a --stat line or a file
+// summary such as "go.sum: large change", reporting whether it is a summary
+func overviewPath(line string) (string, bool) {
+	if name, _, ok := strings.Cut(line, " | "); ok {
+		return movedPath(strings.TrimSpace(name), " => "), false
+	}
+
+	if strings.HasPrefix(line, " ") {
+		return "", false
+	}
+	if name, _, ok := strings.Cut(line, ": "); ok {
+		return movedPath(name, " -> "), true
+	}
+	return "", false
+}
+
+// movedPath returns the destination of a rename written as "old => new" or
+// "dir/{old => new}/file", or name itself when it is not a rename
+func movedPath(name, arrow string) string {
+	open, closing := strings.Index(name, "{"), strings.LastIndex(name, "}")
+	if open >= 0 && closing > open {
+		if _, to, ok := strings.Cut(name[open+1:closing], arrow); ok {
+			return strings.ReplaceAll(name[:open]+to+name[closing+1:], "//", "/")
+		}
+	}
+
+	if _, to, ok := strings.Cut(name, arrow); ok {
+		return to
+	}
+	return name
+}
+
+// splitDiff splits a unified diff into per-file sections
+func splitDiff(diff string) []string {
+	var sections []string
+	var current []string
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") && len(current) > 0 {
+			sections = append(sections, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+
+	if len(current) > 0 {
+		sections = append(sections, strings.Join(current, "\n"))
+	}
+
+	return sections
+}
+
+// sectionPath returns the destination path of a diff section
+func sectionPath(section string) string {
+	header, _, _ := strings.Cut(section, "\n")
+	if !strings.HasPrefix(header, "diff --git ") {
+		return ""
+	}
+
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+len(" b/"):]
+	}
+
+	return ""
+}
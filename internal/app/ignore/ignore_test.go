@@ -0,0 +1,210 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "Success with basename glob",
+			patterns: []string{"*.lock"},
+			path:     "web/yarn.lock",
+			expected: true,
+		},
+		{
+			name:     "Success with exact file name",
+			patterns: []string{"go.sum"},
+			path:     "go.sum",
+			expected: true,
+		},
+		{
+			name:     "Success with directory pattern",
+			patterns: []string{"vendor/"},
+			path:     "vendor/github.com/pkg/errors/errors.go",
+			expected: true,
+		},
+		{
+			name:     "Success with directory pattern not matching a file",
+			patterns: []string{"vendor/"},
+			path:     "vendor",
+			expected: false,
+		},
+		{
+			name:     "Success with anchored pattern",
+			patterns: []string{"/gen/*.go"},
+			path:     "pkg/gen/types.go",
+			expected: false,
+		},
+		{
+			name:     "Success with double star",
+			patterns: []string{"**/__snapshots__/**"},
+			path:     "web/src/__snapshots__/App.test.js.snap",
+			expected: true,
+		},
+		{
+			name:     "Success with negation",
+			patterns: []string{"*.pb.go", "!api/keep.pb.go"},
+			path:     "api/keep.pb.go",
+			expected: false,
+		},
+		{
+			name:     "Success ignoring comments and blank lines",
+			patterns: []string{"# generated", "", "  "},
+			path:     "# generated",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, New(tt.patterns).Match(tt.path))
+		})
+	}
+}
+
+func Test_Match_NilMatcher(t *testing.T) {
+	var m *Matcher
+	assert.False(t, m.Match("go.sum"))
+}
+
+func Test_FilterDiff(t *testing.T) {
+	diff := "diff --git a/main.go b/main.go\n+package main\n" +
+		"diff --git a/go.sum b/go.sum\n+github.com/pkg/errors v0.9.1\n" +
+		"diff --git a/web/yarn.lock b/web/yarn.lock\n+lodash@4"
+
+	tests := []struct {
+		name     string
+		patterns []string
+		diff     string
+		kept     string
+		excluded []string
+	}{
+		{
+			name:     "Success without patterns",
+			diff:     diff,
+			kept:     diff,
+			excluded: nil,
+		},
+		{
+			name:     "Success excluding matching sections",
+			patterns: []string{"go.sum", "*.lock"},
+			diff:     diff,
+			kept:     "diff --git a/main.go b/main.go\n+package main",
+			excluded: []string{"go.sum", "web/yarn.lock"},
+		},
+		{
+			name:     "Success excluding stat lines and summaries",
+			patterns: []string{"go.sum", "*.png", "gen/"},
+			diff: " go.sum            |   2 +\n logo.png          | Bin\n main.go           |   1 +\n {gen => api}/x.go |   0\n 4 files changed\n\n" +
+				"go.sum: large change, +2000 -0 lines, 1KB -> 2KB\nlogo.png: binary, added 2KB\ngen/x.go -> api/x.go: renamed without changes\n\n" +
+				"diff --git a/main.go b/main.go\n+package main",
+			kept:     " main.go           |   1 +\n {gen => api}/x.go |   0\n 4 files changed\n\ngen/x.go -> api/x.go: renamed without changes\n\ndiff --git a/main.go b/main.go\n+package main",
+			excluded: []string{"go.sum", "logo.png"},
+		},
+		{
+			name:     "Success keeping the overview without matches",
+			patterns: []string{"go.sum"},
+			diff:     " main.go | 1 +\n 1 file changed\n\ndiff --git a/main.go b/main.go\n+package main",
+			kept:     " main.go | 1 +\n 1 file changed\n\ndiff --git a/main.go b/main.go\n+package main",
+			excluded: nil,
+		},
+		{
+			name:     "Success with empty diff",
+			patterns: []string{"go.sum"},
+			diff:     "",
+			kept:     "",
+			excluded: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, excluded := New(tt.patterns).FilterDiff(tt.diff)
+			assert.Equal(t, tt.kept, kept)
+			assert.Equal(t, tt.excluded, excluded)
+		})
+	}
+}
+
+func Test_PromptDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		diff     string
+		expected string
+	}{
+		{
+			name:     "Success appending summary",
+			patterns: []string{"go.sum"},
+			diff:     "diff --git a/main.go b/main.go\n+package main\ndiff --git a/go.sum b/go.sum\n+x",
+			expected: "diff --git a/main.go b/main.go\n+package main\n\n1 file regenerated (contents omitted): go.sum",
+		},
+		{
+			name:     "Success with only excluded files",
+			patterns: []string{"go.sum", "go.mod"},
+			diff:     "diff --git a/go.mod b/go.mod\n+x\ndiff --git a/go.sum b/go.sum\n+x",
+			expected: "2 files regenerated (contents omitted): go.mod, go.sum",
+		},
+		{
+			name:     "Success with only excluded files in the overview",
+			patterns: []string{"go.sum"},
+			diff:     " go.sum | 2000 +\n 1 file changed, 2000 insertions(+)\n\ngo.sum: large change, +2000 -0 lines, 1KB -> 2KB",
+			expected: "1 file regenerated (contents omitted): go.sum",
+		},
+		{
+			name:     "Success without exclusions",
+			patterns: []string{"go.sum"},
+			diff:     "diff --git a/main.go b/main.go\n+package main",
+			expected: "diff --git a/main.go b/main.go\n+package main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, New(tt.patterns).PromptDiff(tt.diff))
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(originalWd)
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, FileName), []byte("# generated code\n*.pb.go\n"), 0o644))
+
+	nopLogger := zerolog.Nop()
+	mockLogger := logger.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+
+	cfg := config.DefaultConfig()
+	cfg.Diff.Exclude = []string{"go.sum"}
+
+	m := Load(cfg, mockLogger)
+
+	assert.True(t, m.Match("go.sum"))
+	assert.True(t, m.Match("api/service.pb.go"))
+	assert.False(t, m.Match("main.go"))
+}
@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+// MaxHeaderLength is the longest header accepted, matching the limit of the structured form
+const MaxHeaderLength = 72
+
+// Check returns the reasons a commit message does not follow the conventional
+// commit format, or nothing when it does
+func Check(message string) []string {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return []string{"message is empty"}
+	}
+
+	header, rest, _ := strings.Cut(message, "\n")
+	header = strings.TrimSpace(header)
+
+	var problems []string
+
+	conv := git.ParseConventional(header, nil)
+	switch {
+	case !conv.IsValid():
+		problems = append(problems, "header is not in the form type(scope): description")
+	case !slices.Contains(gpt.CommitTypes(), conv.Type):
+		problems = append(problems, fmt.Sprintf("unknown type %q", conv.Type))
+	}
+
+	if conv.IsValid() && strings.HasSuffix(conv.Description, ".") {
+		problems = append(problems, "description ends with a period")
+	}
+
+	if len(header) > MaxHeaderLength {
+		problems = append(problems, fmt.Sprintf("header is longer than %d characters", MaxHeaderLength))
+	}
+
+	if rest != "" && strings.TrimSpace(strings.SplitN(rest, "\n", 2)[0]) != "" {
+		problems = append(problems, "body is not separated from the header by a blank line")
+	}
+
+	return problems
+}
+
+// IsValid reports whether a commit message follows the conventional commit format
+func IsValid(message string) bool {
+	return len(Check(message)) == 0
+}
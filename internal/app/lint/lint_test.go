@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected []string
+	}{
+		{
+			name:    "Success with conventional message",
+			message: "feat(api): Add endpoint\n\nServes the current version.",
+		},
+		{
+			name:    "Success with breaking change",
+			message: "refactor!: Drop the v1 config format",
+		},
+		{
+			name:     "Failure with free-form subject",
+			message:  "Fixed stuff",
+			expected: []string{"header is not in the form type(scope): description"},
+		},
+		{
+			name:     "Failure with unknown type",
+			message:  "feature: Add endpoint",
+			expected: []string{`unknown type "feature"`},
+		},
+		{
+			name:     "Failure with trailing period and long header",
+			message:  "fix: " + strings.Repeat("a", 70) + ".",
+			expected: []string{"description ends with a period", "header is longer than 72 characters"},
+		},
+		{
+			name:     "Failure without blank line",
+			message:  "fix: Handle nil\nThe config may be nil.",
+			expected: []string{"body is not separated from the header by a blank line"},
+		},
+		{
+			name:     "Failure with empty message",
+			message:  "  \n",
+			expected: []string{"message is empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := Check(tt.message)
+
+			assert.Equal(t, tt.expected, problems)
+			assert.Equal(t, len(tt.expected) == 0, IsValid(tt.message))
+		})
+	}
+}
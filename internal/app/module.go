@@ -4,6 +4,7 @@ import (
 	"go.uber.org/fx"
 
 	"cmt/internal/app/cli"
+	"cmt/internal/app/credentials"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
 	"cmt/internal/config/logger"
@@ -11,6 +12,7 @@ import (
 
 var Module = fx.Options(
 	cli.Module,
+	credentials.Module,
 	git.Module,
 	gpt.Module,
 	logger.Module,
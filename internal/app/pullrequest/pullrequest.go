@@ -0,0 +1,203 @@
+package pullrequest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+// fallbackBase is the base branch when neither the remote nor the git config names one
+const fallbackBase = "main"
+
+// TemplatePaths lists the locations of the pull request template looked up
+// at the repository root, in the order GitHub resolves them
+var TemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// Section is a heading of a pull request template and the text below it
+type Section struct {
+	// Line is the heading as written in the template, such as ## Summary
+	Line string
+	// Heading is the heading text without the leading # characters
+	Heading string
+	Body    string
+}
+
+// Template is a pull request template split at its headings
+type Template struct {
+	Preamble string
+	Sections []Section
+}
+
+// DefaultBase returns the branch a pull request is compared with when none is
+// given: the default branch of origin, then init.defaultBranch, then main
+func DefaultBase(ctx context.Context, gitClient git.Client) string {
+	if branch, err := gitClient.DefaultBranch(ctx); err == nil && branch != "" {
+		return branch
+	}
+
+	if branch, err := gitClient.ConfigValue(ctx, "init.defaultBranch"); err == nil && branch != "" {
+		return branch
+	}
+
+	return fallbackBase
+}
+
+// FindTemplate returns the path of the first pull request template found under root
+func FindTemplate(root string) (string, bool) {
+	for _, path := range TemplatePaths {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		if info, err := os.Stat(full); err == nil && !info.IsDir() {
+			return full, true
+		}
+	}
+	return "", false
+}
+
+// ParseTemplate splits a template at its Markdown headings, ignoring lines
+// starting with # inside code blocks
+func ParseTemplate(content string) Template {
+	var t Template
+	var body []string
+	fenced := false
+
+	flush := func() {
+		text := strings.Trim(strings.Join(body, "\n"), "\n")
+		if len(t.Sections) == 0 {
+			t.Preamble = text
+		} else {
+			t.Sections[len(t.Sections)-1].Body = text
+		}
+		body = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+		}
+
+		if heading, ok := headingText(line); ok && !fenced {
+			flush()
+			t.Sections = append(t.Sections, Section{Line: strings.TrimRight(line, " "), Heading: heading})
+			continue
+		}
+
+		body = append(body, line)
+	}
+	flush()
+
+	return t
+}
+
+// Render returns the description of the pull request, filling in the
+// template section by section when one is given
+func Render(pr gpt.PullRequest, template string) string {
+	if strings.TrimSpace(template) == "" {
+		return renderDefault(pr)
+	}
+
+	t := ParseTemplate(template)
+
+	var parts []string
+	if t.Preamble != "" {
+		parts = append(parts, t.Preamble)
+	}
+
+	for _, s := range t.Sections {
+		content := sectionContent(pr, s.Heading)
+		if content == "" {
+			content = s.Body
+		}
+
+		part := s.Line
+		if content != "" {
+			part += "\n\n" + content
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Format returns the title followed by the description, separated by a blank line
+func Format(pr gpt.PullRequest, template string) string {
+	return pr.Title + "\n\n" + Render(pr, template)
+}
+
+// renderDefault lays out the description without a template, leaving out empty sections
+func renderDefault(pr gpt.PullRequest) string {
+	var parts []string
+
+	add := func(title, content string) {
+		if content = strings.TrimSpace(content); content != "" {
+			parts = append(parts, "## "+title+"\n\n"+content)
+		}
+	}
+
+	add("Summary", pr.Summary)
+	add("Changes", bullets(pr.Changes))
+	add("Testing", pr.Testing)
+	add("Breaking Changes", pr.Breaking)
+
+	return strings.Join(parts, "\n\n")
+}
+
+// sectionContent returns the generated content of a template section, falling
+// back to the matching part of the default layout when the model left it out
+func sectionContent(pr gpt.PullRequest, heading string) string {
+	for key, value := range pr.Sections {
+		if strings.EqualFold(strings.TrimSpace(key), heading) {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	if len(pr.Sections) > 0 {
+		return ""
+	}
+
+	name := strings.ToLower(heading)
+	switch {
+	case strings.Contains(name, "breaking"):
+		return strings.TrimSpace(pr.Breaking)
+	case strings.Contains(name, "test"):
+		return strings.TrimSpace(pr.Testing)
+	case strings.Contains(name, "change"):
+		return bullets(pr.Changes)
+	case strings.Contains(name, "summary"), strings.Contains(name, "description"), strings.Contains(name, "what"):
+		return strings.TrimSpace(pr.Summary)
+	default:
+		return ""
+	}
+}
+
+// bullets formats the items as a Markdown list
+func bullets(items []string) string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			lines = append(lines, "- "+strings.TrimPrefix(item, "- "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// headingText returns the text of an ATX Markdown heading
+func headingText(line string) (string, bool) {
+	trimmed := strings.TrimLeft(line, "#")
+	level := len(line) - len(trimmed)
+	if level == 0 || level > 6 || (trimmed != "" && trimmed[0] != ' ' && trimmed[0] != '\t') {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(trimmed), "#")), true
+}
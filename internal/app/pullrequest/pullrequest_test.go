@@ -0,0 +1,142 @@
+package pullrequest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+func Test_DefaultBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   func(mockGit *git.MockClient)
+		expected string
+	}{
+		{
+			name: "Success with default branch of the remote",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().DefaultBranch(gomock.Any()).Return("trunk", nil)
+			},
+			expected: "trunk",
+		},
+		{
+			name: "Success with init.defaultBranch",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().DefaultBranch(gomock.Any()).Return("", nil)
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "init.defaultBranch").Return("develop", nil)
+			},
+			expected: "develop",
+		},
+		{
+			name: "Success with fallback",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().DefaultBranch(gomock.Any()).Return("", assert.AnError)
+				mockGit.EXPECT().ConfigValue(gomock.Any(), "init.defaultBranch").Return("", nil)
+			},
+			expected: "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGit := git.NewMockClient(ctrl)
+			tt.before(mockGit)
+
+			assert.Equal(t, tt.expected, DefaultBase(context.Background(), mockGit))
+		})
+	}
+}
+
+func Test_FindTemplate(t *testing.T) {
+	root := t.TempDir()
+
+	_, ok := FindTemplate(root)
+	assert.False(t, ok)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "pull_request_template.md"), []byte("## Summary"), 0o644))
+
+	path, ok := FindTemplate(root)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(root, "docs", "pull_request_template.md"), path)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "pull_request_template.md"), []byte("## Summary"), 0o644))
+
+	path, ok = FindTemplate(root)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(root, ".github", "pull_request_template.md"), path)
+}
+
+func Test_ParseTemplate(t *testing.T) {
+	content := "Thanks for contributing!\r\n\r\n## Summary\r\n\r\n<!-- What does it do? -->\r\n\r\n## How to test\r\n\r\n```sh\r\n# run the tests\r\ngo test ./...\r\n```\r\n\r\n### Checklist ###\r\n- [ ] Docs updated\r\n"
+
+	expected := Template{
+		Preamble: "Thanks for contributing!",
+		Sections: []Section{
+			{Line: "## Summary", Heading: "Summary", Body: "<!-- What does it do? -->"},
+			{Line: "## How to test", Heading: "How to test", Body: "```sh\n# run the tests\ngo test ./...\n```"},
+			{Line: "### Checklist ###", Heading: "Checklist", Body: "- [ ] Docs updated"},
+		},
+	}
+
+	assert.Equal(t, expected, ParseTemplate(content))
+}
+
+func Test_Render(t *testing.T) {
+	pr := gpt.PullRequest{
+		Title:   "Add retry to the API client",
+		Summary: "Retries failed requests with backoff.",
+		Changes: []string{"Add retry loop", "- Make the retry count configurable"},
+		Testing: "Unit tests cover the retry loop.",
+	}
+
+	tests := []struct {
+		name     string
+		pr       gpt.PullRequest
+		template string
+		expected string
+	}{
+		{
+			name:     "Success with default layout",
+			pr:       pr,
+			expected: "## Summary\n\nRetries failed requests with backoff.\n\n## Changes\n\n- Add retry loop\n- Make the retry count configurable\n\n## Testing\n\nUnit tests cover the retry loop.",
+		},
+		{
+			name: "Success with generated sections",
+			pr: gpt.PullRequest{
+				Title:    "Add retry to the API client",
+				Sections: map[string]string{"what": "Retries failed requests.", "Why": "Requests fail under load."},
+			},
+			template: "## What\n\n<!-- describe -->\n\n## Why\n\n## Checklist\n\n- [ ] Docs updated",
+			expected: "## What\n\nRetries failed requests.\n\n## Why\n\nRequests fail under load.\n\n## Checklist\n\n- [ ] Docs updated",
+		},
+		{
+			name:     "Success with sections matched by heading",
+			pr:       pr,
+			template: "Intro\n\n## Description\n\n## Test plan\n\n## Breaking changes\n\nNone expected.",
+			expected: "Intro\n\n## Description\n\nRetries failed requests with backoff.\n\n## Test plan\n\nUnit tests cover the retry loop.\n\n## Breaking changes\n\nNone expected.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.pr, tt.template))
+		})
+	}
+}
+
+func Test_Format(t *testing.T) {
+	pr := gpt.PullRequest{Title: "Fix crash on empty diff", Summary: "Handles an empty diff."}
+
+	assert.Equal(t, "Fix crash on empty diff\n\n## Summary\n\nHandles an empty diff.", Format(pr, ""))
+}
@@ -0,0 +1,140 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/semver"
+)
+
+// DefaultPrefix is put in front of the first version when the repository has no tags
+const DefaultPrefix = "v"
+
+// Reason is a commit that raises the version and the level it calls for
+type Reason struct {
+	Commit git.Commit
+	Level  semver.Level
+}
+
+// Plan is the version recommended for the commits since the last release
+type Plan struct {
+	// Current is the latest release tag, empty for the first release
+	Current string
+	Next    semver.Version
+	Level   semver.Level
+	Reasons []Reason
+}
+
+// Baseline returns the latest release tag without pre-release and the latest tag overall
+func Baseline(tags []string) (string, string) {
+	sorted := semver.Sort(tags)
+	if len(sorted) == 0 {
+		return "", ""
+	}
+
+	latest := sorted[len(sorted)-1]
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if v, _ := semver.Parse(sorted[i]); !v.IsPrerelease() {
+			return sorted[i], latest
+		}
+	}
+
+	return "", latest
+}
+
+// LevelOf returns the level a commit calls for: major for breaking changes,
+// minor for features and patch for fixes and performance improvements
+func LevelOf(c git.Commit) semver.Level {
+	cc := c.Conventional()
+
+	switch {
+	case cc.Breaking:
+		return semver.Major
+	case cc.Type == "feat":
+		return semver.Minor
+	case cc.Type == "fix" || cc.Type == "perf":
+		return semver.Patch
+	default:
+		return semver.None
+	}
+}
+
+// NewPlan computes the next version from the commits since the stable tag,
+// continuing the pre-release of the latest tag when pre matches it
+func NewPlan(stableTag, latestTag string, commits []git.Commit, pre string) Plan {
+	plan := Plan{Current: latestTag}
+
+	for _, c := range commits {
+		if c.IsMerge() {
+			continue
+		}
+
+		level := LevelOf(c)
+		if level == semver.None {
+			continue
+		}
+
+		plan.Reasons = append(plan.Reasons, Reason{Commit: c, Level: level})
+		if level > plan.Level {
+			plan.Level = level
+		}
+	}
+
+	stable := semver.Version{Prefix: DefaultPrefix}
+	if v, err := semver.Parse(stableTag); err == nil {
+		stable = v
+	}
+
+	latest := stable
+	if v, err := semver.Parse(latestTag); err == nil {
+		latest = v
+	}
+
+	if plan.Level == semver.None && !latest.IsPrerelease() {
+		plan.Next = latest
+		return plan
+	}
+
+	plan.Next = semver.Next(stable, latest, plan.Level, pre)
+	return plan
+}
+
+// IsRelease reports whether the plan moves to a new version
+func (p Plan) IsRelease() bool {
+	if p.Current == "" {
+		return p.Level != semver.None
+	}
+	return p.Next.String() != p.Current
+}
+
+// Rationale explains the recommended version and lists the commits raising it
+func (p Plan) Rationale() string {
+	var b strings.Builder
+
+	current := p.Current
+	if current == "" {
+		current = "none"
+	}
+	fmt.Fprintf(&b, "Current version: %s\n", current)
+
+	if !p.IsRelease() {
+		b.WriteString("No release needed: no breaking changes, features or fixes since the last release\n")
+		return b.String()
+	}
+
+	if p.Level == semver.None {
+		fmt.Fprintf(&b, "Next version:    %s (finalizes %s)\n", p.Next, p.Current)
+	} else {
+		fmt.Fprintf(&b, "Next version:    %s (%s)\n", p.Next, p.Level)
+	}
+
+	if len(p.Reasons) > 0 {
+		b.WriteString("\nChanges:\n")
+	}
+	for _, r := range p.Reasons {
+		fmt.Fprintf(&b, "  %-6s %s %s\n", r.Level, r.Commit.ShortHash(), r.Commit.Subject)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/semver"
+)
+
+func Test_Baseline(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		stable string
+		latest string
+	}{
+		{name: "Success with releases", tags: []string{"v1.0.0", "v1.1.0", "nightly"}, stable: "v1.1.0", latest: "v1.1.0"},
+		{name: "Success with pre-release on top", tags: []string{"v1.0.0", "v1.1.0-rc.1"}, stable: "v1.0.0", latest: "v1.1.0-rc.1"},
+		{name: "Success with pre-releases only", tags: []string{"v1.0.0-rc.1"}, stable: "", latest: "v1.0.0-rc.1"},
+		{name: "Success without tags", tags: nil, stable: "", latest: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stable, latest := Baseline(tt.tags)
+
+			assert.Equal(t, tt.stable, stable)
+			assert.Equal(t, tt.latest, latest)
+		})
+	}
+}
+
+func Test_LevelOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   git.Commit
+		expected semver.Level
+	}{
+		{name: "Success with breaking marker", commit: git.Commit{Subject: "fix(api)!: Drop v1"}, expected: semver.Major},
+		{name: "Success with breaking trailer", commit: git.Commit{Subject: "docs: Update", Trailers: []git.Trailer{{Key: "BREAKING CHANGE", Value: "x"}}}, expected: semver.Major},
+		{name: "Success with feature", commit: git.Commit{Subject: "feat: Add login"}, expected: semver.Minor},
+		{name: "Success with fix", commit: git.Commit{Subject: "fix: Handle nil"}, expected: semver.Patch},
+		{name: "Success with performance", commit: git.Commit{Subject: "perf: Cache lookups"}, expected: semver.Patch},
+		{name: "Success with chore", commit: git.Commit{Subject: "chore: Bump deps"}, expected: semver.None},
+		{name: "Success with free-form subject", commit: git.Commit{Subject: "Update readme"}, expected: semver.None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LevelOf(tt.commit))
+		})
+	}
+}
+
+func Test_NewPlan(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+		{Hash: "bbbbbbb222", Subject: "chore: Bump deps"},
+		{Hash: "ccccccc333", Subject: "feat(api): Add endpoint"},
+	}
+
+	tests := []struct {
+		name      string
+		stable    string
+		latest    string
+		commits   []git.Commit
+		pre       string
+		next      string
+		level     semver.Level
+		release   bool
+		rationale string
+	}{
+		{
+			name:    "Success with minor release",
+			stable:  "v1.2.0",
+			latest:  "v1.2.0",
+			commits: commits,
+			next:    "v1.3.0",
+			level:   semver.Minor,
+			release: true,
+			rationale: "Current version: v1.2.0\n" +
+				"Next version:    v1.3.0 (minor)\n\n" +
+				"Changes:\n" +
+				"  patch  aaaaaaa fix: Handle nil\n" +
+				"  minor  ccccccc feat(api): Add endpoint\n",
+		},
+		{
+			name:    "Success with first release",
+			commits: commits[:1],
+			next:    "v0.0.1",
+			level:   semver.Patch,
+			release: true,
+			rationale: "Current version: none\n" +
+				"Next version:    v0.0.1 (patch)\n\n" +
+				"Changes:\n" +
+				"  patch  aaaaaaa fix: Handle nil\n",
+		},
+		{
+			name:      "Success without releasable changes",
+			stable:    "v1.2.0",
+			latest:    "v1.2.0",
+			commits:   commits[1:2],
+			next:      "v1.2.0",
+			level:     semver.None,
+			release:   false,
+			rationale: "Current version: v1.2.0\nNo release needed: no breaking changes, features or fixes since the last release\n",
+		},
+		{
+			name:      "Success with finalized pre-release",
+			stable:    "v1.2.0",
+			latest:    "v1.3.0-rc.1",
+			commits:   commits[1:2],
+			next:      "v1.3.0",
+			level:     semver.None,
+			release:   true,
+			rationale: "Current version: v1.3.0-rc.1\nNext version:    v1.3.0 (finalizes v1.3.0-rc.1)\n",
+		},
+		{
+			name:    "Success with pre-release",
+			stable:  "v1.2.0",
+			latest:  "v1.3.0-rc.1",
+			commits: commits,
+			pre:     "rc",
+			next:    "v1.3.0-rc.2",
+			level:   semver.Minor,
+			release: true,
+			rationale: "Current version: v1.3.0-rc.1\n" +
+				"Next version:    v1.3.0-rc.2 (minor)\n\n" +
+				"Changes:\n" +
+				"  patch  aaaaaaa fix: Handle nil\n" +
+				"  minor  ccccccc feat(api): Add endpoint\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := NewPlan(tt.stable, tt.latest, tt.commits, tt.pre)
+
+			assert.Equal(t, tt.next, plan.Next.String())
+			assert.Equal(t, tt.level, plan.Level)
+			assert.Equal(t, tt.release, plan.IsRelease())
+			assert.Equal(t, tt.rationale, plan.Rationale())
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config"
+)
+
+// Output formats of the review for CI
+const (
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatJSON, FormatSARIF}
+
+// sarifSchema and sarifVersion identify the SARIF specification of the output
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolURI      = "https://github.com/tab/cmt"
+)
+
+// Document is the stable schema of the JSON output
+type Document struct {
+	Findings []gpt.Finding `json:"findings"`
+}
+
+// sarifLog is the subset of the SARIF 2.1.0 log written for code scanning tools
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// Format renders the findings in the given output format
+func Format(findings []gpt.Finding, format string) (string, error) {
+	if findings == nil {
+		findings = []gpt.Finding{}
+	}
+
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return encode(Document{Findings: findings})
+	case FormatSARIF:
+		return encode(sarif(findings))
+	default:
+		return "", fmt.Errorf("%w: %s (use %s)", errors.ErrInvalidReviewFormat, format, strings.Join(Formats, ", "))
+	}
+}
+
+// sarif converts the findings to a SARIF log with a single run
+func sarif(findings []gpt.Finding) sarifLog {
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		result := sarifResult{
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Message},
+		}
+
+		if f.File != "" {
+			location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: f.File}}
+			if f.Line > 0 {
+				location.Region = &sarifRegion{StartLine: f.Line}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "cmt", Version: config.Version, InformationURI: toolURI}},
+			Results: results,
+		}},
+	}
+}
+
+// HasErrors reports whether any finding has the error severity
+func HasErrors(findings []gpt.Finding) bool {
+	for _, f := range findings {
+		if f.Severity == gpt.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// sarifLevel maps a finding severity to a SARIF result level
+func sarifLevel(severity string) string {
+	switch severity {
+	case gpt.SeverityError:
+		return "error"
+	case gpt.SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// encode renders a value as indented JSON without escaping HTML characters
+func encode(v any) (string, error) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
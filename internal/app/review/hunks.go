@@ -0,0 +1,67 @@
+package review
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cmt/internal/app/git"
+)
+
+// hunkPattern matches the start and length of the new side in a hunk header
+var hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// Files splits a combined diff into the diff of each file, keyed by the path after the change.
+// Lines before the first file, such as the --stat overview, are left out
+func Files(diff string) map[string]git.FileDiff {
+	files := make(map[string]git.FileDiff)
+
+	var path string
+	var lines []string
+	flush := func() {
+		if path != "" {
+			files[path] = git.ParseFileDiff(strings.Join(lines, "\n"))
+		}
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if header, ok := strings.CutPrefix(line, "diff --git "); ok {
+			flush()
+			path, lines = "", nil
+			if i := strings.LastIndex(header, " b/"); i >= 0 {
+				path = header[i+3:]
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return files
+}
+
+// Locate returns the index of the hunk containing the line of the new file,
+// or of the last hunk starting before it, and 0 when the line is unknown
+func Locate(diff git.FileDiff, line int) int {
+	index := 0
+	for i, hunk := range diff.Hunks {
+		match := hunkPattern.FindStringSubmatch(hunk.Header)
+		if match == nil {
+			continue
+		}
+
+		start, _ := strconv.Atoi(match[1])
+		length := 1
+		if match[2] != "" {
+			length, _ = strconv.Atoi(match[2])
+		}
+
+		if line < start {
+			break
+		}
+		index = i
+		if line < start+max(length, 1) {
+			break
+		}
+	}
+	return index
+}
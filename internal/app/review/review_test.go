@@ -0,0 +1,122 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config"
+)
+
+const testDiff = ` api.go  | 3 ++-
+ util.go | 1 +
+ 2 files changed, 3 insertions(+), 1 deletion(-)
+
+diff --git a/api.go b/api.go
+index 1111111..2222222 100644
+--- a/api.go
++++ b/api.go
+@@ -3,3 +3,3 @@ package api
+ func Name(cfg *Config) string {
+-	return cfg.Name
++	return name(cfg)
+ }
+@@ -20,2 +20,3 @@ func name(cfg *Config) string {
+ 	if cfg == nil {
++		return ""
+ 	}
+diff --git a/util.go b/util.go
+new file mode 100644
+--- /dev/null
++++ b/util.go
+@@ -0,0 +1 @@
++package api`
+
+func Test_Files(t *testing.T) {
+	files := Files(testDiff)
+
+	require.Len(t, files, 2)
+	assert.Len(t, files["api.go"].Hunks, 2)
+	assert.Equal(t, "@@ -20,2 +20,3 @@ func name(cfg *Config) string {", files["api.go"].Hunks[1].Header)
+	assert.Equal(t, []string{"+package api"}, files["util.go"].Hunks[0].Lines)
+}
+
+func Test_Locate(t *testing.T) {
+	diff := Files(testDiff)["api.go"]
+
+	tests := []struct {
+		name     string
+		line     int
+		expected int
+	}{
+		{name: "Success with unknown line", line: 0, expected: 0},
+		{name: "Success with line in first hunk", line: 4, expected: 0},
+		{name: "Success with line between hunks", line: 12, expected: 0},
+		{name: "Success with line in second hunk", line: 21, expected: 1},
+		{name: "Success with line after last hunk", line: 90, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Locate(diff, tt.line))
+		})
+	}
+}
+
+func Test_Format(t *testing.T) {
+	findings := []gpt.Finding{
+		{File: "api.go", Line: 4, Severity: gpt.SeverityError, Message: "cfg may be nil"},
+		{File: "util.go", Severity: gpt.SeverityInfo, Message: "Add a package comment"},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+		err      error
+	}{
+		{
+			name:     "Success with JSON",
+			format:   "json",
+			expected: `{"findings":[{"file":"api.go","line":4,"severity":"error","message":"cfg may be nil"},{"file":"util.go","line":0,"severity":"info","message":"Add a package comment"}]}`,
+		},
+		{
+			name:     "Success with SARIF",
+			format:   "SARIF",
+			expected: `{"$schema":"https://json.schemastore.org/sarif-2.1.0.json","version":"2.1.0","runs":[{"tool":{"driver":{"name":"cmt","version":"` + config.Version + `","informationUri":"https://github.com/tab/cmt"}},"results":[{"level":"error","message":{"text":"cfg may be nil"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"api.go"},"region":{"startLine":4}}}]},{"level":"note","message":{"text":"Add a package comment"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"util.go"}}}]}]}]}`,
+		},
+		{
+			name:   "Failure with unknown format",
+			format: "xml",
+			err:    errors.ErrInvalidReviewFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Format(findings, tt.format)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Format_Empty(t *testing.T) {
+	result, err := Format(nil, FormatJSON)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"findings":[]}`, result)
+}
+
+func Test_HasErrors(t *testing.T) {
+	assert.False(t, HasErrors([]gpt.Finding{{Severity: gpt.SeverityWarning}}))
+	assert.True(t, HasErrors([]gpt.Finding{{Severity: gpt.SeverityInfo}, {Severity: gpt.SeverityError}}))
+}
@@ -0,0 +1,282 @@
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cmt/internal/app/errors"
+)
+
+const (
+	// minEntropyLength is the shortest value checked for high entropy
+	minEntropyLength = 20
+	// base64EntropyThreshold is the Shannon entropy above which a base64-like value is reported
+	base64EntropyThreshold = 4.0
+	// hexEntropyThreshold is the Shannon entropy above which a hex value is reported
+	hexEntropyThreshold = 3.0
+)
+
+// privateKeyRule names the rule matching the first line of a private key block
+const privateKeyRule = "private key"
+
+// Finding is a suspected secret on an added line of a diff
+type Finding struct {
+	File   string
+	Line   int
+	Column int
+	Rule   string
+	Match  string
+	// EndLine is the last line of a secret spanning several lines, such as a
+	// private key block, and zero for a secret on a single line
+	EndLine int
+}
+
+// String returns the location and rule of the finding
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Rule)
+}
+
+// Error reports the secrets that prevented the diff from being sent
+type Error struct {
+	Findings []Finding
+}
+
+// Error returns the list of findings
+func (e *Error) Error() string {
+	lines := []string{fmt.Sprintf("%v in staged changes:", errors.ErrSecretsDetected)}
+	for _, f := range e.Findings {
+		lines = append(lines, "  "+f.String())
+	}
+	lines = append(lines, "unstage the values or set secrets.mode to redact")
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the sentinel error
+func (e *Error) Unwrap() error {
+	return errors.ErrSecretsDetected
+}
+
+// rule is a named credential pattern
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// rules are the known credential patterns checked on every added line
+var rules = []rule{
+	{name: privateKeyRule, pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----`)},
+	{name: "AWS access key", pattern: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "AWS secret key", pattern: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`)},
+	{name: "GitHub token", pattern: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{name: "OpenAI key", pattern: regexp.MustCompile(`\bsk-(proj-)?[A-Za-z0-9_-]{20,}`)},
+	{name: "Slack token", pattern: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{name: "JWT", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+}
+
+var (
+	// privateKeyEnd matches the line closing a private key block
+	privateKeyEnd = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY( BLOCK)?-----`)
+	// assignmentPattern captures values assigned to a key in code or config
+	assignmentPattern = regexp.MustCompile(`(?i)([A-Za-z0-9_.-]*(?:key|secret|token|password|passwd|pwd|credential|auth)[A-Za-z0-9_.-]*)["']?\s*[:=]\s*["']?([^\s"',;]+)`)
+	// envPattern matches a KEY=VALUE line of a dotenv file
+	envPattern = regexp.MustCompile(`^\s*(?:export\s+)?[A-Za-z_][A-Za-z0-9_]*\s*=\s*(.+)$`)
+	// hunkPattern extracts the new start line of a hunk header
+	hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+	// hexPattern matches hexadecimal strings
+	hexPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	// base64Pattern matches base64 and URL-safe base64 strings
+	base64Pattern = regexp.MustCompile(`^[A-Za-z0-9+/_=-]+$`)
+)
+
+// Scan returns the suspected secrets on the added lines of a unified diff.
+// A private key covers its whole block of added lines up to the END line
+func Scan(diff string) []Finding {
+	var findings []Finding
+	block := -1
+
+	walkAdded(strings.Split(diff, "\n"), func(_ int, file string, line int, text string) {
+		if block >= 0 {
+			if f := &findings[block]; f.File == file && f.EndLine+1 == line {
+				f.EndLine = line
+				if privateKeyEnd.MatchString(text) {
+					block = -1
+				}
+				return
+			}
+			block = -1
+		}
+
+		for _, f := range scanLine(file, line, text) {
+			if f.Rule == privateKeyRule && !privateKeyEnd.MatchString(text[f.Column:]) {
+				f.EndLine = line
+				block = len(findings)
+			}
+			findings = append(findings, f)
+		}
+	})
+
+	return findings
+}
+
+// Redact replaces each finding on its own added line with a placeholder naming
+// its rule, and every line of a secret spanning several lines as a whole
+func Redact(diff string, findings []Finding) string {
+	type location struct {
+		file string
+		line int
+	}
+
+	byLine := make(map[location][]Finding)
+	spanned := make(map[location]string)
+	for _, f := range findings {
+		for line := f.Line + 1; line <= f.EndLine; line++ {
+			spanned[location{file: f.File, line: line}] = f.Rule
+		}
+		key := location{file: f.File, line: f.Line}
+		byLine[key] = append(byLine[key], f)
+	}
+
+	lines := strings.Split(diff, "\n")
+	walkAdded(lines, func(i int, file string, line int, text string) {
+		if rule, ok := spanned[location{file: file, line: line}]; ok {
+			lines[i] = "+[REDACTED " + rule + "]"
+			return
+		}
+
+		found := byLine[location{file: file, line: line}]
+		if len(found) == 0 {
+			return
+		}
+
+		sort.SliceStable(found, func(a, b int) bool { return found[a].Column > found[b].Column })
+		for _, f := range found {
+			text = redactMatch(text, f)
+		}
+		lines[i] = "+" + text
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+// redactMatch replaces the match of a finding in the content of its line
+func redactMatch(text string, f Finding) string {
+	placeholder := "[REDACTED " + f.Rule + "]"
+	if f.EndLine > f.Line {
+		return text[:max(f.Column, 0)] + placeholder
+	}
+	if f.Column >= 0 && f.Column+len(f.Match) <= len(text) && text[f.Column:f.Column+len(f.Match)] == f.Match {
+		return text[:f.Column] + placeholder + text[f.Column+len(f.Match):]
+	}
+	return strings.ReplaceAll(text, f.Match, placeholder)
+}
+
+// walkAdded calls fn with the index, file, new line number and content of every added line of a diff
+func walkAdded(lines []string, fn func(i int, file string, line int, text string)) {
+	file := ""
+	line := 0
+
+	for i, text := range lines {
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/")
+			continue
+		case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "diff --git "):
+			continue
+		case strings.HasPrefix(text, "@@"):
+			if m := hunkPattern.FindStringSubmatch(text); m != nil {
+				line, _ = strconv.Atoi(m[1])
+			}
+			continue
+		case strings.HasPrefix(text, "+"):
+			fn(i, file, line, text[1:])
+			line++
+		case strings.HasPrefix(text, " "):
+			line++
+		}
+	}
+}
+
+// scanLine checks a single added line against the rules
+func scanLine(file string, line int, text string) []Finding {
+	var findings []Finding
+
+	found := func(name string, column int, match string) {
+		for _, f := range findings {
+			if strings.Contains(f.Match, match) || strings.Contains(match, f.Match) {
+				return
+			}
+		}
+		findings = append(findings, Finding{File: file, Line: line, Column: column, Rule: name, Match: match})
+	}
+
+	for _, r := range rules {
+		if loc := r.pattern.FindStringIndex(text); loc != nil && loc[1] > loc[0] {
+			found(r.name, loc[0], text[loc[0]:loc[1]])
+		}
+	}
+
+	if isEnvFile(file) {
+		if m := envPattern.FindStringSubmatchIndex(text); m != nil {
+			raw := text[m[2]:m[3]]
+			if value := strings.Trim(strings.TrimSpace(raw), `"'`); value != "" {
+				found("dotenv value", m[2]+strings.Index(raw, value), value)
+			}
+		}
+	}
+
+	for _, m := range assignmentPattern.FindAllStringSubmatchIndex(text, -1) {
+		if value := text[m[4]:m[5]]; isHighEntropy(value) {
+			found("high entropy "+strings.ToLower(text[m[2]:m[3]]), m[4], value)
+		}
+	}
+
+	return findings
+}
+
+// isEnvFile reports whether the path is a dotenv file
+func isEnvFile(file string) bool {
+	base := path.Base(file)
+	if base == ".env.example" || base == ".env.sample" || base == ".env.template" {
+		return false
+	}
+	return base == ".env" || strings.HasPrefix(base, ".env.")
+}
+
+// isHighEntropy reports whether a value looks like a random secret
+func isHighEntropy(value string) bool {
+	if len(value) < minEntropyLength {
+		return false
+	}
+
+	switch {
+	case hexPattern.MatchString(value):
+		return entropy(value) > hexEntropyThreshold
+	case base64Pattern.MatchString(value):
+		return entropy(value) > base64EntropyThreshold
+	default:
+		return false
+	}
+}
+
+// entropy returns the Shannon entropy of s in bits per character
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var result float64
+	length := float64(len(s))
+	for _, count := range counts {
+		p := float64(count) / length
+		result -= p * math.Log2(p)
+	}
+
+	return result
+}
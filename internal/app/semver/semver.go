@@ -0,0 +1,249 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cmt/internal/app/errors"
+)
+
+// pattern matches a semantic version with an optional v prefix, pre-release and build metadata
+var pattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
+// Level is the part of a version raised by a release
+type Level int
+
+const (
+	// None means the changes do not call for a release
+	None Level = iota
+	// Patch is raised for bug fixes
+	Patch
+	// Minor is raised for new features
+	Minor
+	// Major is raised for breaking changes
+	Major
+)
+
+// String returns the name of the level
+func (l Level) String() string {
+	switch l {
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	default:
+		return "none"
+	}
+}
+
+// Version is a parsed semantic version
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+// Parse parses a version such as v1.2.3-rc.1+build.5
+func Parse(value string) (Version, error) {
+	m := pattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: %q", errors.ErrInvalidVersion, value)
+	}
+
+	major, _ := strconv.Atoi(m[2])
+	minor, _ := strconv.Atoi(m[3])
+	patch, _ := strconv.Atoi(m[4])
+
+	return Version{
+		Prefix:     m[1],
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		Prerelease: m[5],
+		Build:      m[6],
+	}, nil
+}
+
+// String formats the version with its prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v precedes, equals or follows other; build metadata is ignored
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// IsPrerelease reports whether the version has a pre-release part
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Bump raises the given level, resetting the lower parts and dropping the pre-release and build
+func (v Version) Bump(level Level) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	switch level {
+	case Major:
+		next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+	case Minor:
+		next.Minor, next.Patch = v.Minor+1, 0
+	case Patch:
+		next.Patch++
+	}
+
+	return next
+}
+
+// Next computes the version following latest for changes of the given level.
+// Pre-releases of the computed version are continued: with pre set to "rc",
+// 1.3.0-rc.1 becomes 1.3.0-rc.2, and without it the pre-release is finalized
+// as 1.3.0. The stable version is the latest release without pre-release
+func Next(stable, latest Version, level Level, pre string) Version {
+	next := stable.Bump(level)
+	next.Prefix = latest.Prefix
+
+	if latest.IsPrerelease() {
+		base := Version{Prefix: latest.Prefix, Major: latest.Major, Minor: latest.Minor, Patch: latest.Patch}
+		if base.Compare(next) >= 0 {
+			next = base
+		}
+	}
+
+	if pre == "" {
+		return next
+	}
+
+	counter := 1
+	if id, n, ok := splitPrerelease(latest.Prerelease); ok && id == pre && sameRelease(latest, next) {
+		counter = n + 1
+	}
+
+	next.Prerelease = fmt.Sprintf("%s.%d", pre, counter)
+	return next
+}
+
+// sameRelease reports whether both versions share major, minor and patch
+func sameRelease(a, b Version) bool {
+	return a.Major == b.Major && a.Minor == b.Minor && a.Patch == b.Patch
+}
+
+// splitPrerelease splits a pre-release such as rc.2 into its identifier and counter
+func splitPrerelease(prerelease string) (string, int, bool) {
+	id, counter, ok := strings.Cut(prerelease, ".")
+	if !ok {
+		return prerelease, 0, prerelease != ""
+	}
+
+	n, err := strconv.Atoi(counter)
+	if err != nil {
+		return "", 0, false
+	}
+	return id, n, true
+}
+
+// Sort returns the tags that are semantic versions in ascending order
+func Sort(tags []string) []string {
+	type parsed struct {
+		tag     string
+		version Version
+	}
+
+	var versions []parsed
+	for _, tag := range tags {
+		if v, err := Parse(tag); err == nil {
+			versions = append(versions, parsed{tag: tag, version: v})
+		}
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].version.Compare(versions[j].version) < 0
+	})
+
+	result := make([]string, len(versions))
+	for i, v := range versions {
+		result[i] = v.tag
+	}
+	return result
+}
+
+// Latest returns the highest semantic version among the tags
+func Latest(tags []string) (string, bool) {
+	sorted := Sort(tags)
+	if len(sorted) == 0 {
+		return "", false
+	}
+	return sorted[len(sorted)-1], true
+}
+
+// comparePrerelease orders pre-release identifiers as the specification does:
+// a version without pre-release follows one with it, numeric identifiers are
+// compared numerically and precede alphanumeric ones
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	left := strings.Split(a, ".")
+	right := strings.Split(b, ".")
+
+	for i := 0; i < len(left) && i < len(right); i++ {
+		l, lErr := strconv.Atoi(left[i])
+		r, rErr := strconv.Atoi(right[i])
+
+		switch {
+		case lErr == nil && rErr == nil:
+			if l != r {
+				return sign(l - r)
+			}
+		case lErr == nil:
+			return -1
+		case rErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return sign(len(left) - len(right))
+}
+
+// sign reduces a difference to -1, 0 or 1
+func sign(d int) int {
+	switch {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,135 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Version
+		err      error
+	}{
+		{
+			name:     "Success with prefix",
+			value:    "v1.2.3",
+			expected: Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:     "Success with pre-release and build",
+			value:    "2.0.0-rc.1+build.5",
+			expected: Version{Major: 2, Prerelease: "rc.1", Build: "build.5"},
+		},
+		{
+			name:  "Failure with missing patch",
+			value: "v1.2",
+			err:   errors.ErrInvalidVersion,
+		},
+		{
+			name:  "Failure with leading zero",
+			value: "1.02.0",
+			err:   errors.ErrInvalidVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.value)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.value, result.String())
+		})
+	}
+}
+
+func Test_Version_Compare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "Success with equal versions", a: "v1.0.0", b: "1.0.0", expected: 0},
+		{name: "Success with minor difference", a: "1.2.0", b: "1.10.0", expected: -1},
+		{name: "Success with release after pre-release", a: "1.0.0", b: "1.0.0-rc.1", expected: 1},
+		{name: "Success with numeric pre-release", a: "1.0.0-rc.2", b: "1.0.0-rc.10", expected: -1},
+		{name: "Success with numeric before alphanumeric", a: "1.0.0-1", b: "1.0.0-alpha", expected: -1},
+		{name: "Success with longer pre-release", a: "1.0.0-alpha.1", b: "1.0.0-alpha", expected: 1},
+		{name: "Success ignoring build metadata", a: "1.0.0+a", b: "1.0.0+b", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			assert.NoError(t, err)
+			b, err := Parse(tt.b)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, a.Compare(b))
+		})
+	}
+}
+
+func Test_Sort(t *testing.T) {
+	tags := []string{"v1.10.0", "latest", "v1.2.0", "v1.2.0-rc.1", "v0.9.0", "nightly-2024"}
+
+	assert.Equal(t, []string{"v0.9.0", "v1.2.0-rc.1", "v1.2.0", "v1.10.0"}, Sort(tags))
+
+	latest, ok := Latest(tags)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.10.0", latest)
+
+	_, ok = Latest([]string{"latest"})
+	assert.False(t, ok)
+}
+
+func Test_Version_Bump(t *testing.T) {
+	v, err := Parse("v1.2.3-rc.1+build")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "v2.0.0", v.Bump(Major).String())
+	assert.Equal(t, "v1.3.0", v.Bump(Minor).String())
+	assert.Equal(t, "v1.2.4", v.Bump(Patch).String())
+	assert.Equal(t, "v1.2.3", v.Bump(None).String())
+}
+
+func Test_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		stable   string
+		latest   string
+		level    Level
+		pre      string
+		expected string
+	}{
+		{name: "Success with minor release", stable: "v1.2.0", latest: "v1.2.0", level: Minor, expected: "v1.3.0"},
+		{name: "Success with major release", stable: "1.2.0", latest: "1.2.0", level: Major, expected: "2.0.0"},
+		{name: "Success with first pre-release", stable: "v1.2.0", latest: "v1.2.0", level: Minor, pre: "rc", expected: "v1.3.0-rc.1"},
+		{name: "Success with continued pre-release", stable: "v1.2.0", latest: "v1.3.0-rc.1", level: Patch, pre: "rc", expected: "v1.3.0-rc.2"},
+		{name: "Success with new pre-release identifier", stable: "v1.2.0", latest: "v1.3.0-beta.3", level: Minor, pre: "rc", expected: "v1.3.0-rc.1"},
+		{name: "Success with finalized pre-release", stable: "v1.2.0", latest: "v1.3.0-rc.2", level: Patch, expected: "v1.3.0"},
+		{name: "Success with pre-release outgrown by breaking change", stable: "v1.2.0", latest: "v1.3.0-rc.2", level: Major, pre: "rc", expected: "v2.0.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stable, err := Parse(tt.stable)
+			assert.NoError(t, err)
+			latest, err := Parse(tt.latest)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected, Next(stable, latest, tt.level, tt.pre).String())
+		})
+	}
+}
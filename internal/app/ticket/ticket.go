@@ -0,0 +1,107 @@
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+// FooterKey is the git trailer used for ticket references
+const FooterKey = "Refs"
+
+// Ticket represents a ticket reference and where it is placed in the commit message
+type Ticket struct {
+	ID        string
+	Placement string
+}
+
+// Resolve extracts the ticket from the current branch using the configured pattern
+func Resolve(ctx context.Context, cfg *config.Config, gitClient git.Client, log logger.Logger) Ticket {
+	if cfg == nil || cfg.Ticket.Pattern == "" {
+		return Ticket{}
+	}
+
+	branch, err := gitClient.CurrentBranch(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Skipping ticket detection")
+		return Ticket{}
+	}
+
+	id, err := Extract(cfg.Ticket.Pattern, branch)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to extract ticket from branch")
+		return Ticket{}
+	}
+
+	log.Debug().Str("branch", branch).Str("ticket", id).Msg("Resolved ticket from branch")
+	return Ticket{ID: id, Placement: cfg.Ticket.Placement}
+}
+
+// Extract returns the first capture group of the pattern in the branch name,
+// or the whole match when the pattern has no groups, as written in the branch
+func Extract(pattern, branch string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	matches := re.FindStringSubmatch(branch)
+	switch {
+	case matches == nil:
+		return "", nil
+	case len(matches) > 1:
+		return matches[1], nil
+	default:
+		return matches[0], nil
+	}
+}
+
+// Prefix returns the ticket when it is placed before the commit message
+func (t Ticket) Prefix() string {
+	if t.Placement != config.TicketPlacementPrefix {
+		return ""
+	}
+	return t.ID
+}
+
+// Apply places the ticket into the scope or the footer of the commit message.
+// A message that already has another scope keeps it and refers to the ticket
+// in the footer instead
+func (t Ticket) Apply(message string) string {
+	if t.ID == "" || message == "" {
+		return message
+	}
+
+	switch t.Placement {
+	case config.TicketPlacementScope:
+		parsed := gpt.ParseCommitMessage(message)
+		switch {
+		case parsed.Type == "" || parsed.Scope == t.ID:
+			return message
+		case parsed.Scope != "":
+			return t.footer(message)
+		}
+		parsed.Scope = t.ID
+		return parsed.String()
+
+	case config.TicketPlacementFooter:
+		return t.footer(message)
+	}
+
+	return message
+}
+
+// footer appends the ticket trailer unless the message already has it
+func (t Ticket) footer(message string) string {
+	trailer := fmt.Sprintf("%s: %s", FooterKey, t.ID)
+	if strings.Contains(message, trailer) {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + trailer
+}
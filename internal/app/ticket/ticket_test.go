@@ -0,0 +1,212 @@
+package ticket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/config"
+	"cmt/internal/config/logger"
+)
+
+func Test_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nopLogger := zerolog.Nop()
+
+	tests := []struct {
+		name      string
+		pattern   string
+		placement string
+		before    func(*git.MockClient)
+		expected  Ticket
+	}{
+		{
+			name:      "Success with ticket in branch",
+			pattern:   `(?i)([A-Z]+-\d+)`,
+			placement: config.TicketPlacementFooter,
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().CurrentBranch(gomock.Any()).Return("feature/proj-42-login", nil)
+			},
+			expected: Ticket{ID: "proj-42", Placement: config.TicketPlacementFooter},
+		},
+		{
+			name:      "Success without ticket in branch",
+			pattern:   `(?i)([A-Z]+-\d+)`,
+			placement: config.TicketPlacementPrefix,
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+			},
+			expected: Ticket{Placement: config.TicketPlacementPrefix},
+		},
+		{
+			name:      "Success with detached HEAD",
+			pattern:   `(?i)([A-Z]+-\d+)`,
+			placement: config.TicketPlacementPrefix,
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().CurrentBranch(gomock.Any()).Return("", errors.ErrDetachedHead)
+			},
+			expected: Ticket{},
+		},
+		{
+			name:      "Success without pattern",
+			pattern:   "",
+			placement: config.TicketPlacementPrefix,
+			before:    func(*git.MockClient) {},
+			expected:  Ticket{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGit := git.NewMockClient(ctrl)
+			mockLogger := logger.NewMockLogger(ctrl)
+			mockLogger.EXPECT().Debug().Return(nopLogger.Debug()).AnyTimes()
+
+			tt.before(mockGit)
+
+			cfg := config.DefaultConfig()
+			cfg.Ticket.Pattern = tt.pattern
+			cfg.Ticket.Placement = tt.placement
+
+			result := Resolve(context.Background(), cfg, mockGit, mockLogger)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Extract(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		branch   string
+		expected string
+		error    bool
+	}{
+		{
+			name:     "Success with capture group",
+			pattern:  `(?i)([A-Z]+-\d+)`,
+			branch:   "feature/JIRA-123-add-login",
+			expected: "JIRA-123",
+		},
+		{
+			name:     "Success with lowercase branch",
+			pattern:  `(?i)([A-Z]+-\d+)`,
+			branch:   "bugfix/abc-7",
+			expected: "abc-7",
+		},
+		{
+			name:     "Success without capture group",
+			pattern:  `#\d+`,
+			branch:   "fix/#42-crash",
+			expected: "#42",
+		},
+		{
+			name:     "Success without match",
+			pattern:  `(?i)([A-Z]+-\d+)`,
+			branch:   "main",
+			expected: "",
+		},
+		{
+			name:    "Failure with invalid pattern",
+			pattern: `([A-Z`,
+			branch:  "main",
+			error:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Extract(tt.pattern, tt.branch)
+
+			if tt.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_Prefix(t *testing.T) {
+	assert.Equal(t, "JIRA-1", Ticket{ID: "JIRA-1", Placement: config.TicketPlacementPrefix}.Prefix())
+	assert.Empty(t, Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope}.Prefix())
+	assert.Empty(t, Ticket{}.Prefix())
+}
+
+func Test_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		ticket   Ticket
+		message  string
+		expected string
+	}{
+		{
+			name:     "Success with scope placement",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope},
+			message:  "feat: Add login\n\nDetails",
+			expected: "feat(JIRA-1): Add login\n\nDetails",
+		},
+		{
+			name:     "Success with scope placement on breaking change",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope},
+			message:  "feat!: Drop v1 login",
+			expected: "feat(JIRA-1)!: Drop v1 login",
+		},
+		{
+			name:     "Success with scope placement on scoped message",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope},
+			message:  "feat(auth)!: Add login\n\nDetails",
+			expected: "feat(auth)!: Add login\n\nDetails\n\nRefs: JIRA-1",
+		},
+		{
+			name:     "Success with scope placement already applied",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope},
+			message:  "feat(JIRA-1): Add login",
+			expected: "feat(JIRA-1): Add login",
+		},
+		{
+			name:     "Success with scope placement on free-form message",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementScope},
+			message:  "Add login",
+			expected: "Add login",
+		},
+		{
+			name:     "Success with footer placement",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementFooter},
+			message:  "feat(auth): Add login\n\nDetails",
+			expected: "feat(auth): Add login\n\nDetails\n\nRefs: JIRA-1",
+		},
+		{
+			name:     "Success with existing footer",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementFooter},
+			message:  "feat: Add login\n\nRefs: JIRA-1",
+			expected: "feat: Add login\n\nRefs: JIRA-1",
+		},
+		{
+			name:     "Success with prefix placement",
+			ticket:   Ticket{ID: "JIRA-1", Placement: config.TicketPlacementPrefix},
+			message:  "feat: Add login",
+			expected: "feat: Add login",
+		},
+		{
+			name:     "Success without ticket",
+			ticket:   Ticket{Placement: config.TicketPlacementFooter},
+			message:  "feat: Add login",
+			expected: "feat: Add login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.ticket.Apply(tt.message))
+		})
+	}
+}
@@ -2,9 +2,10 @@ package commit
 
 // FetchSuccessMsg indicates successful initial data fetch
 type FetchSuccessMsg struct {
-	Status  string
-	Diff    string
-	Message string
+	Status         string
+	UnstagedStatus string
+	Diff           string
+	Message        string
 }
 
 // FetchErrorMsg indicates initial data fetch failure
@@ -17,3 +18,30 @@ type RegenerateMsg struct {
 	Message string
 	Err     error
 }
+
+// EditorFinishedMsg indicates the external editor has exited
+type EditorFinishedMsg struct {
+	Path string
+	Err  error
+}
+
+// ScopesMsg carries scope suggestions collected from the git history
+type ScopesMsg struct {
+	Scopes []string
+}
+
+// FileDiffMsg carries the staged and unstaged diff of a single file
+type FileDiffMsg struct {
+	Path     string
+	Staged   string
+	Unstaged string
+	Err      error
+}
+
+// HunkAppliedMsg indicates a hunk was staged or unstaged and carries the refreshed data
+type HunkAppliedMsg struct {
+	FileDiffMsg
+	Status         string
+	UnstagedStatus string
+	Diff           string
+}
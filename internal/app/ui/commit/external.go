@@ -0,0 +1,48 @@
+package commit
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"cmt/internal/app/editor"
+)
+
+// openEditor suspends the TUI and opens the commit message in the external editor
+func (m Model) openEditor() tea.Cmd {
+	path, err := editor.WriteTempFile(m.fullMessage())
+	if err != nil {
+		return func() tea.Msg {
+			return EditorFinishedMsg{Err: err}
+		}
+	}
+
+	return tea.ExecProcess(editor.Command(m.editor, path), func(err error) tea.Msg {
+		return EditorFinishedMsg{Path: path, Err: err}
+	})
+}
+
+// applyEditorResult loads the message written by the external editor
+func (m Model) applyEditorResult(msg EditorFinishedMsg) Model {
+	if msg.Err != nil {
+		if msg.Path != "" {
+			_ = os.Remove(msg.Path)
+		}
+		return m
+	}
+
+	text, err := editor.ReadTempFile(msg.Path)
+	if err != nil || text == "" {
+		return m
+	}
+
+	prefix, message := m.parsePrefix(text)
+	m.state.Prefix = prefix
+	m.state.CommitMessage = message
+
+	if m.stateMachine.ViewPane() == MessagePane {
+		m.viewport.SetContent(m.getDisplayMessage())
+	}
+
+	return m
+}
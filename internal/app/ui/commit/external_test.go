@@ -0,0 +1,87 @@
+package commit
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/editor"
+)
+
+func Test_HandleNormalMode_Edit_WithExternalEditor(t *testing.T) {
+	m, _ := newFormModel(t, "feat: Add feature", "")
+	m.editor = "true"
+
+	updated, cmd := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	updatedModel := updated.(Model)
+
+	assert.Equal(t, Viewing, updatedModel.stateMachine.WorkflowMode())
+	assert.NotNil(t, cmd)
+}
+
+func Test_ApplyEditorResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefix         string
+		content        string
+		err            error
+		expectedPrefix string
+		expectedMsg    string
+	}{
+		{
+			name:           "Success with edited message",
+			content:        "fix: Edited message\n\n# comment\n",
+			expectedMsg:    "fix: Edited message",
+			expectedPrefix: "",
+		},
+		{
+			name:           "Success with prefix kept",
+			prefix:         "TASK-1",
+			content:        "TASK-1 fix: Edited message\n",
+			expectedPrefix: "TASK-1",
+			expectedMsg:    "fix: Edited message",
+		},
+		{
+			name:           "Success with prefix removed",
+			prefix:         "TASK-1",
+			content:        "fix: Edited message\n",
+			expectedPrefix: "",
+			expectedMsg:    "fix: Edited message",
+		},
+		{
+			name:           "Success with empty message keeps previous",
+			content:        "# only comments\n",
+			expectedPrefix: "",
+			expectedMsg:    "feat: Add feature",
+		},
+		{
+			name:           "Failure when editor exits with error",
+			content:        "fix: Ignored\n",
+			err:            errors.New("exit status 1"),
+			expectedPrefix: "",
+			expectedMsg:    "feat: Add feature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newFormModel(t, "feat: Add feature", tt.prefix)
+
+			path, err := editor.WriteTempFile("")
+			assert.NoError(t, err)
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			updated, _ := m.Update(EditorFinishedMsg{Path: path, Err: tt.err})
+			updatedModel := updated.(Model)
+
+			assert.Equal(t, tt.expectedPrefix, updatedModel.state.Prefix)
+			assert.Equal(t, tt.expectedMsg, updatedModel.state.CommitMessage)
+
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
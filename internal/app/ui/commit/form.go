@@ -0,0 +1,314 @@
+package commit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+const (
+	// HeaderLimit is the recommended maximum length of a commit header
+	HeaderLimit = 72
+	// scopeHistorySize is the number of commits scanned for scope suggestions
+	scopeHistorySize = 200
+)
+
+// FormField represents the focused field of the commit form
+type FormField int
+
+const (
+	// TypeField is the commit type selector
+	TypeField FormField = iota
+	// ScopeField is the commit scope input
+	ScopeField
+	// DescriptionField is the commit description input
+	DescriptionField
+	// BodyField is the commit body textarea
+	BodyField
+)
+
+// commitForm holds the inputs of the structured commit form
+type commitForm struct {
+	types       []string
+	typeIndex   int // -1 until a type is chosen
+	breaking    bool
+	scope       textinput.Model
+	description textinput.Model
+	body        textarea.Model
+	focus       FormField
+}
+
+// newCommitForm creates a commit form prefilled from the given message
+func newCommitForm(message string, width int) commitForm {
+	parsed := gpt.ParseCommitMessage(message)
+
+	types := gpt.CommitTypes()
+	typeIndex := -1
+	for i, t := range types {
+		if t == parsed.Type {
+			typeIndex = i
+			break
+		}
+	}
+
+	scope := textinput.New()
+	scope.Placeholder = "scope"
+	scope.ShowSuggestions = true
+	scope.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	scope.SetValue(parsed.Scope)
+
+	description := textinput.New()
+	description.Placeholder = "Describe the change in imperative mood"
+	description.SetValue(parsed.Description)
+
+	body := textarea.New()
+	body.Placeholder = "Explain what and why…"
+	body.CharLimit = 0
+	body.ShowLineNumbers = false
+	body.SetValue(parsed.Body)
+
+	f := commitForm{
+		types:       types,
+		typeIndex:   typeIndex,
+		breaking:    parsed.Breaking,
+		scope:       scope,
+		description: description,
+		body:        body,
+		focus:       TypeField,
+	}
+
+	return f.resize(width)
+}
+
+// resize adapts the form inputs to the available width
+func (f commitForm) resize(width int) commitForm {
+	inputWidth := max(width-20, 10)
+
+	f.scope.Width = inputWidth
+	f.description.Width = inputWidth
+	f.body.SetWidth(max(width-4, 10))
+	f.body.SetHeight(8)
+
+	return f
+}
+
+// message composes the commit message from the form fields
+func (f commitForm) message() gpt.CommitMessage {
+	commitType := ""
+	if f.typeIndex >= 0 {
+		commitType = f.types[f.typeIndex]
+	}
+
+	return gpt.CommitMessage{
+		Type:        commitType,
+		Scope:       strings.TrimSpace(f.scope.Value()),
+		Description: strings.TrimSpace(f.description.Value()),
+		Body:        strings.TrimSpace(f.body.Value()),
+		Breaking:    f.breaking,
+	}
+}
+
+// setFocus moves focus to the given field
+func (f commitForm) setFocus(field FormField) (commitForm, tea.Cmd) {
+	f.focus = field
+
+	f.scope.Blur()
+	f.description.Blur()
+	f.body.Blur()
+
+	switch field {
+	case ScopeField:
+		return f, f.scope.Focus()
+	case DescriptionField:
+		return f, f.description.Focus()
+	case BodyField:
+		return f, f.body.Focus()
+	}
+
+	return f, nil
+}
+
+// cycleType selects the next or previous commit type, starting from the
+// first or last one when no type is chosen yet
+func (f commitForm) cycleType(delta int) commitForm {
+	switch {
+	case f.typeIndex >= 0:
+		f.typeIndex = (f.typeIndex + delta + len(f.types)) % len(f.types)
+	case delta > 0:
+		f.typeIndex = 0
+	default:
+		f.typeIndex = len(f.types) - 1
+	}
+	return f
+}
+
+// enterComposing switches to the structured form editor
+func (m Model) enterComposing() (tea.Model, tea.Cmd) {
+	m.stateMachine.EnterComposing()
+	m.form = newCommitForm(m.state.CommitMessage, m.width)
+
+	return m, m.loadScopes()
+}
+
+// loadScopes creates a command to collect scope suggestions from the git history
+func (m Model) loadScopes() tea.Cmd {
+	return func() tea.Msg {
+		log, err := m.gitClient.Log(m.ctx, []string{"-n", fmt.Sprintf("%d", scopeHistorySize)})
+		if err != nil {
+			return ScopesMsg{}
+		}
+
+		return ScopesMsg{Scopes: scopesFromLog(log)}
+	}
+}
+
+// scopesFromLog extracts commit scopes from the history ordered by frequency
+func scopesFromLog(commits []git.Commit) []string {
+	counts := make(map[string]int)
+
+	for _, c := range commits {
+		scope := c.Conventional().Scope
+		if scope != "" {
+			counts[scope]++
+		}
+	}
+
+	scopes := make([]string, 0, len(counts))
+	for scope := range counts {
+		scopes = append(scopes, scope)
+	}
+
+	sort.Slice(scopes, func(i, j int) bool {
+		if counts[scopes[i]] != counts[scopes[j]] {
+			return counts[scopes[i]] > counts[scopes[j]]
+		}
+		return scopes[i] < scopes[j]
+	})
+
+	return scopes
+}
+
+// handleFormMode processes keys in the structured form editor
+func (m Model) handleFormMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		if composed := m.form.message(); composed.Description != "" {
+			m.state.CommitMessage = composed.String()
+		}
+
+		m.stateMachine.EnterViewing(m.stateMachine.ViewPane())
+		if m.stateMachine.ViewPane() == MessagePane {
+			m.viewport.SetContent(m.getDisplayMessage())
+		}
+		return m, nil
+
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+
+	case tea.KeyTab:
+		m.form, cmd = m.form.setFocus((m.form.focus + 1) % (BodyField + 1))
+		return m, cmd
+
+	case tea.KeyShiftTab:
+		m.form, cmd = m.form.setFocus((m.form.focus + BodyField) % (BodyField + 1))
+		return m, cmd
+	}
+
+	switch m.form.focus {
+	case TypeField:
+		switch msg.String() {
+		case "left", "h", "up", "k":
+			m.form = m.form.cycleType(-1)
+		case "right", "l", "down", "j", " ":
+			m.form = m.form.cycleType(1)
+		case "!":
+			m.form.breaking = !m.form.breaking
+		case "enter":
+			m.form, cmd = m.form.setFocus(ScopeField)
+		}
+	case ScopeField:
+		if msg.Type == tea.KeyEnter {
+			m.form, cmd = m.form.setFocus(DescriptionField)
+			return m, cmd
+		}
+		m.form.scope, cmd = m.form.scope.Update(msg)
+	case DescriptionField:
+		if msg.Type == tea.KeyEnter {
+			m.form, cmd = m.form.setFocus(BodyField)
+			return m, cmd
+		}
+		m.form.description, cmd = m.form.description.Update(msg)
+	case BodyField:
+		m.form.body, cmd = m.form.body.Update(msg)
+	}
+
+	return m, cmd
+}
+
+// renderFormMode renders the structured form editor
+func (m Model) renderFormMode() string {
+	var sections []string
+
+	sections = append(sections, titleStyle.Render(">_ compose"))
+	sections = append(sections, "")
+
+	label := func(field FormField, text string) string {
+		style := formLabelStyle
+		if m.form.focus == field {
+			style = style.Foreground(ColorPrimary)
+		}
+		return style.Render(text)
+	}
+
+	var types []string
+	for i, t := range m.form.types {
+		if i == m.form.typeIndex {
+			types = append(types, selectedStyle.Render("["+t+"]"))
+		} else {
+			types = append(types, lipgloss.NewStyle().Foreground(ColorMuted).Render(t))
+		}
+	}
+	if m.form.breaking {
+		types = append(types, lipgloss.NewStyle().Foreground(ColorDeleted).Render("! breaking"))
+	}
+
+	header := m.form.message().Header()
+	if m.state.Prefix != "" {
+		header = m.state.Prefix + " " + header
+	}
+
+	length := utf8.RuneCountInString(header)
+	counterStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if length > HeaderLimit {
+		counterStyle = counterStyle.Foreground(ColorDeleted)
+	}
+	counter := counterStyle.Render(fmt.Sprintf("%d/%d", length, HeaderLimit))
+
+	rows := []string{
+		label(TypeField, "type") + strings.Join(types, " "),
+		label(ScopeField, "scope") + m.form.scope.View(),
+		label(DescriptionField, "description") + m.form.description.View() + " " + counter,
+		label(BodyField, "body"),
+		m.form.body.View(),
+		"",
+		lipgloss.NewStyle().Foreground(ColorMuted).Render(header),
+	}
+
+	sections = append(sections, formStyle.Render(strings.Join(rows, "\n")))
+	sections = append(sections, "")
+	sections = append(sections, helpStyle.Render("tab/shift+tab switch field • ←/→ change type • ! breaking change • → accept scope suggestion • esc save • ctrl+c quit"))
+
+	return strings.Join(sections, "\n")
+}
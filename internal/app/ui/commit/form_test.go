@@ -0,0 +1,296 @@
+package commit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config/logger"
+)
+
+// newFormModel creates a model with the given commit message and prefix
+func newFormModel(t *testing.T, message, prefix string) (Model, *git.MockClient) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mockGit := git.NewMockClient(ctrl)
+	mockGPT := gpt.NewMockClient(ctrl)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+
+	m := NewModel(Input{
+		CommitMessage: message,
+		Prefix:        prefix,
+		GitClient:     mockGit,
+		GPTClient:     mockGPT,
+		Logger:        mockLogger,
+		Ctx:           context.Background(),
+		Spinner:       func() spinner.Model { return mockSpinner },
+	})
+	m.width = 120
+	m.height = 40
+	m.ready = true
+
+	return m, mockGit
+}
+
+func Test_NewCommitForm(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected gpt.CommitMessage
+	}{
+		{
+			name:     "Success with conventional message",
+			message:  "fix(api): Handle timeouts\n\nRetry on 504.",
+			expected: gpt.CommitMessage{Type: "fix", Scope: "api", Description: "Handle timeouts", Body: "Retry on 504."},
+		},
+		{
+			name:     "Success with breaking marker",
+			message:  "refactor(api)!: Drop v1",
+			expected: gpt.CommitMessage{Type: "refactor", Scope: "api", Description: "Drop v1", Breaking: true},
+		},
+		{
+			name:     "Success with unknown type",
+			message:  "update: Something",
+			expected: gpt.CommitMessage{Type: "", Scope: "", Description: "Something"},
+		},
+		{
+			name:     "Success with free-form message",
+			message:  "Update stuff",
+			expected: gpt.CommitMessage{Description: "Update stuff"},
+		},
+		{
+			name:     "Success with empty message",
+			message:  "",
+			expected: gpt.CommitMessage{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := newCommitForm(tt.message, 80)
+
+			assert.Equal(t, tt.expected, form.message())
+			assert.Equal(t, TypeField, form.focus)
+		})
+	}
+}
+
+func Test_ScopesFromLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []git.Commit
+		expected []string
+	}{
+		{
+			name: "Success ordered by frequency",
+			commits: []git.Commit{
+				{Subject: "feat(api): Add endpoint"},
+				{Subject: "fix(ui): Fix button"},
+				{Subject: "fix(api): Handle error"},
+				{Subject: "docs: Update readme"},
+				{Subject: "chore(build): Bump"},
+			},
+			expected: []string{"api", "build", "ui"},
+		},
+		{
+			name:     "Success with free-form subjects",
+			commits:  []git.Commit{{Subject: "garbage"}, {Subject: ""}},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scopesFromLog(tt.commits))
+		})
+	}
+}
+
+func Test_HandleNormalMode_Compose(t *testing.T) {
+	m, mockGit := newFormModel(t, "feat(api): Add endpoint", "")
+
+	mockGit.EXPECT().
+		Log(gomock.Any(), []string{"-n", "200"}).
+		Return([]git.Commit{{Subject: "fix(db): Fix query"}}, nil)
+
+	updated, cmd := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	updatedModel := updated.(Model)
+
+	assert.Equal(t, Composing, updatedModel.stateMachine.WorkflowMode())
+	assert.Equal(t, "api", updatedModel.form.scope.Value())
+	assert.NotNil(t, cmd)
+
+	msg := cmd()
+	assert.Equal(t, ScopesMsg{Scopes: []string{"db"}}, msg)
+
+	updated, _ = updatedModel.Update(msg)
+	updatedModel = updated.(Model)
+	assert.Equal(t, []string{"db"}, updatedModel.form.scope.AvailableSuggestions())
+}
+
+func Test_LoadScopes_WithError(t *testing.T) {
+	m, mockGit := newFormModel(t, "", "")
+
+	mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(nil, errors.ErrNoGitCommits)
+
+	assert.Equal(t, ScopesMsg{}, m.loadScopes()())
+}
+
+func Test_HandleFormMode(t *testing.T) {
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	tests := []struct {
+		name     string
+		message  string
+		steps    []tea.KeyMsg
+		expected string
+	}{
+		{
+			name:    "Success when changing type",
+			message: "feat(api): Add endpoint",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyRight},
+				{Type: tea.KeyEsc},
+			},
+			expected: "fix(api): Add endpoint",
+		},
+		{
+			name:    "Success when cycling type backwards",
+			message: "feat: Add endpoint",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyLeft},
+				{Type: tea.KeyEsc},
+			},
+			expected: "revert: Add endpoint",
+		},
+		{
+			name:    "Success when choosing a type for a free-form message",
+			message: "Update endpoint",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyLeft},
+				{Type: tea.KeyEsc},
+			},
+			expected: "revert: Update endpoint",
+		},
+		{
+			name:    "Success when keeping a free-form message",
+			message: "Update endpoint\n\nDetails",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyEsc},
+			},
+			expected: "Update endpoint\n\nDetails",
+		},
+		{
+			name:    "Success when keeping the breaking marker",
+			message: "feat(api)!: Drop v1",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyRight},
+				{Type: tea.KeyEsc},
+			},
+			expected: "fix(api)!: Drop v1",
+		},
+		{
+			name:    "Success when toggling the breaking marker",
+			message: "feat(api): Drop v1",
+			steps: []tea.KeyMsg{
+				runes("!"),
+				{Type: tea.KeyEsc},
+			},
+			expected: "feat(api)!: Drop v1",
+		},
+		{
+			name:    "Success when editing scope and description",
+			message: "feat: Add",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				runes("auth"),
+				{Type: tea.KeyEnter},
+				runes(" login"),
+				{Type: tea.KeyEsc},
+			},
+			expected: "feat(auth): Add login",
+		},
+		{
+			name:    "Success when editing body",
+			message: "docs: Update readme",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyShiftTab},
+				runes("More details"),
+				{Type: tea.KeyEsc},
+			},
+			expected: "docs: Update readme\n\nMore details",
+		},
+		{
+			name:    "Success when description is cleared",
+			message: "docs: Update",
+			steps: []tea.KeyMsg{
+				{Type: tea.KeyTab},
+				{Type: tea.KeyTab},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyBackspace},
+				{Type: tea.KeyEsc},
+			},
+			expected: "docs: Update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mockGit := newFormModel(t, tt.message, "")
+			mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+			updated, _ := m.enterComposing()
+			m = updated.(Model)
+
+			for _, key := range tt.steps {
+				updated, _ = m.Update(key)
+				m = updated.(Model)
+			}
+
+			assert.Equal(t, Viewing, m.stateMachine.WorkflowMode())
+			assert.Equal(t, tt.expected, m.state.CommitMessage)
+		})
+	}
+}
+
+func Test_HandleFormMode_Quit(t *testing.T) {
+	m, mockGit := newFormModel(t, "feat: Add", "")
+	mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	updated, _ := m.enterComposing()
+	_, cmd := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	assert.NotNil(t, cmd)
+}
+
+func Test_RenderFormMode(t *testing.T) {
+	m, mockGit := newFormModel(t, "feat(api): "+strings.Repeat("x", 70), "TASK-1")
+	mockGit.EXPECT().Log(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	updated, _ := m.enterComposing()
+	view := updated.(Model).View()
+
+	assert.Contains(t, view, ">_ compose")
+	assert.Contains(t, view, "[feat]")
+	assert.Contains(t, view, "88/72")
+	assert.Contains(t, view, "TASK-1 feat(api): ")
+}
@@ -0,0 +1,285 @@
+package commit
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+)
+
+// flattenFiles returns the paths of all files in the tree in display order
+func flattenFiles(nodes []FileNode) []string {
+	var paths []string
+
+	for _, node := range nodes {
+		if node.IsDir {
+			paths = append(paths, flattenFiles(node.Children)...)
+			continue
+		}
+		paths = append(paths, node.Path)
+	}
+
+	return paths
+}
+
+// selectedFile returns the path of the file under the tree cursor
+func (m Model) selectedFile() string {
+	paths := flattenFiles(m.state.Files)
+	if len(paths) == 0 {
+		return ""
+	}
+
+	return paths[clamp(m.treeCursor, 0, len(paths)-1)]
+}
+
+// moveTreeCursor moves the tree cursor by delta files and keeps it visible
+func (m Model) moveTreeCursor(delta int) Model {
+	paths := flattenFiles(m.state.Files)
+	if len(paths) == 0 {
+		return m
+	}
+
+	m.treeCursor = clamp(m.treeCursor+delta, 0, len(paths)-1)
+	m.treeViewport.SetContent(m.renderFileTree())
+
+	line := m.selectedTreeLine()
+	switch {
+	case line < m.treeViewport.YOffset:
+		m.treeViewport.SetYOffset(line)
+	case line >= m.treeViewport.YOffset+m.treeViewport.Height:
+		m.treeViewport.SetYOffset(line - m.treeViewport.Height + 1)
+	}
+
+	return m
+}
+
+// selectedTreeLine returns the rendered line index of the selected file
+func (m Model) selectedTreeLine() int {
+	selected := m.selectedFile()
+	line := 0
+
+	var walk func(nodes []FileNode) bool
+	walk = func(nodes []FileNode) bool {
+		for _, node := range nodes {
+			if !node.IsDir && node.Path == selected {
+				return true
+			}
+			line++
+			if node.IsDir && walk(node.Children) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(m.state.Files)
+
+	return line
+}
+
+// handleDiffMode processes keys while the diff pane is visible
+func (m Model) handleDiffMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.NextHunk):
+		return m.moveHunkCursor(1), nil
+
+	case key.Matches(msg, m.keys.PrevHunk):
+		return m.moveHunkCursor(-1), nil
+
+	case key.Matches(msg, m.keys.Stage):
+		if _, staged := m.state.Hunks.Patch(m.hunkCursor); m.state.Hunks.Len() > 0 && !staged {
+			return m, m.applyHunk()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Unstage):
+		if _, staged := m.state.Hunks.Patch(m.hunkCursor); m.state.Hunks.Len() > 0 && staged {
+			return m, m.applyHunk()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Back):
+		m.stateMachine.EnterViewing(MessagePane)
+		treeWidth := m.width / 3
+		messageWidth := m.width - treeWidth - 4
+		m.viewport.Width = messageWidth - 4
+		m.viewport.SetContent(m.getDisplayMessage())
+		m.viewport.GotoTop()
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "j", "down", "k", "up", "pgdown", "pgup", "home", "end", "g", "G":
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+
+	return m.handleNormalMode(msg)
+}
+
+// moveHunkCursor moves the hunk cursor by delta hunks and scrolls to it
+func (m Model) moveHunkCursor(delta int) Model {
+	total := m.state.Hunks.Len()
+	if total == 0 {
+		return m
+	}
+
+	m.hunkCursor = clamp(m.hunkCursor+delta, 0, total-1)
+
+	content, offsets := m.renderHunks()
+	m.viewport.SetContent(content)
+	m.viewport.SetYOffset(offsets[m.hunkCursor])
+
+	return m
+}
+
+// loadFileDiff creates a command to load the staged and unstaged hunks of a file
+func (m Model) loadFileDiff(path string) tea.Cmd {
+	return func() tea.Msg {
+		return m.fetchFileDiff(path)
+	}
+}
+
+// fetchFileDiff loads the staged and unstaged diff of a file
+func (m Model) fetchFileDiff(path string) FileDiffMsg {
+	staged, err := m.gitClient.FileDiff(m.ctx, path, true)
+	if err != nil {
+		return FileDiffMsg{Path: path, Err: err}
+	}
+
+	unstaged, err := m.gitClient.FileDiff(m.ctx, path, false)
+	if err != nil {
+		return FileDiffMsg{Path: path, Err: err}
+	}
+
+	return FileDiffMsg{Path: path, Staged: staged, Unstaged: unstaged}
+}
+
+// applyHunk creates a command to stage or unstage the selected hunk and refresh the data
+func (m Model) applyHunk() tea.Cmd {
+	patch, staged := m.state.Hunks.Patch(m.hunkCursor)
+	path := m.state.Hunks.Path
+
+	return func() tea.Msg {
+		if err := m.gitClient.ApplyPatch(m.ctx, patch, staged); err != nil {
+			return HunkAppliedMsg{FileDiffMsg: FileDiffMsg{Path: path, Err: err}}
+		}
+
+		fileDiff := m.fetchFileDiff(path)
+		if fileDiff.Err != nil {
+			return HunkAppliedMsg{FileDiffMsg: fileDiff}
+		}
+
+		status, err := m.gitClient.Status(m.ctx)
+		if err != nil && !errors.Is(err, errors.ErrNoGitChanges) {
+			return HunkAppliedMsg{FileDiffMsg: FileDiffMsg{Path: path, Err: err}}
+		}
+
+		unstaged, err := m.gitClient.UnstagedStatus(m.ctx)
+		if err != nil {
+			return HunkAppliedMsg{FileDiffMsg: FileDiffMsg{Path: path, Err: err}}
+		}
+
+		diff, err := m.gitClient.Diff(m.ctx)
+		if err != nil && !errors.Is(err, errors.ErrNoGitChanges) {
+			return HunkAppliedMsg{FileDiffMsg: FileDiffMsg{Path: path, Err: err}}
+		}
+
+		return HunkAppliedMsg{
+			FileDiffMsg:    fileDiff,
+			Status:         status,
+			UnstagedStatus: unstaged,
+			Diff:           diff,
+		}
+	}
+}
+
+// setFileHunks replaces the hunks shown in the diff pane
+func (m Model) setFileHunks(msg FileDiffMsg) Model {
+	m.state.Hunks = FileHunks{
+		Path:     msg.Path,
+		Staged:   git.ParseFileDiff(msg.Staged),
+		Unstaged: git.ParseFileDiff(msg.Unstaged),
+	}
+	m.hunkCursor = clamp(m.hunkCursor, 0, max(m.state.Hunks.Len()-1, 0))
+
+	content, offsets := m.renderHunks()
+	m.viewport.SetContent(content)
+	if len(offsets) > 0 {
+		m.viewport.SetYOffset(offsets[m.hunkCursor])
+	}
+
+	return m
+}
+
+// renderHunks renders the staged and unstaged hunks and returns the line offset of each hunk
+func (m Model) renderHunks() (string, []int) {
+	var lines []string
+	var offsets []int
+
+	sections := []struct {
+		title string
+		diff  git.FileDiff
+	}{
+		{title: "staged", diff: m.state.Hunks.Staged},
+		{title: "unstaged", diff: m.state.Hunks.Unstaged},
+	}
+
+	index := 0
+	for _, section := range sections {
+		lines = append(lines, sectionStyle.Render(section.title))
+
+		if len(section.diff.Hunks) == 0 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorMuted).Render("  no changes"))
+		}
+
+		for _, hunk := range section.diff.Hunks {
+			offsets = append(offsets, len(lines))
+
+			marker := "  "
+			headerStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+			if index == m.hunkCursor {
+				marker = "▶ "
+				headerStyle = lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+			}
+			lines = append(lines, marker+headerStyle.Render(hunk.Header))
+
+			for _, line := range hunk.Lines {
+				lines = append(lines, "  "+renderDiffLine(line))
+			}
+
+			index++
+		}
+
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n"), offsets
+}
+
+// renderDiffLine colors a single diff line according to its change type
+func renderDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+"):
+		return lipgloss.NewStyle().Foreground(ColorAdded).Render(line)
+	case strings.HasPrefix(line, "-"):
+		return lipgloss.NewStyle().Foreground(ColorDeleted).Render(line)
+	default:
+		return line
+	}
+}
+
+// clamp limits value to the range between low and high
+func clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
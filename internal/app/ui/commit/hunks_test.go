@@ -0,0 +1,411 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/config/logger"
+)
+
+const (
+	testStagedDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,3 @@
+ package main
++// staged
+ func main() {}`
+
+	testUnstagedDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,3 @@
++// first
+ package main
+ func main() {}
+@@ -20,2 +21,3 @@
+ func helper() {}
++// second
+ var x = 1`
+)
+
+// newHunksModel creates a model showing the diff pane for main.go
+func newHunksModel(t *testing.T) (Model, *git.MockClient) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	mockGit := git.NewMockClient(ctrl)
+	mockGPT := gpt.NewMockClient(ctrl)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+
+	input := Input{
+		Files:         "M\tmain.go\nA\tpkg/util.go",
+		CommitMessage: "feat: Add feature",
+		GitClient:     mockGit,
+		GPTClient:     mockGPT,
+		Logger:        mockLogger,
+		Ctx:           context.Background(),
+		Spinner:       func() spinner.Model { return mockSpinner },
+	}
+
+	m := NewModel(input)
+	m.width = 120
+	m.height = 40
+	m.ready = true
+
+	updated, _ := m.Update(FileDiffMsg{
+		Path:     "main.go",
+		Staged:   testStagedDiff,
+		Unstaged: testUnstagedDiff,
+	})
+
+	return updated.(Model), mockGit
+}
+
+func Test_FlattenFiles(t *testing.T) {
+	files := BuildFileTree("M\tmain.go\nA\tpkg/util.go\nA\tpkg/a/b.go")
+
+	assert.Equal(t, []string{"pkg/a/b.go", "pkg/util.go", "main.go"}, flattenFiles(files))
+	assert.Empty(t, flattenFiles(nil))
+}
+
+func Test_FileHunks(t *testing.T) {
+	hunks := FileHunks{
+		Path:     "main.go",
+		Staged:   git.ParseFileDiff(testStagedDiff),
+		Unstaged: git.ParseFileDiff(testUnstagedDiff),
+	}
+
+	assert.Equal(t, 3, hunks.Len())
+
+	patch, staged := hunks.Patch(0)
+	assert.True(t, staged)
+	assert.Contains(t, patch, "+// staged")
+
+	patch, staged = hunks.Patch(2)
+	assert.False(t, staged)
+	assert.Contains(t, patch, "+// second")
+	assert.NotContains(t, patch, "+// first")
+}
+
+func Test_MoveTreeCursor(t *testing.T) {
+	m, _ := newHunksModel(t)
+	m.focusPane = TreeFocus
+
+	assert.Equal(t, "pkg/util.go", m.selectedFile())
+
+	updated, _ := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	assert.Equal(t, "main.go", m.selectedFile())
+
+	updated, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	assert.Equal(t, "main.go", m.selectedFile())
+
+	updated, _ = m.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = updated.(Model)
+	assert.Equal(t, "pkg/util.go", m.selectedFile())
+}
+
+func Test_HandleNormalMode_OpenDiff(t *testing.T) {
+	m, mockGit := newHunksModel(t)
+	m.stateMachine.EnterViewing(MessagePane)
+
+	mockGit.EXPECT().FileDiff(gomock.Any(), "pkg/util.go", true).Return("staged", nil)
+	mockGit.EXPECT().FileDiff(gomock.Any(), "pkg/util.go", false).Return("", nil)
+
+	_, cmd := m.handleNormalMode(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+
+	msg, ok := cmd().(FileDiffMsg)
+	assert.True(t, ok)
+	assert.Equal(t, "pkg/util.go", msg.Path)
+	assert.Equal(t, "staged", msg.Staged)
+	assert.NoError(t, msg.Err)
+}
+
+func Test_Update_FileDiffMsg(t *testing.T) {
+	m, _ := newHunksModel(t)
+
+	assert.Equal(t, DiffPane, m.stateMachine.ViewPane())
+	assert.Equal(t, "main.go", m.state.Hunks.Path)
+	assert.Equal(t, 3, m.state.Hunks.Len())
+	assert.Equal(t, 0, m.hunkCursor)
+
+	t.Run("Failure keeps current pane and shows the error", func(t *testing.T) {
+		m.stateMachine.EnterViewing(MessagePane)
+
+		updated, _ := m.Update(FileDiffMsg{Path: "main.go", Err: errors.ErrFailedToLoadGitDiff})
+		updatedModel := updated.(Model)
+		assert.Equal(t, MessagePane, updatedModel.stateMachine.ViewPane())
+		assert.Equal(t, "Failed to load diff of main.go: failed to load git diff", updatedModel.state.Notice)
+		assert.Contains(t, updatedModel.View(), "Failed to load diff of main.go")
+	})
+}
+
+func Test_HandleDiffMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		cursor       int
+		key          tea.KeyMsg
+		before       func(*git.MockClient)
+		expectCmd    bool
+		expectCursor int
+		expectPane   ViewPane
+	}{
+		{
+			name:         "Success when moving to next hunk",
+			cursor:       0,
+			key:          tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}},
+			before:       func(*git.MockClient) {},
+			expectCursor: 1,
+			expectPane:   DiffPane,
+		},
+		{
+			name:         "Success when moving past the last hunk",
+			cursor:       2,
+			key:          tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}},
+			before:       func(*git.MockClient) {},
+			expectCursor: 2,
+			expectPane:   DiffPane,
+		},
+		{
+			name:         "Success when moving to previous hunk",
+			cursor:       1,
+			key:          tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}},
+			before:       func(*git.MockClient) {},
+			expectCursor: 0,
+			expectPane:   DiffPane,
+		},
+		{
+			name:   "Success when staging an unstaged hunk",
+			cursor: 2,
+			key:    tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), false).Return(nil)
+			},
+			expectCmd:    true,
+			expectCursor: 2,
+			expectPane:   DiffPane,
+		},
+		{
+			name:         "Success when staging an already staged hunk",
+			cursor:       0,
+			key:          tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}},
+			before:       func(*git.MockClient) {},
+			expectCursor: 0,
+			expectPane:   DiffPane,
+		},
+		{
+			name:   "Success when unstaging a staged hunk",
+			cursor: 0,
+			key:    tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}},
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), true).Return(nil)
+			},
+			expectCmd:    true,
+			expectCursor: 0,
+			expectPane:   DiffPane,
+		},
+		{
+			name:         "Success when unstaging an unstaged hunk",
+			cursor:       1,
+			key:          tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}},
+			before:       func(*git.MockClient) {},
+			expectCursor: 1,
+			expectPane:   DiffPane,
+		},
+		{
+			name:         "Success when going back",
+			cursor:       1,
+			key:          tea.KeyMsg{Type: tea.KeyEsc},
+			before:       func(*git.MockClient) {},
+			expectCursor: 1,
+			expectPane:   MessagePane,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mockGit := newHunksModel(t)
+			m.hunkCursor = tt.cursor
+			tt.before(mockGit)
+
+			updated, cmd := m.Update(tt.key)
+			updatedModel := updated.(Model)
+
+			if tt.expectCmd {
+				assert.NotNil(t, cmd)
+
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", true).Return(testStagedDiff, nil)
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", false).Return(testUnstagedDiff, nil)
+				mockGit.EXPECT().Status(gomock.Any()).Return("M\tmain.go", nil)
+				mockGit.EXPECT().UnstagedStatus(gomock.Any()).Return("M\tmain.go", nil)
+				mockGit.EXPECT().Diff(gomock.Any()).Return("new diff", nil)
+
+				msg, ok := cmd().(HunkAppliedMsg)
+				assert.True(t, ok)
+				assert.NoError(t, msg.Err)
+				assert.Equal(t, "new diff", msg.Diff)
+			} else {
+				assert.Nil(t, cmd)
+			}
+
+			assert.Equal(t, tt.expectCursor, updatedModel.hunkCursor)
+			assert.Equal(t, tt.expectPane, updatedModel.stateMachine.ViewPane())
+		})
+	}
+}
+
+func Test_ApplyHunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   func(*git.MockClient)
+		expected HunkAppliedMsg
+	}{
+		{
+			name: "Success when nothing remains staged",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), true).Return(nil)
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", true).Return("", nil)
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", false).Return(testUnstagedDiff, nil)
+				mockGit.EXPECT().Status(gomock.Any()).Return("", errors.ErrNoGitChanges)
+				mockGit.EXPECT().UnstagedStatus(gomock.Any()).Return("M\tmain.go", nil)
+				mockGit.EXPECT().Diff(gomock.Any()).Return("", errors.ErrNoGitChanges)
+			},
+			expected: HunkAppliedMsg{
+				FileDiffMsg:    FileDiffMsg{Path: "main.go", Unstaged: testUnstagedDiff},
+				UnstagedStatus: "M\tmain.go",
+			},
+		},
+		{
+			name: "Failure when patch does not apply",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), true).Return(errors.ErrFailedToApplyPatch)
+			},
+			expected: HunkAppliedMsg{
+				FileDiffMsg: FileDiffMsg{Path: "main.go", Err: errors.ErrFailedToApplyPatch},
+			},
+		},
+		{
+			name: "Failure when the backend cannot apply patches",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), true).Return(errors.ErrUnsupportedByBackend)
+			},
+			expected: HunkAppliedMsg{
+				FileDiffMsg: FileDiffMsg{Path: "main.go", Err: errors.ErrUnsupportedByBackend},
+			},
+		},
+		{
+			name: "Failure when status fails",
+			before: func(mockGit *git.MockClient) {
+				mockGit.EXPECT().ApplyPatch(gomock.Any(), gomock.Any(), true).Return(nil)
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", true).Return("", nil)
+				mockGit.EXPECT().FileDiff(gomock.Any(), "main.go", false).Return(testUnstagedDiff, nil)
+				mockGit.EXPECT().Status(gomock.Any()).Return("", errors.ErrFailedToLoadGitDiff)
+			},
+			expected: HunkAppliedMsg{
+				FileDiffMsg: FileDiffMsg{Path: "main.go", Err: errors.ErrFailedToLoadGitDiff},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mockGit := newHunksModel(t)
+			tt.before(mockGit)
+
+			msg := m.applyHunk()()
+			assert.Equal(t, tt.expected, msg)
+		})
+	}
+}
+
+func Test_Update_HunkAppliedMsg(t *testing.T) {
+	m, _ := newHunksModel(t)
+	m.hunkCursor = 2
+
+	updated, _ := m.Update(HunkAppliedMsg{
+		FileDiffMsg: FileDiffMsg{Path: "main.go", Staged: testStagedDiff},
+		Status:      "M\tmain.go",
+		Diff:        "refreshed diff",
+	})
+	updatedModel := updated.(Model)
+
+	assert.Equal(t, "refreshed diff", updatedModel.state.Diff)
+	assert.Equal(t, []string{"main.go"}, flattenFiles(updatedModel.state.Files))
+	assert.Equal(t, 1, updatedModel.state.Hunks.Len())
+	assert.Equal(t, 0, updatedModel.hunkCursor)
+	assert.Equal(t, DiffPane, updatedModel.stateMachine.ViewPane())
+	assert.True(t, updatedModel.state.Stale)
+	assert.Contains(t, updatedModel.View(), "Staged changes changed, press r to regenerate the message")
+
+	t.Run("Success clears the stale flag once regenerated", func(t *testing.T) {
+		updated, _ := updatedModel.Update(RegenerateMsg{Message: "feat: Add feature and helper"})
+
+		assert.False(t, updated.(Model).state.Stale)
+		assert.NotContains(t, updated.(Model).View(), "Staged changes changed")
+	})
+
+	t.Run("Success lists files with only unstaged changes", func(t *testing.T) {
+		updated, _ := m.Update(HunkAppliedMsg{
+			FileDiffMsg:    FileDiffMsg{Path: "main.go", Unstaged: testUnstagedDiff},
+			UnstagedStatus: "M\tmain.go",
+		})
+		updatedModel := updated.(Model)
+
+		assert.Equal(t, []string{"main.go"}, flattenFiles(updatedModel.state.Files))
+		assert.True(t, updatedModel.state.Files[0].Unstaged)
+	})
+
+	t.Run("Failure shows the error", func(t *testing.T) {
+		m.state.Notice = ""
+
+		updated, _ := m.Update(HunkAppliedMsg{
+			FileDiffMsg: FileDiffMsg{Path: "main.go", Err: errors.ErrFailedToApplyPatch},
+		})
+		updatedModel := updated.(Model)
+
+		assert.Equal(t, "Failed to update main.go: failed to apply patch", updatedModel.state.Notice)
+		assert.Equal(t, 3, updatedModel.state.Hunks.Len())
+		assert.Contains(t, updatedModel.View(), "Failed to update main.go: failed to apply patch")
+	})
+
+	t.Run("Failure with the native backend", func(t *testing.T) {
+		m.state.Notice = ""
+
+		updated, _ := m.Update(HunkAppliedMsg{
+			FileDiffMsg: FileDiffMsg{Path: "main.go", Err: fmt.Errorf("%w: staging hunks", errors.ErrUnsupportedByBackend)},
+		})
+		updatedModel := updated.(Model)
+
+		assert.Equal(t, "Staging hunks is not supported by the native git backend, set git.backend to cli", updatedModel.state.Notice)
+		assert.Contains(t, updatedModel.View(), "Staging hunks is not supported by the native git backend")
+	})
+}
+
+func Test_RenderHunks(t *testing.T) {
+	m, _ := newHunksModel(t)
+	m.hunkCursor = 1
+
+	content, offsets := m.renderHunks()
+
+	assert.Contains(t, content, "staged")
+	assert.Contains(t, content, "unstaged")
+	assert.Contains(t, content, "▶ ")
+	assert.Contains(t, content, "+// second")
+	assert.Equal(t, []int{1, 7, 11}, offsets)
+}
@@ -6,9 +6,16 @@ import "github.com/charmbracelet/bubbles/key"
 type KeyMap struct {
 	Accept      key.Binding
 	Edit        key.Binding
+	Compose     key.Binding
 	Regenerate  key.Binding
 	ToggleLogs  key.Binding
 	ToggleFocus key.Binding
+	OpenDiff    key.Binding
+	NextHunk    key.Binding
+	PrevHunk    key.Binding
+	Stage       key.Binding
+	Unstage     key.Binding
+	Back        key.Binding
 	Quit        key.Binding
 }
 
@@ -23,6 +30,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit"),
 		),
+		Compose: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "compose"),
+		),
 		Regenerate: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "regenerate"),
@@ -35,6 +46,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch pane"),
 		),
+		OpenDiff: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view diff"),
+		),
+		NextHunk: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next hunk"),
+		),
+		PrevHunk: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "prev hunk"),
+		),
+		Stage: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "stage hunk"),
+		),
+		Unstage: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "unstage hunk"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -44,12 +79,18 @@ func DefaultKeyMap() KeyMap {
 
 // ShortHelp returns keybindings to be shown in the mini help view
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Accept, k.Edit, k.Regenerate, k.ToggleFocus, k.ToggleLogs, k.Quit}
+	return []key.Binding{k.Accept, k.Edit, k.Compose, k.Regenerate, k.ToggleFocus, k.OpenDiff, k.ToggleLogs, k.Quit}
 }
 
 // FullHelp returns keybindings for the expanded help view
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Accept, k.Edit, k.Regenerate, k.ToggleFocus, k.ToggleLogs, k.Quit},
+		{k.Accept, k.Edit, k.Compose, k.Regenerate, k.ToggleFocus, k.OpenDiff, k.ToggleLogs, k.Quit},
+		{k.NextHunk, k.PrevHunk, k.Stage, k.Unstage, k.Back},
 	}
 }
+
+// DiffHelp returns keybindings to be shown while the diff pane is visible
+func (k KeyMap) DiffHelp() []key.Binding {
+	return []key.Binding{k.NextHunk, k.PrevHunk, k.Stage, k.Unstage, k.Back, k.Accept, k.Quit}
+}
@@ -11,9 +11,16 @@ func Test_DefaultKeyMap(t *testing.T) {
 
 	assert.NotEmpty(t, km.Accept.Keys())
 	assert.NotEmpty(t, km.Edit.Keys())
+	assert.NotEmpty(t, km.Compose.Keys())
 	assert.NotEmpty(t, km.Regenerate.Keys())
 	assert.NotEmpty(t, km.ToggleLogs.Keys())
 	assert.NotEmpty(t, km.ToggleFocus.Keys())
+	assert.NotEmpty(t, km.OpenDiff.Keys())
+	assert.NotEmpty(t, km.NextHunk.Keys())
+	assert.NotEmpty(t, km.PrevHunk.Keys())
+	assert.NotEmpty(t, km.Stage.Keys())
+	assert.NotEmpty(t, km.Unstage.Keys())
+	assert.NotEmpty(t, km.Back.Keys())
 	assert.NotEmpty(t, km.Quit.Keys())
 }
 
@@ -21,13 +28,21 @@ func Test_ShortHelp(t *testing.T) {
 	km := DefaultKeyMap()
 	shortHelp := km.ShortHelp()
 
-	assert.Equal(t, 6, len(shortHelp))
+	assert.Equal(t, 8, len(shortHelp))
 }
 
 func Test_FullHelp(t *testing.T) {
 	km := DefaultKeyMap()
 	fullHelp := km.FullHelp()
 
-	assert.Equal(t, 1, len(fullHelp))
-	assert.Equal(t, 6, len(fullHelp[0]))
+	assert.Equal(t, 2, len(fullHelp))
+	assert.Equal(t, 8, len(fullHelp[0]))
+	assert.Equal(t, 5, len(fullHelp[1]))
+}
+
+func Test_DiffHelp(t *testing.T) {
+	km := DefaultKeyMap()
+	diffHelp := km.DiffHelp()
+
+	assert.Equal(t, 7, len(diffHelp))
 }
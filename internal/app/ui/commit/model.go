@@ -12,8 +12,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/ticket"
 	"cmt/internal/config/logger"
 )
 
@@ -36,15 +39,21 @@ type Model struct {
 	viewport     viewport.Model
 	treeViewport viewport.Model
 	textarea     textarea.Model
+	form         commitForm
 	spinner      spinner.Model
 	gitClient    git.Client
 	gptClient    gpt.Client
 	logger       logger.Logger
+	editor       string
+	ticket       ticket.Ticket
+	ignore       *ignore.Matcher
 	ctx          context.Context
 	width        int
 	height       int
 	ready        bool
 	focusPane    FocusPane
+	treeCursor   int
+	hunkCursor   int
 }
 
 // Input contains the initial data for the commit UI
@@ -56,6 +65,9 @@ type Input struct {
 	GitClient     git.Client
 	GPTClient     gpt.Client
 	Logger        logger.Logger
+	Editor        string
+	Ticket        ticket.Ticket
+	Ignore        *ignore.Matcher
 	Ctx           context.Context
 	Spinner       spinner.Factory
 }
@@ -82,7 +94,7 @@ func NewModel(input Input) Model {
 
 	s := input.Spinner()
 
-	files := BuildFileTree(input.Files)
+	files := MarkExcluded(BuildFileTree(input.Files), input.Ignore)
 
 	initialMode := Viewing
 	if input.CommitMessage == "" {
@@ -106,6 +118,9 @@ func NewModel(input Input) Model {
 		gitClient:    input.GitClient,
 		gptClient:    input.GPTClient,
 		logger:       input.Logger,
+		editor:       input.Editor,
+		ticket:       input.Ticket,
+		ignore:       input.Ignore,
 		ctx:          input.Ctx,
 		ready:        false,
 		focusPane:    MessageFocus,
@@ -120,28 +135,45 @@ func (m Model) Init() tea.Cmd {
 	return m.spinner.Tick
 }
 
-// fetchInitialData fetches git status, diff, and generates initial commit message
+// fetchInitialData fetches git status, diff, and generates initial commit message.
+// With nothing staged yet the unstaged files are still listed so their hunks
+// can be staged, and the message is generated later on request
 func (m Model) fetchInitialData() tea.Cmd {
 	return func() tea.Msg {
 		status, err := m.gitClient.Status(m.ctx)
-		if err != nil {
+		if err != nil && !errors.Is(err, errors.ErrNoGitChanges) {
 			return FetchErrorMsg{Err: err}
 		}
 
-		diff, err := m.gitClient.Diff(m.ctx)
+		unstaged, err := m.gitClient.UnstagedStatus(m.ctx)
 		if err != nil {
 			return FetchErrorMsg{Err: err}
 		}
 
-		message, err := m.gptClient.FetchCommitMessage(m.ctx, diff)
-		if err != nil {
-			return FetchErrorMsg{Err: err}
+		if status == "" && unstaged == "" {
+			return FetchErrorMsg{Err: errors.ErrNoGitChanges}
+		}
+
+		var diff, message string
+		if status != "" {
+			diff, err = m.gitClient.Diff(m.ctx)
+			if err != nil && !errors.Is(err, errors.ErrNoGitChanges) {
+				return FetchErrorMsg{Err: err}
+			}
+		}
+
+		if diff != "" {
+			message, err = m.generateMessage(diff)
+			if err != nil {
+				return FetchErrorMsg{Err: err}
+			}
 		}
 
 		return FetchSuccessMsg{
-			Status:  status,
-			Diff:    diff,
-			Message: message,
+			Status:         status,
+			UnstagedStatus: unstaged,
+			Diff:           diff,
+			Message:        message,
 		}
 	}
 }
@@ -171,6 +203,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Width = m.width - 6
 			m.viewport.Height = viewportHeight
 			m.viewport.SetContent(m.renderAppLogs())
+		case DiffPane:
+			m.viewport.Width = m.width - 6
+			m.viewport.Height = viewportHeight
+			content, _ := m.renderHunks()
+			m.viewport.SetContent(content)
 		default:
 			m.viewport.Width = messageWidth - 4
 			m.viewport.Height = viewportHeight
@@ -180,19 +217,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.textarea.SetWidth(m.width)
 		m.textarea.SetHeight(m.height - 5)
 
+		if m.stateMachine.WorkflowMode() == Composing {
+			m.form = m.form.resize(m.width)
+		}
+
 		return m, nil
 
 	case tea.KeyMsg:
 		if m.stateMachine.WorkflowMode() == Editing {
 			return m.handleEditMode(msg)
 		}
+		if m.stateMachine.WorkflowMode() == Composing {
+			return m.handleFormMode(msg)
+		}
+		if m.stateMachine.ViewPane() == DiffPane && m.stateMachine.WorkflowMode() == Viewing {
+			return m.handleDiffMode(msg)
+		}
 		return m.handleNormalMode(msg)
 
 	case FetchSuccessMsg:
-		files := BuildFileTree(msg.Status)
-		m.state.Files = files
+		m.state.Files = MarkExcluded(BuildStatusTree(msg.Status, msg.UnstagedStatus), m.ignore)
 		m.state.Diff = msg.Diff
-		m.state.CommitMessage = msg.Message
+		if msg.Diff == "" {
+			m.state.Notice = "Nothing is staged yet, open a file with " + m.keys.OpenDiff.Help().Key + " to stage its hunks"
+		} else {
+			m.state.CommitMessage = m.ticket.Apply(msg.Message)
+		}
 		m.viewport.SetContent(m.getDisplayMessage())
 		m.treeViewport.SetContent(m.renderFileTree())
 		m.stateMachine.EnterViewing(MessagePane)
@@ -207,13 +257,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Err != nil {
 			m.stateMachine.EnterViewing(m.stateMachine.ViewPane())
 		} else {
-			m.state.CommitMessage = msg.Message
+			m.state.CommitMessage = m.ticket.Apply(msg.Message)
+			m.state.Stale = false
 			if m.stateMachine.ViewPane() == MessagePane {
 				m.viewport.SetContent(m.getDisplayMessage())
 			}
 			m.stateMachine.EnterViewing(m.stateMachine.ViewPane())
 		}
 		return m, nil
+
+	case EditorFinishedMsg:
+		m = m.applyEditorResult(msg)
+		return m, nil
+
+	case ScopesMsg:
+		if m.stateMachine.WorkflowMode() == Composing {
+			m.form.scope.SetSuggestions(msg.Scopes)
+		}
+		return m, nil
+
+	case FileDiffMsg:
+		if msg.Err != nil {
+			m.state.Notice = "Failed to load diff of " + msg.Path + ": " + msg.Err.Error()
+			return m, nil
+		}
+		m.state.Notice = ""
+		m.hunkCursor = 0
+		m.stateMachine.EnterViewing(DiffPane)
+		m.viewport.Width = m.width - 6
+		m = m.setFileHunks(msg)
+		return m, nil
+
+	case HunkAppliedMsg:
+		if errors.Is(msg.Err, errors.ErrUnsupportedByBackend) {
+			m.state.Notice = "Staging hunks is not supported by the native git backend, set git.backend to cli"
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.state.Notice = "Failed to update " + msg.Path + ": " + msg.Err.Error()
+			return m, nil
+		}
+		m.state.Notice = ""
+		m.state.Files = MarkExcluded(BuildStatusTree(msg.Status, msg.UnstagedStatus), m.ignore)
+		m.state.Stale = m.state.Stale || msg.Diff != m.state.Diff
+		m.state.Diff = msg.Diff
+		m.treeCursor = clamp(m.treeCursor, 0, max(len(flattenFiles(m.state.Files))-1, 0))
+		m.treeViewport.SetContent(m.renderFileTree())
+		if m.stateMachine.ViewPane() == DiffPane {
+			m = m.setFileHunks(msg.FileDiffMsg)
+		}
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -230,6 +323,10 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Accept):
 		if m.stateMachine.CanAccept() {
+			if m.state.CommitMessage == "" {
+				m.state.Notice = "Nothing to commit yet, stage changes and press " + m.keys.Regenerate.Help().Key + " to generate the message"
+				return m, nil
+			}
 			m.state.Accepted = true
 			return m, tea.Quit
 		}
@@ -237,19 +334,29 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, m.keys.Edit):
 		if m.stateMachine.CanEdit() {
-			m.stateMachine.EnterEditing()
-			fullMessage := m.state.CommitMessage
-			if m.state.Prefix != "" {
-				fullMessage = m.state.Prefix + " " + fullMessage
+			if m.editor != "" {
+				return m, m.openEditor()
 			}
-			m.textarea.SetValue(fullMessage)
+			m.stateMachine.EnterEditing()
+			m.textarea.SetValue(m.fullMessage())
 			m.textarea.Focus()
 			return m, textarea.Blink
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Compose):
+		if m.stateMachine.CanEdit() {
+			return m.enterComposing()
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Regenerate):
 		if m.stateMachine.CanRegenerate() {
+			if m.state.Diff == "" {
+				m.state.Notice = "Nothing is staged yet, open a file with " + m.keys.OpenDiff.Help().Key + " to stage its hunks"
+				return m, nil
+			}
+			m.state.Notice = ""
 			m.stateMachine.EnterRegenerating()
 			return m, m.regenerateMessage()
 		}
@@ -277,6 +384,15 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.focusPane = TreeFocus
 		}
+		m.treeViewport.SetContent(m.renderFileTree())
+		return m, nil
+
+	case key.Matches(msg, m.keys.OpenDiff):
+		if m.stateMachine.CanToggleView() {
+			if path := m.selectedFile(); path != "" {
+				return m, m.loadFileDiff(path)
+			}
+		}
 		return m, nil
 
 	case key.Matches(msg, m.keys.Quit):
@@ -290,6 +406,12 @@ func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		} else {
 			if m.focusPane == TreeFocus {
+				switch msg.String() {
+				case "j", "down":
+					return m.moveTreeCursor(1), nil
+				case "k", "up":
+					return m.moveTreeCursor(-1), nil
+				}
 				m.treeViewport, cmd = m.treeViewport.Update(msg)
 			} else {
 				m.viewport, cmd = m.viewport.Update(msg)
@@ -333,30 +455,124 @@ func (m Model) handleEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // regenerateMessage creates a command to regenerate the commit message
 func (m Model) regenerateMessage() tea.Cmd {
 	return func() tea.Msg {
-		message, err := m.gptClient.FetchCommitMessage(m.ctx, m.state.Diff)
+		message, err := m.generateMessage(m.state.Diff)
 		return RegenerateMsg{Message: message, Err: err}
 	}
 }
 
-// GetOutput returns the final output after the program exits
-func (m Model) GetOutput() Output {
-	message := m.state.CommitMessage
-	if m.state.Prefix != "" {
-		message = m.state.Prefix + " " + message
+// generateMessage asks the model for a commit message for the staged diff.
+// When the changes conclude a merge or a squash, the message summarizes the
+// merged commits instead, and a merge keeps the subject prepared by git.
+// Reverts do not need the model, their message names the reverted commits,
+// unless git did not record all of them
+func (m Model) generateMessage(diff string) (string, error) {
+	op, err := m.gitClient.Operation(m.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	switch op.Kind {
+	case git.OperationNone:
+		return m.gptClient.FetchCommitMessage(m.ctx, m.ignore.PromptDiff(diff))
+	case git.OperationRevert:
+		return m.revertMessage(op, diff)
+	}
+
+	commits, err := m.mergedCommits(op)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := m.gptClient.FetchSquashMessage(m.ctx, git.FormatMessages(commits), m.ignore.PromptDiff(diff))
+	if err != nil {
+		return "", err
 	}
 
+	if op.Kind == git.OperationMerge {
+		return op.Subject() + "\n\n" + message, nil
+	}
+	return message, nil
+}
+
+// mergedCommits returns the commits brought in by a merge or a squash, each
+// listed once. SQUASH_MSG names every squashed commit, so they are loaded
+// without walking their history, while the history of each merged head is
+// read up to HEAD
+func (m Model) mergedCommits(op git.Operation) ([]git.Commit, error) {
+	if len(op.Heads) == 0 {
+		return nil, nil
+	}
+
+	if op.Kind == git.OperationSquash {
+		commits, err := m.gitClient.Log(m.ctx, append([]string{"--no-walk"}, op.Heads...))
+		if err != nil && !errors.Is(err, errors.ErrNoGitCommits) {
+			return nil, err
+		}
+		return commits, nil
+	}
+
+	var commits []git.Commit
+	seen := make(map[string]bool)
+	for _, head := range op.Heads {
+		merged, err := m.gitClient.Log(m.ctx, []string{"HEAD.." + head})
+		if err != nil && !errors.Is(err, errors.ErrNoGitCommits) {
+			return nil, err
+		}
+		for _, c := range merged {
+			if !seen[c.Hash] {
+				seen[c.Hash] = true
+				commits = append(commits, c)
+			}
+		}
+	}
+
+	return commits, nil
+}
+
+// revertMessage looks up the reverted commits and returns the revert message
+// for them. When the staged changes go beyond the recorded commits, as after
+// reverting several commits without a conflict, the model writes the message
+func (m Model) revertMessage(op git.Operation, diff string) (string, error) {
+	if len(op.Heads) == 0 {
+		return "", errors.ErrFailedToReadGitState
+	}
+
+	if op.Unlisted {
+		return m.gptClient.FetchCommitMessage(m.ctx, m.ignore.PromptDiff(diff))
+	}
+
+	opts := []string{"-n", "1", op.Heads[0]}
+	if len(op.Heads) > 1 {
+		opts = append([]string{"--no-walk"}, op.Heads...)
+	}
+
+	commits, err := m.gitClient.Log(m.ctx, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return git.RevertMessage(commits...), nil
+}
+
+// GetOutput returns the final output after the program exits
+func (m Model) GetOutput() Output {
 	return Output{
 		Accepted: m.state.Accepted,
-		Result:   message,
+		Result:   m.fullMessage(),
 	}
 }
 
-// getDisplayMessage returns the commit message with prefix prepended if set
-func (m Model) getDisplayMessage() string {
-	message := m.state.CommitMessage
+// fullMessage returns the commit message with prefix prepended if set
+func (m Model) fullMessage() string {
 	if m.state.Prefix != "" {
-		message = m.state.Prefix + " " + message
+		return m.state.Prefix + " " + m.state.CommitMessage
 	}
+	return m.state.CommitMessage
+}
+
+// getDisplayMessage returns the commit message with prefix prepended if set
+func (m Model) getDisplayMessage() string {
+	message := m.fullMessage()
 
 	if m.ready && m.viewport.Width > 0 {
 		return lipgloss.NewStyle().Width(m.viewport.Width).Render(message)
@@ -12,6 +12,8 @@ import (
 	"cmt/internal/app/errors"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/ticket"
 	"cmt/internal/config/logger"
 )
 
@@ -136,7 +138,9 @@ func Test_FetchInitialData(t *testing.T) {
 			name: "Success",
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
 				mockGit.EXPECT().Status(ctx).Return("A\tfile.txt", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("M\tREADME.md", nil)
 				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{}, nil)
 				mockGPT.EXPECT().FetchCommitMessage(ctx, "diff content").Return("Generated message", nil)
 			},
 			expectError: false,
@@ -144,10 +148,198 @@ func Test_FetchInitialData(t *testing.T) {
 				successMsg, ok := msg.(FetchSuccessMsg)
 				assert.True(t, ok)
 				assert.Equal(t, "A\tfile.txt", successMsg.Status)
+				assert.Equal(t, "M\tREADME.md", successMsg.UnstagedStatus)
 				assert.Equal(t, "diff content", successMsg.Diff)
 				assert.Equal(t, "Generated message", successMsg.Message)
 			},
 		},
+		{
+			name: "Success with merge in progress",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{
+					Kind:    git.OperationMerge,
+					Heads:   []string{"bbbbbbb222"},
+					Message: "Merge branch 'feature'\n\n# Conflicts:\n#\tapi.go\n",
+				}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"HEAD..bbbbbbb222"}).Return([]git.Commit{
+					{Hash: "bbbbbbb222", Subject: "feat: Add endpoint"},
+					{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+				}, nil)
+				mockGPT.EXPECT().
+					FetchSquashMessage(ctx, "aaaaaaa fix: Handle nil\nbbbbbbb feat: Add endpoint", "diff content").
+					Return("feat(api): Add endpoint\n\n- Handle nil config", nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "Merge branch 'feature'\n\nfeat(api): Add endpoint\n\n- Handle nil config", successMsg.Message)
+			},
+		},
+		{
+			name: "Success with octopus merge in progress",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{
+					Kind:    git.OperationMerge,
+					Heads:   []string{"ccccccc333", "bbbbbbb222"},
+					Message: "Merge branches 'feature' and 'fix'\n",
+				}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"HEAD..ccccccc333"}).Return([]git.Commit{
+					{Hash: "ccccccc333", Subject: "test: Cover endpoint"},
+					{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+				}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"HEAD..bbbbbbb222"}).Return([]git.Commit{
+					{Hash: "bbbbbbb222", Subject: "feat: Add endpoint"},
+					{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+				}, nil)
+				mockGPT.EXPECT().
+					FetchSquashMessage(ctx, "bbbbbbb feat: Add endpoint\naaaaaaa fix: Handle nil\nccccccc test: Cover endpoint", "diff content").
+					Return("feat(api): Add endpoint", nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "Merge branches 'feature' and 'fix'\n\nfeat(api): Add endpoint", successMsg.Message)
+			},
+		},
+		{
+			name: "Success with squash in progress",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{
+					Kind:  git.OperationSquash,
+					Heads: []string{"ccccccc333", "bbbbbbb222", "aaaaaaa111"},
+				}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"--no-walk", "ccccccc333", "bbbbbbb222", "aaaaaaa111"}).Return([]git.Commit{
+					{Hash: "ccccccc333", Subject: "test: Cover endpoint"},
+					{Hash: "bbbbbbb222", Subject: "feat: Add endpoint"},
+					{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+				}, nil)
+				mockGPT.EXPECT().
+					FetchSquashMessage(ctx, "aaaaaaa fix: Handle nil\nbbbbbbb feat: Add endpoint\nccccccc test: Cover endpoint", "diff content").
+					Return("feat(api): Add endpoint", nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "feat(api): Add endpoint", successMsg.Message)
+			},
+		},
+		{
+			name: "Success with revert in progress",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{Kind: git.OperationRevert, Heads: []string{"aaaaaaa111"}}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"-n", "1", "aaaaaaa111"}).Return([]git.Commit{
+					{Hash: "aaaaaaa111", Subject: "feat(api): Add endpoint"},
+				}, nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "revert: feat(api): Add endpoint\n\nThis reverts commit aaaaaaa111.\n\nRefs: aaaaaaa", successMsg.Message)
+			},
+		},
+		{
+			name: "Success with revert of several commits",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{Kind: git.OperationRevert, Heads: []string{"aaaaaaa", "bbbbbbb"}}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"--no-walk", "aaaaaaa", "bbbbbbb"}).Return([]git.Commit{
+					{Hash: "bbbbbbb222", Subject: "feat(api): Add endpoint"},
+					{Hash: "aaaaaaa111", Subject: "fix: Handle nil"},
+				}, nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "revert: 2 commits\n\nThis reverts commit bbbbbbb222 (feat(api): Add endpoint).\nThis reverts commit aaaaaaa111 (fix: Handle nil).\n\nRefs: bbbbbbb, aaaaaaa", successMsg.Message)
+			},
+		},
+		{
+			name: "Success with revert of unrecorded commits",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go\nM\tmain.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{Kind: git.OperationRevert, Heads: []string{"bbbbbbb222"}, Unlisted: true}, nil)
+				mockGPT.EXPECT().FetchCommitMessage(ctx, "diff content").Return("revert: Undo endpoint and nil handling", nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "revert: Undo endpoint and nil handling", successMsg.Message)
+			},
+		},
+		{
+			name: "Failure when reverted commit cannot be loaded",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("M\tapi.go", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{Kind: git.OperationRevert, Heads: []string{"aaaaaaa111"}}, nil)
+				mockGit.EXPECT().Log(ctx, []string{"-n", "1", "aaaaaaa111"}).Return(nil, errors.ErrFailedToLoadGitLog)
+			},
+			expectError: true,
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				errorMsg, ok := msg.(FetchErrorMsg)
+				assert.True(t, ok)
+				assert.ErrorIs(t, errorMsg.Err, errors.ErrFailedToLoadGitLog)
+			},
+		},
+		{
+			name: "Failure when merge state cannot be read",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("A\tfile.txt", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{}, errors.ErrFailedToReadGitState)
+			},
+			expectError: true,
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				errorMsg, ok := msg.(FetchErrorMsg)
+				assert.True(t, ok)
+				assert.ErrorIs(t, errorMsg.Err, errors.ErrFailedToReadGitState)
+			},
+		},
+		{
+			name: "Success with nothing staged",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("", errors.ErrNoGitChanges)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("M\tREADME.md", nil)
+			},
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				successMsg, ok := msg.(FetchSuccessMsg)
+				assert.True(t, ok)
+				assert.Equal(t, "M\tREADME.md", successMsg.UnstagedStatus)
+				assert.Empty(t, successMsg.Diff)
+				assert.Empty(t, successMsg.Message)
+			},
+		},
+		{
+			name: "Failure without changes",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("", errors.ErrNoGitChanges)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
+			},
+			expectError: true,
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				errorMsg, ok := msg.(FetchErrorMsg)
+				assert.True(t, ok)
+				assert.ErrorIs(t, errorMsg.Err, errors.ErrNoGitChanges)
+			},
+		},
 		{
 			name: "Failure when git status fails",
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
@@ -160,10 +352,24 @@ func Test_FetchInitialData(t *testing.T) {
 				assert.Error(t, errorMsg.Err)
 			},
 		},
+		{
+			name: "Failure when unstaged status fails",
+			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().Status(ctx).Return("A\tfile.txt", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", errors.ErrFailedToLoadGitDiff)
+			},
+			expectError: true,
+			checkFn: func(t *testing.T, msg tea.Msg) {
+				errorMsg, ok := msg.(FetchErrorMsg)
+				assert.True(t, ok)
+				assert.ErrorIs(t, errorMsg.Err, errors.ErrFailedToLoadGitDiff)
+			},
+		},
 		{
 			name: "Failure when git diff fails",
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
 				mockGit.EXPECT().Status(ctx).Return("A\tfile.txt", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
 				mockGit.EXPECT().Diff(ctx).Return("", errors.New("git diff failed"))
 			},
 			expectError: true,
@@ -177,7 +383,9 @@ func Test_FetchInitialData(t *testing.T) {
 			name: "Failure when g p t fails",
 			before: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
 				mockGit.EXPECT().Status(ctx).Return("A\tfile.txt", nil)
+				mockGit.EXPECT().UnstagedStatus(ctx).Return("", nil)
 				mockGit.EXPECT().Diff(ctx).Return("diff content", nil)
+				mockGit.EXPECT().Operation(ctx).Return(git.Operation{}, nil)
 				mockGPT.EXPECT().FetchCommitMessage(ctx, "diff content").Return("", errors.New("gpt fetch failed"))
 			},
 			expectError: true,
@@ -280,6 +488,69 @@ func Test_Update_FetchSuccess(t *testing.T) {
 	assert.Equal(t, "diff content", updatedModel.state.Diff)
 	assert.Equal(t, "Generated message", updatedModel.state.CommitMessage)
 	assert.Equal(t, Viewing, updatedModel.stateMachine.WorkflowMode())
+
+	t.Run("Success with nothing staged", func(t *testing.T) {
+		updated, _ := m.Update(FetchSuccessMsg{UnstagedStatus: "M\tREADME.md"})
+		updatedModel := updated.(Model)
+
+		assert.Equal(t, []string{"README.md"}, flattenFiles(updatedModel.state.Files))
+		assert.Empty(t, updatedModel.state.CommitMessage)
+		assert.Equal(t, "Nothing is staged yet, open a file with enter to stage its hunks", updatedModel.state.Notice)
+
+		updated, cmd := updatedModel.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+		assert.False(t, updated.(Model).state.Accepted)
+		assert.Nil(t, cmd)
+
+		updated, cmd = updatedModel.handleNormalMode(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+		assert.Equal(t, Viewing, updated.(Model).stateMachine.WorkflowMode())
+		assert.Nil(t, cmd)
+	})
+}
+
+func Test_Update_AppliesTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGit := git.NewMockClient(ctrl)
+	mockGPT := gpt.NewMockClient(ctrl)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+
+	tests := []struct {
+		name     string
+		msg      tea.Msg
+		expected string
+	}{
+		{
+			name:     "Success with fetched message",
+			msg:      FetchSuccessMsg{Status: "A\tfile.txt", Diff: "diff", Message: "feat(ui): Add button"},
+			expected: "feat(ui): Add button\n\nRefs: JIRA-7",
+		},
+		{
+			name:     "Success with regenerated message",
+			msg:      RegenerateMsg{Message: "fix: Fix crash"},
+			expected: "fix: Fix crash\n\nRefs: JIRA-7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(Input{
+				CommitMessage: "old message",
+				GitClient:     mockGit,
+				GPTClient:     mockGPT,
+				Logger:        mockLogger,
+				Ticket:        ticket.Ticket{ID: "JIRA-7", Placement: "footer"},
+				Ctx:           context.Background(),
+				Spinner:       func() spinner.Model { return mockSpinner },
+			})
+
+			updated, _ := m.Update(tt.msg)
+			assert.Equal(t, tt.expected, updated.(Model).state.CommitMessage)
+		})
+	}
 }
 
 func Test_Update_FetchError(t *testing.T) {
@@ -503,6 +774,7 @@ func Test_HandleNormalMode_Regenerate(t *testing.T) {
 	mockLogger := logger.NewMockLogger(ctrl)
 
 	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+	mockGit.EXPECT().Operation(ctx).Return(git.Operation{}, nil)
 	mockGPT.EXPECT().FetchCommitMessage(ctx, "test diff").Return("regenerated message", nil)
 
 	input := Input{
@@ -530,6 +802,41 @@ func Test_HandleNormalMode_Regenerate(t *testing.T) {
 	assert.Equal(t, "regenerated message", regenMsg.Message)
 }
 
+func Test_HandleNormalMode_Regenerate_WithIgnore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockGit := git.NewMockClient(ctrl)
+	mockGPT := gpt.NewMockClient(ctrl)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockLogger := logger.NewMockLogger(ctrl)
+
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+	mockGit.EXPECT().Operation(ctx).Return(git.Operation{}, nil)
+	mockGPT.EXPECT().FetchCommitMessage(ctx, "diff --git a/main.go b/main.go\n+package main\n\n1 file regenerated (contents omitted): go.sum").Return("regenerated message", nil)
+
+	input := Input{
+		CommitMessage: "old message",
+		Diff:          "diff --git a/main.go b/main.go\n+package main\ndiff --git a/go.sum b/go.sum\n+h1:abc",
+		GitClient:     mockGit,
+		GPTClient:     mockGPT,
+		Logger:        mockLogger,
+		Ignore:        ignore.New([]string{"go.sum"}),
+		Ctx:           ctx,
+		Spinner:       func() spinner.Model { return mockSpinner },
+	}
+
+	m := NewModel(input)
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+	_, cmd := m.handleNormalMode(keyMsg)
+
+	msg := cmd()
+	regenMsg, ok := msg.(RegenerateMsg)
+	assert.True(t, ok)
+	assert.Equal(t, "regenerated message", regenMsg.Message)
+}
+
 func Test_HandleNormalMode_ToggleLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,36 @@
+package commit
+
+import "strings"
+
+// Title renders the heading shown above the panels
+func Title(text string) string {
+	return titleStyle.Render(text)
+}
+
+// Panel renders content in a bordered panel, highlighting the border when the panel has focus
+func Panel(content string, width, height int, focused bool) string {
+	style := panelStyle
+	if focused {
+		style = style.BorderForeground(ColorPrimary)
+	}
+	return style.Width(width).Height(height).Render(content)
+}
+
+// Section renders the label of a group of lines inside a panel
+func Section(text string) string {
+	return sectionStyle.Render(text)
+}
+
+// Help renders the key bindings shown below the panels
+func Help(view string) string {
+	return helpStyle.Render(view)
+}
+
+// RenderDiff colors the added and deleted lines of a diff
+func RenderDiff(diff string) string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = renderDiffLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,11 +1,16 @@
 package commit
 
+import "cmt/internal/app/git"
+
 // State holds the data for the commit TUI
 type State struct {
 	Files         []FileNode
 	CommitMessage string
 	Prefix        string
 	Diff          string
+	Hunks         FileHunks
+	Notice        string
+	Stale         bool // staged changes differ from those the message describes
 	Accepted      bool
 	Error         error
 }
@@ -17,4 +22,26 @@ type FileNode struct {
 	IsDir    bool
 	Children []FileNode
 	Status   string // A=added, M=modified, D=deleted
+	Excluded bool   // omitted from the diff sent to the model
+	Unstaged bool   // only has unstaged changes
+}
+
+// FileHunks holds the staged and unstaged hunks of the file shown in the diff pane
+type FileHunks struct {
+	Path     string
+	Staged   git.FileDiff
+	Unstaged git.FileDiff
+}
+
+// Len returns the total number of staged and unstaged hunks
+func (f FileHunks) Len() int {
+	return len(f.Staged.Hunks) + len(f.Unstaged.Hunks)
+}
+
+// Patch returns the patch for the hunk at the given index and whether it is staged
+func (f FileHunks) Patch(index int) (patch string, staged bool) {
+	if index < len(f.Staged.Hunks) {
+		return f.Staged.Patch(index), true
+	}
+	return f.Unstaged.Patch(index - len(f.Staged.Hunks)), false
 }
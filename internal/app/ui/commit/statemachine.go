@@ -8,6 +8,8 @@ const (
 	MessagePane ViewPane = iota
 	// AppLogsPane shows application logs
 	AppLogsPane
+	// DiffPane shows the hunks of the selected file
+	DiffPane
 )
 
 // String returns the string representation of the ViewPane
@@ -17,6 +19,8 @@ func (v ViewPane) String() string {
 		return "MessagePane"
 	case AppLogsPane:
 		return "AppLogsPane"
+	case DiffPane:
+		return "DiffPane"
 	default:
 		return "Unknown"
 	}
@@ -34,6 +38,8 @@ const (
 	Fetching
 	// Regenerating indicates a new commit message is being generated
 	Regenerating
+	// Composing indicates the user is editing the commit message in the structured form
+	Composing
 )
 
 // String returns the string representation of the WorkflowMode
@@ -47,6 +53,8 @@ func (w WorkflowMode) String() string {
 		return "Fetching"
 	case Regenerating:
 		return "Regenerating"
+	case Composing:
+		return "Composing"
 	default:
 		return "Unknown"
 	}
@@ -107,6 +115,11 @@ func (sm *stateMachine) EnterEditing() {
 	sm.SetWorkflowMode(Editing)
 }
 
+// EnterComposing enters structured form editing mode
+func (sm *stateMachine) EnterComposing() {
+	sm.SetWorkflowMode(Composing)
+}
+
 // EnterRegenerating enters regenerating mode
 func (sm *stateMachine) EnterRegenerating() {
 	sm.SetWorkflowMode(Regenerating)
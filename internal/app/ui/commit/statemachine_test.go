@@ -22,6 +22,11 @@ func Test_ViewPane_String(t *testing.T) {
 			pane:     AppLogsPane,
 			expected: "AppLogsPane",
 		},
+		{
+			name:     "Success with diff pane",
+			pane:     DiffPane,
+			expected: "DiffPane",
+		},
 		{
 			name:     "Success with unknown pane",
 			pane:     ViewPane(999),
@@ -63,6 +68,11 @@ func Test_WorkflowMode_String(t *testing.T) {
 			mode:     Regenerating,
 			expected: "Regenerating",
 		},
+		{
+			name:     "Success with composing mode",
+			mode:     Composing,
+			expected: "Composing",
+		},
 		{
 			name:     "Success with unknown mode",
 			mode:     WorkflowMode(999),
@@ -166,6 +176,13 @@ func Test_EnterEditing(t *testing.T) {
 	assert.Equal(t, Editing, sm.WorkflowMode())
 }
 
+func Test_EnterComposing(t *testing.T) {
+	sm := newStateMachine(MessagePane, Viewing)
+	sm.EnterComposing()
+
+	assert.Equal(t, Composing, sm.WorkflowMode())
+}
+
 func Test_EnterRegenerating(t *testing.T) {
 	sm := newStateMachine(MessagePane, Viewing)
 	sm.EnterRegenerating()
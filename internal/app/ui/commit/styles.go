@@ -22,7 +22,27 @@ var (
 		BorderForeground(ColorBorder).
 		Padding(0, 1)
 
+	sectionStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorBorder)
+
+	selectedStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary)
+
+	formStyle = lipgloss.NewStyle().
+		Padding(0, 2)
+
+	formLabelStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorBorder).
+		Width(14)
+
 	helpStyle = lipgloss.NewStyle().
 		Foreground(ColorBorder).
 		Padding(0, 2)
+
+	noticeStyle = lipgloss.NewStyle().
+		Foreground(ColorDeleted).
+		Padding(0, 2)
 )
@@ -3,6 +3,8 @@ package commit
 import (
 	"sort"
 	"strings"
+
+	"cmt/internal/app/ignore"
 )
 
 // BuildFileTree constructs a hierarchical tree from a flat list of file paths with status
@@ -26,11 +28,7 @@ func BuildFileTree(statusOutput string) []FileNode {
 		}
 
 		status := parts[0]
-		path := parts[1]
-
-		if strings.HasPrefix(status, "R") && len(parts) >= 3 {
-			path = parts[2]
-		}
+		path := statusPath(parts)
 
 		segments := strings.Split(path, "/")
 
@@ -109,6 +107,51 @@ func BuildFileTree(statusOutput string) []FileNode {
 	return result
 }
 
+// BuildStatusTree constructs the tree of the staged files and adds the files
+// that only have unstaged changes, so their hunks can be staged from the diff pane
+func BuildStatusTree(staged, unstaged string) []FileNode {
+	paths := make(map[string]bool)
+	for _, line := range strings.Split(staged, "\n") {
+		if parts := strings.Split(strings.TrimSpace(line), "\t"); len(parts) >= 2 {
+			paths[statusPath(parts)] = true
+		}
+	}
+
+	lines := []string{staged}
+	unstagedOnly := make(map[string]bool)
+	for _, line := range strings.Split(unstaged, "\n") {
+		parts := strings.Split(strings.TrimSpace(line), "\t")
+		if len(parts) < 2 || paths[statusPath(parts)] {
+			continue
+		}
+		unstagedOnly[statusPath(parts)] = true
+		lines = append(lines, line)
+	}
+
+	return markUnstaged(BuildFileTree(strings.Join(lines, "\n")), unstagedOnly)
+}
+
+// markUnstaged flags the files that only have unstaged changes
+func markUnstaged(nodes []FileNode, paths map[string]bool) []FileNode {
+	for i := range nodes {
+		if nodes[i].IsDir {
+			nodes[i].Children = markUnstaged(nodes[i].Children, paths)
+			continue
+		}
+		nodes[i].Unstaged = paths[nodes[i].Path]
+	}
+	return nodes
+}
+
+// statusPath returns the path of a git --name-status line split on tabs,
+// using the destination of a rename
+func statusPath(parts []string) string {
+	if strings.HasPrefix(parts[0], "R") && len(parts) >= 3 {
+		return parts[2]
+	}
+	return parts[1]
+}
+
 // sortChildren recursively sorts children of a node
 func sortChildren(node *FileNode) {
 	if !node.IsDir || len(node.Children) == 0 {
@@ -139,3 +182,28 @@ func findChild(parent *FileNode, name string) *FileNode {
 	}
 	return nil
 }
+
+// MarkExcluded flags the files excluded from the diff sent to the model
+func MarkExcluded(nodes []FileNode, matcher *ignore.Matcher) []FileNode {
+	for i := range nodes {
+		if nodes[i].IsDir {
+			nodes[i].Children = MarkExcluded(nodes[i].Children, matcher)
+			continue
+		}
+		nodes[i].Excluded = matcher.Match(nodes[i].Path)
+	}
+	return nodes
+}
+
+// countExcluded returns the number of excluded files in the tree
+func countExcluded(nodes []FileNode) int {
+	count := 0
+	for _, node := range nodes {
+		if node.IsDir {
+			count += countExcluded(node.Children)
+		} else if node.Excluded {
+			count++
+		}
+	}
+	return count
+}
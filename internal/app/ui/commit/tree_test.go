@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"cmt/internal/app/ignore"
 )
 
 func Test_BuildFileTree(t *testing.T) {
@@ -196,3 +198,83 @@ func Test_FindChild(t *testing.T) {
 		})
 	}
 }
+
+func Test_MarkExcluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		matcher  *ignore.Matcher
+		expected int
+	}{
+		{
+			name:     "Success with nested excluded files",
+			input:    "M\tgo.sum\nM\tmain.go\nA\tweb/yarn.lock",
+			matcher:  ignore.New([]string{"go.sum", "*.lock"}),
+			expected: 2,
+		},
+		{
+			name:     "Success without matcher",
+			input:    "M\tgo.sum",
+			matcher:  nil,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := MarkExcluded(BuildFileTree(tt.input), tt.matcher)
+			assert.Equal(t, tt.expected, countExcluded(nodes))
+		})
+	}
+}
+
+func Test_BuildStatusTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		staged   string
+		unstaged string
+		files    []string
+		only     []string
+	}{
+		{
+			name:     "Success with unstaged only files",
+			staged:   "M\tmain.go",
+			unstaged: "M\tmain.go\nM\tdocs/README.md",
+			files:    []string{"docs/README.md", "main.go"},
+			only:     []string{"docs/README.md"},
+		},
+		{
+			name:     "Success with nothing staged",
+			staged:   "",
+			unstaged: "D\told.go",
+			files:    []string{"old.go"},
+			only:     []string{"old.go"},
+		},
+		{
+			name:     "Success without unstaged changes",
+			staged:   "R100\tsrc/a.go\tsrc/b.go",
+			unstaged: "",
+			files:    []string{"src/b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := BuildStatusTree(tt.staged, tt.unstaged)
+			assert.Equal(t, tt.files, flattenFiles(nodes))
+
+			var only []string
+			var walk func([]FileNode)
+			walk = func(nodes []FileNode) {
+				for _, node := range nodes {
+					walk(node.Children)
+					if node.Unstaged {
+						only = append(only, node.Path)
+					}
+				}
+			}
+			walk(nodes)
+			assert.Equal(t, tt.only, only)
+		})
+	}
+}
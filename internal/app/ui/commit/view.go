@@ -1,6 +1,7 @@
 package commit
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -16,6 +17,10 @@ func (m Model) View() string {
 		return m.renderEditMode()
 	}
 
+	if m.stateMachine.WorkflowMode() == Composing {
+		return m.renderFormMode()
+	}
+
 	return m.renderNormalMode()
 }
 
@@ -25,8 +30,11 @@ func (m Model) renderNormalMode() string {
 
 	titleText := ">_ commit message"
 
-	if m.stateMachine.ViewPane() == AppLogsPane {
+	switch m.stateMachine.ViewPane() {
+	case AppLogsPane:
 		titleText = ">_ logs"
+	case DiffPane:
+		titleText = ">_ diff " + m.state.Hunks.Path
 	}
 
 	if m.stateMachine.IsGenerating() {
@@ -41,34 +49,15 @@ func (m Model) renderNormalMode() string {
 		return strings.Join(sections, "\n")
 	}
 
-	if m.stateMachine.ViewPane() == AppLogsPane {
-		logsPanel := panelStyle.
-			Width(m.width - 2).
-			Height(m.height - 10).
-			Render(m.viewport.View())
+	if m.stateMachine.ViewPane() == AppLogsPane || m.stateMachine.ViewPane() == DiffPane {
+		logsPanel := Panel(m.viewport.View(), m.width-2, m.height-10, false)
 		sections = append(sections, logsPanel)
 	} else {
 		treeWidth := m.width / 3
 		messageWidth := m.width - treeWidth - 4
 
-		treePanelStyle := panelStyle
-		messagePanelStyle := panelStyle
-
-		if m.focusPane == TreeFocus {
-			treePanelStyle = treePanelStyle.BorderForeground(ColorPrimary)
-		} else {
-			messagePanelStyle = messagePanelStyle.BorderForeground(ColorPrimary)
-		}
-
-		treePanel := treePanelStyle.
-			Width(treeWidth).
-			Height(m.height - 10).
-			Render(m.treeViewport.View())
-
-		messagePanel := messagePanelStyle.
-			Width(messageWidth).
-			Height(m.height - 10).
-			Render(m.viewport.View())
+		treePanel := Panel(m.treeViewport.View(), treeWidth, m.height-10, m.focusPane == TreeFocus)
+		messagePanel := Panel(m.viewport.View(), messageWidth, m.height-10, m.focusPane != TreeFocus)
 
 		panels := lipgloss.JoinHorizontal(lipgloss.Top, treePanel, messagePanel)
 		sections = append(sections, panels)
@@ -76,7 +65,17 @@ func (m Model) renderNormalMode() string {
 
 	sections = append(sections, "")
 
+	switch {
+	case m.state.Notice != "":
+		sections = append(sections, noticeStyle.Render(m.state.Notice))
+	case m.state.Stale:
+		sections = append(sections, noticeStyle.Render("Staged changes changed, press "+m.keys.Regenerate.Help().Key+" to regenerate the message"))
+	}
+
 	helpView := m.help.View(m.keys)
+	if m.stateMachine.ViewPane() == DiffPane {
+		helpView = m.help.ShortHelpView(m.keys.DiffHelp())
+	}
 	sections = append(sections, helpStyle.Render(helpView))
 
 	return strings.Join(sections, "\n")
@@ -104,6 +103,15 @@ func (m Model) renderFileTree() string {
 	var sb strings.Builder
 	sb.WriteString(m.renderTreeNodes(m.state.Files, "", true))
 
+	if excluded := countExcluded(m.state.Files); excluded > 0 {
+		noun := "files"
+		if excluded == 1 {
+			noun = "file"
+		}
+		sb.WriteString("\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(ColorMuted).Render(fmt.Sprintf("%d %s regenerated", excluded, noun)))
+	}
+
 	return sb.String()
 }
 
@@ -144,6 +152,14 @@ func (m Model) renderTreeNodes(nodes []FileNode, indent string, isRoot bool) str
 		if node.IsDir {
 			name += "/"
 		}
+		switch {
+		case m.focusPane == TreeFocus && !node.IsDir && node.Path == m.selectedFile():
+			name = selectedStyle.Render(name)
+		case node.Excluded:
+			name = lipgloss.NewStyle().Foreground(ColorMuted).Faint(true).Render(name)
+		case node.Unstaged:
+			name = lipgloss.NewStyle().Foreground(ColorMuted).Italic(true).Render(name + " (unstaged)")
+		}
 		sb.WriteString(name)
 		sb.WriteString("\n")
 
@@ -11,6 +11,7 @@ import (
 	"cmt/internal/app/cli/spinner"
 	"cmt/internal/app/git"
 	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
 	"cmt/internal/config/logger"
 )
 
@@ -107,6 +108,11 @@ func Test_RenderFileTree(t *testing.T) {
 			files:    "A\tfile.txt",
 			expected: "file.txt",
 		},
+		{
+			name:     "Success with excluded files summary",
+			files:    "M\tgo.sum\nM\tmain.go",
+			expected: "1 file regenerated",
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +122,7 @@ func Test_RenderFileTree(t *testing.T) {
 				GitClient: mockGit,
 				GPTClient: mockGPT,
 				Logger:    mockLogger,
+				Ignore:    ignore.New([]string{"go.sum"}),
 				Ctx:       context.Background(),
 				Spinner:   func() spinner.Model { return mockSpinner },
 			}
@@ -0,0 +1,7 @@
+package explain
+
+// ExplanationMsg carries the explanation generated for the commit
+type ExplanationMsg struct {
+	Text string
+	Err  error
+}
@@ -0,0 +1,43 @@
+package explain
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyMap defines the key bindings for the explain TUI
+type KeyMap struct {
+	ToggleFocus key.Binding
+	ToggleDiff  key.Binding
+	Regenerate  key.Binding
+	Quit        key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		ToggleFocus: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "switch pane"),
+		),
+		ToggleDiff: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "toggle diff"),
+		),
+		Regenerate: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "regenerate"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "esc", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.ToggleFocus, k.ToggleDiff, k.Regenerate, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
@@ -0,0 +1,230 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/ui/commit"
+)
+
+// Pane is the content shown in the right panel
+type Pane int
+
+const (
+	// ExplanationPane shows the generated explanation
+	ExplanationPane Pane = iota
+	// DiffPane shows the diff of the commit
+	DiffPane
+)
+
+// Model represents the Bubble Tea model for the commit explanation viewer
+type Model struct {
+	commit       git.Commit
+	diff         string
+	explanation  string
+	err          error
+	keys         KeyMap
+	help         help.Model
+	details      viewport.Model
+	content      viewport.Model
+	spinner      spinner.Model
+	gptClient    gpt.Client
+	ignore       *ignore.Matcher
+	ctx          context.Context
+	pane         Pane
+	detailsFocus bool
+	loading      bool
+	ready        bool
+	width        int
+	height       int
+}
+
+// Input contains the commit to explain
+type Input struct {
+	Commit    git.Commit
+	Diff      string
+	GPTClient gpt.Client
+	Ignore    *ignore.Matcher
+	Ctx       context.Context
+	Spinner   spinner.Factory
+}
+
+// NewModel creates a new explain UI model
+func NewModel(input Input) Model {
+	h := help.New()
+	h.ShowAll = false
+
+	return Model{
+		commit:    input.Commit,
+		diff:      input.Diff,
+		keys:      DefaultKeyMap(),
+		help:      h,
+		details:   viewport.New(0, 0),
+		content:   viewport.New(0, 0),
+		spinner:   input.Spinner(),
+		gptClient: input.GPTClient,
+		ignore:    input.Ignore,
+		ctx:       input.Ctx,
+		loading:   true,
+	}
+}
+
+// Init starts generating the explanation
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchExplanation())
+}
+
+// Update handles messages and updates the model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.ready = true
+
+		detailsWidth := m.width / 3
+		contentWidth := m.width - detailsWidth - 4
+
+		m.details.Width = detailsWidth - 4
+		m.details.Height = m.height - 12
+		m.content.Width = contentWidth - 4
+		m.content.Height = m.height - 12
+
+		m.details.SetContent(m.renderDetails())
+		m.content.SetContent(m.renderContent())
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case ExplanationMsg:
+		m.loading = false
+		m.explanation = msg.Text
+		m.err = msg.Err
+		m.content.SetContent(m.renderContent())
+		m.content.GotoTop()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// handleKey processes key presses, scrolling the focused panel with the remaining keys
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.ToggleFocus):
+		m.detailsFocus = !m.detailsFocus
+		return m, nil
+
+	case key.Matches(msg, m.keys.ToggleDiff):
+		if m.pane == DiffPane {
+			m.pane = ExplanationPane
+		} else {
+			m.pane = DiffPane
+		}
+		m.detailsFocus = false
+		m.content.SetContent(m.renderContent())
+		m.content.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Regenerate):
+		if m.loading {
+			return m, nil
+		}
+		m.loading = true
+		m.pane = ExplanationPane
+		return m, tea.Batch(m.spinner.Tick, m.fetchExplanation())
+	}
+
+	var cmd tea.Cmd
+	if m.detailsFocus {
+		m.details, cmd = m.details.Update(msg)
+	} else {
+		m.content, cmd = m.content.Update(msg)
+	}
+	return m, cmd
+}
+
+// fetchExplanation creates a command asking the model to explain the commit
+func (m Model) fetchExplanation() tea.Cmd {
+	return func() tea.Msg {
+		text, err := m.gptClient.FetchExplanation(m.ctx, m.message(), m.ignore.PromptDiff(m.diff))
+		return ExplanationMsg{Text: text, Err: err}
+	}
+}
+
+// message returns the full message of the commit
+func (m Model) message() string {
+	message := m.commit.Subject
+	if body := strings.TrimSpace(m.commit.Body); body != "" {
+		message += "\n\n" + body
+	}
+	if len(m.commit.Trailers) > 0 {
+		trailers := make([]string, 0, len(m.commit.Trailers))
+		for _, trailer := range m.commit.Trailers {
+			trailers = append(trailers, trailer.Key+": "+trailer.Value)
+		}
+		message += "\n\n" + strings.Join(trailers, "\n")
+	}
+	return message
+}
+
+// renderDetails renders the hash, author, date and message of the commit
+func (m Model) renderDetails() string {
+	lines := []string{
+		commit.Section("commit"),
+		m.commit.Hash,
+		"",
+		commit.Section("author"),
+		fmt.Sprintf("%s <%s>", m.commit.Author, m.commit.Email),
+		m.commit.Date.Format(time.DateTime),
+	}
+	if len(m.commit.Refs) > 0 {
+		lines = append(lines, "", commit.Section("refs"), strings.Join(m.commit.Refs, ", "))
+	}
+	lines = append(lines, "", commit.Section("message"), m.message())
+
+	return wrap(strings.Join(lines, "\n"), m.details.Width)
+}
+
+// renderContent renders the explanation or the diff of the commit
+func (m Model) renderContent() string {
+	if m.pane == DiffPane {
+		return commit.RenderDiff(m.diff)
+	}
+	if m.err != nil {
+		return wrap(errors.Format(m.err), m.content.Width)
+	}
+	return wrap(m.explanation, m.content.Width)
+}
+
+// GetError returns the error of the last explanation request
+func (m Model) GetError() error {
+	return m.err
+}
+
+// wrap wraps text to the width of a viewport once it is known
+func wrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	return lipgloss.NewStyle().Width(width).Render(text)
+}
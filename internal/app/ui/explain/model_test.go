@@ -0,0 +1,147 @@
+package explain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+func newTestModel(t *testing.T, gptClient gpt.Client) Model {
+	ctrl := gomock.NewController(t)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+	mockSpinner.EXPECT().View().Return("⠋").AnyTimes()
+
+	m := NewModel(Input{
+		Commit: git.Commit{
+			Hash:     "aaaaaaa1111111",
+			Author:   "Jane Doe",
+			Email:    "jane@example.com",
+			Date:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Subject:  "fix(api): Handle nil config",
+			Body:     "The config is optional.",
+			Trailers: []git.Trailer{{Key: "Refs", Value: "#42"}},
+		},
+		Diff:      "+++ b/api.go\n-return cfg.Name\n+return name(cfg)",
+		GPTClient: gptClient,
+		Ctx:       context.Background(),
+		Spinner:   func() spinner.Model { return mockSpinner },
+	})
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(Model)
+}
+
+func Test_FetchExplanation(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		err      error
+		expected string
+	}{
+		{
+			name:     "Success with explanation",
+			response: "Avoids a panic when no config is given.",
+			expected: "Avoids a panic when no config is given.",
+		},
+		{
+			name:     "Failure with model error",
+			err:      errors.ErrNoResponse,
+			expected: "no response from GPT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockGPT.EXPECT().
+				FetchExplanation(gomock.Any(), "fix(api): Handle nil config\n\nThe config is optional.\n\nRefs: #42", "+++ b/api.go\n-return cfg.Name\n+return name(cfg)").
+				Return(tt.response, tt.err)
+
+			m := newTestModel(t, mockGPT)
+			assert.Contains(t, m.View(), "loading")
+
+			updated, _ := m.Update(m.fetchExplanation()())
+			m = updated.(Model)
+
+			assert.False(t, m.loading)
+			assert.ErrorIs(t, m.GetError(), tt.err)
+			assert.Contains(t, m.View(), tt.expected)
+			assert.Contains(t, m.View(), "jane@example.com")
+		})
+	}
+}
+
+func Test_HandleKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		keys          []tea.KeyMsg
+		expectedPane  Pane
+		expectedFocus bool
+		expectQuit    bool
+	}{
+		{
+			name:         "Success toggling the diff",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'d'}}},
+			expectedPane: DiffPane,
+		},
+		{
+			name:         "Success toggling the diff twice",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'d'}}, {Type: tea.KeyRunes, Runes: []rune{'d'}}},
+			expectedPane: ExplanationPane,
+		},
+		{
+			name:          "Success switching focus",
+			keys:          []tea.KeyMsg{{Type: tea.KeyTab}},
+			expectedPane:  ExplanationPane,
+			expectedFocus: true,
+		},
+		{
+			name:         "Success quitting",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'q'}}},
+			expectedPane: ExplanationPane,
+			expectQuit:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil)
+			updated, _ := m.Update(ExplanationMsg{Text: "Avoids a panic."})
+			m = updated.(Model)
+
+			var cmd tea.Cmd
+			for _, msg := range tt.keys {
+				updated, cmd = m.Update(msg)
+				m = updated.(Model)
+			}
+
+			assert.Equal(t, tt.expectedPane, m.pane)
+			assert.Equal(t, tt.expectedFocus, m.detailsFocus)
+			if tt.expectQuit {
+				assert.IsType(t, tea.QuitMsg{}, cmd())
+			}
+		})
+	}
+}
+
+func Test_View_Diff(t *testing.T) {
+	m := newTestModel(t, nil)
+	updated, _ := m.Update(ExplanationMsg{Text: "Avoids a panic."})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	view := updated.View()
+
+	assert.Contains(t, view, "diff aaaaaaa")
+	assert.Contains(t, view, "+return name(cfg)")
+}
@@ -0,0 +1,41 @@
+package explain
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/ui/commit"
+)
+
+// View renders the explain UI
+func (m Model) View() string {
+	if !m.ready {
+		return "Initializing…"
+	}
+
+	title := ">_ explain " + m.commit.ShortHash()
+	switch {
+	case m.loading:
+		title = m.spinner.View() + " loading…"
+	case m.pane == DiffPane:
+		title = ">_ diff " + m.commit.ShortHash()
+	}
+
+	detailsWidth := m.width / 3
+	contentWidth := m.width - detailsWidth - 4
+
+	sections := []string{commit.Title(title), ""}
+
+	if !m.loading || m.pane == DiffPane {
+		panels := lipgloss.JoinHorizontal(lipgloss.Top,
+			commit.Panel(m.details.View(), detailsWidth, m.height-10, m.detailsFocus),
+			commit.Panel(m.content.View(), contentWidth, m.height-10, !m.detailsFocus),
+		)
+		sections = append(sections, panels, "")
+	}
+
+	sections = append(sections, commit.Help(m.help.View(m.keys)))
+
+	return strings.Join(sections, "\n")
+}
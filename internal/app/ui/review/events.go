@@ -0,0 +1,9 @@
+package review
+
+import "cmt/internal/app/gpt"
+
+// FindingsMsg carries the findings of the review of the staged changes
+type FindingsMsg struct {
+	Findings []gpt.Finding
+	Err      error
+}
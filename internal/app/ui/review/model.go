@@ -0,0 +1,258 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+	"cmt/internal/app/review"
+	"cmt/internal/app/ui/commit"
+)
+
+// Model represents the Bubble Tea model listing the findings of a review
+type Model struct {
+	findings  []gpt.Finding
+	err       error
+	staged    string
+	files     map[string]git.FileDiff
+	cursor    int
+	diffFocus bool
+	keys      KeyMap
+	help      help.Model
+	list      viewport.Model
+	diff      viewport.Model
+	spinner   spinner.Model
+	gptClient gpt.Client
+	ignore    *ignore.Matcher
+	ctx       context.Context
+	loading   bool
+	ready     bool
+	width     int
+	height    int
+}
+
+// Input contains the staged diff to review
+type Input struct {
+	Diff      string
+	GPTClient gpt.Client
+	Ignore    *ignore.Matcher
+	Ctx       context.Context
+	Spinner   spinner.Factory
+}
+
+// NewModel creates a new review UI model
+func NewModel(input Input) Model {
+	h := help.New()
+	h.ShowAll = false
+
+	return Model{
+		staged:    input.Diff,
+		files:     review.Files(input.Diff),
+		keys:      DefaultKeyMap(),
+		help:      h,
+		list:      viewport.New(0, 0),
+		diff:      viewport.New(0, 0),
+		spinner:   input.Spinner(),
+		gptClient: input.GPTClient,
+		ignore:    input.Ignore,
+		ctx:       input.Ctx,
+		loading:   true,
+	}
+}
+
+// Init starts the review of the staged changes
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchFindings())
+}
+
+// Update handles messages and updates the model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.ready = true
+
+		listWidth := m.width / 3
+		diffWidth := m.width - listWidth - 4
+
+		m.list.Width = listWidth - 4
+		m.list.Height = m.height - 12
+		m.diff.Width = diffWidth - 4
+		m.diff.Height = m.height - 12
+
+		return m.selectFinding(m.cursor), nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case FindingsMsg:
+		m.loading = false
+		m.findings = msg.Findings
+		m.err = msg.Err
+		return m.selectFinding(0), nil
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// fetchFindings creates a command asking the model to review the staged diff
+func (m Model) fetchFindings() tea.Cmd {
+	return func() tea.Msg {
+		findings, err := m.gptClient.FetchReview(m.ctx, m.ignore.PromptDiff(m.staged))
+		return FindingsMsg{Findings: findings, Err: err}
+	}
+}
+
+// GetError returns the error of the review request
+func (m Model) GetError() error {
+	return m.err
+}
+
+// handleKey moves the selection in the list, or scrolls the diff when it has focus
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.ToggleFocus):
+		m.diffFocus = !m.diffFocus
+		return m, nil
+
+	case key.Matches(msg, m.keys.Open):
+		m.diffFocus = true
+		return m.selectFinding(m.cursor), nil
+	}
+
+	if m.diffFocus {
+		var cmd tea.Cmd
+		m.diff, cmd = m.diff.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		return m.selectFinding(m.cursor - 1), nil
+	case key.Matches(msg, m.keys.Down):
+		return m.selectFinding(m.cursor + 1), nil
+	}
+
+	return m, nil
+}
+
+// selectFinding moves the cursor to a finding and scrolls the diff to its hunk
+func (m Model) selectFinding(index int) Model {
+	m.cursor = max(min(index, len(m.findings)-1), 0)
+
+	m.list.SetContent(m.renderList())
+	if m.cursor < m.list.YOffset {
+		m.list.SetYOffset(m.cursor)
+	} else if m.list.Height > 0 && m.cursor >= m.list.YOffset+m.list.Height {
+		m.list.SetYOffset(m.cursor - m.list.Height + 1)
+	}
+
+	content, offset := m.renderFinding()
+	m.diff.SetContent(content)
+	m.diff.SetYOffset(offset)
+
+	return m
+}
+
+// renderList renders one line per finding with the selected one highlighted
+func (m Model) renderList() string {
+	lines := make([]string, 0, len(m.findings))
+	for i, f := range m.findings {
+		marker := "  "
+		text := location(f) + " " + f.Message
+		if i == m.cursor {
+			marker = "▶ "
+			text = selectedStyle.Render(text)
+		}
+
+		line := marker + severityStyle(f.Severity).Render(severityIcon(f.Severity)) + " " + text
+		if m.list.Width > 0 {
+			line = lipgloss.NewStyle().MaxWidth(m.list.Width).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderFinding renders the selected finding followed by the diff of its file
+// and returns the line offset of the hunk the finding points at
+func (m Model) renderFinding() (string, int) {
+	if m.err != nil {
+		return errors.Format(m.err), 0
+	}
+	if len(m.findings) == 0 {
+		return "✅ No findings in the staged changes", 0
+	}
+	f := m.findings[m.cursor]
+
+	header := severityStyle(f.Severity).Render(f.Severity) + " " + commit.Section(location(f))
+	message := f.Message
+	if m.diff.Width > 0 {
+		message = lipgloss.NewStyle().Width(m.diff.Width).Render(message)
+	}
+	lines := []string{header, message, ""}
+
+	diff, ok := m.files[f.File]
+	if !ok || len(diff.Hunks) == 0 {
+		lines = append(lines, mutedStyle.Render("no staged changes for this file"))
+		return strings.Join(lines, "\n"), 0
+	}
+
+	target := review.Locate(diff, f.Line)
+	offset := 0
+	for i, hunk := range diff.Hunks {
+		marker := "  "
+		headerStyle := mutedStyle
+		if i == target {
+			marker = "▶ "
+			headerStyle = selectedStyle
+			// The first hunk is shown below the finding, later ones are scrolled to the top
+			if i > 0 {
+				offset = strings.Count(strings.Join(lines, "\n"), "\n") + 1
+			}
+		}
+		lines = append(lines, marker+headerStyle.Render(hunk.Header))
+		for _, line := range strings.Split(commit.RenderDiff(strings.Join(hunk.Lines, "\n")), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	return strings.Join(lines, "\n"), offset
+}
+
+// location returns the file and line a finding points at
+func location(f gpt.Finding) string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// severityIcon returns the symbol shown in front of a finding of the severity
+func severityIcon(severity string) string {
+	switch severity {
+	case gpt.SeverityError:
+		return "✗"
+	case gpt.SeverityInfo:
+		return "•"
+	default:
+		return "!"
+	}
+}
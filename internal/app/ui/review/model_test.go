@@ -0,0 +1,162 @@
+package review
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/gpt"
+)
+
+const testDiff = `diff --git a/api.go b/api.go
+--- a/api.go
++++ b/api.go
+@@ -3,3 +3,3 @@ package api
+ func Name(cfg *Config) string {
+-	return cfg.Name
++	return name(cfg)
+ }
+@@ -20,2 +20,3 @@ func name(cfg *Config) string {
+ 	if cfg == nil {
++		return ""
+ 	}`
+
+var testFindings = []gpt.Finding{
+	{File: "api.go", Line: 4, Severity: gpt.SeverityWarning, Message: "Name no longer reads the field"},
+	{File: "api.go", Line: 21, Severity: gpt.SeverityError, Message: "Empty name hides the missing config"},
+	{File: "missing.go", Severity: gpt.SeverityInfo, Message: "Add a test"},
+}
+
+func newLoadingModel(t *testing.T, gptClient gpt.Client) Model {
+	ctrl := gomock.NewController(t)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+	mockSpinner.EXPECT().View().Return("⠋").AnyTimes()
+
+	m := NewModel(Input{
+		Diff:      testDiff,
+		GPTClient: gptClient,
+		Ctx:       context.Background(),
+		Spinner:   func() spinner.Model { return mockSpinner },
+	})
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 16})
+	return updated.(Model)
+}
+
+func newTestModel(t *testing.T) Model {
+	updated, _ := newLoadingModel(t, nil).Update(FindingsMsg{Findings: testFindings})
+	return updated.(Model)
+}
+
+func Test_FetchFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []gpt.Finding
+		err      error
+		expected string
+	}{
+		{
+			name:     "Success with findings",
+			findings: testFindings,
+			expected: "review 1/3",
+		},
+		{
+			name:     "Success without findings",
+			findings: []gpt.Finding{},
+			expected: "No findings in the staged changes",
+		},
+		{
+			name:     "Failure with model error",
+			err:      errors.ErrNoResponse,
+			expected: "no response from GPT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGPT := gpt.NewMockClient(ctrl)
+			mockGPT.EXPECT().FetchReview(gomock.Any(), testDiff).Return(tt.findings, tt.err)
+
+			m := newLoadingModel(t, mockGPT)
+			assert.Contains(t, m.View(), "reviewing")
+
+			updated, _ := m.Update(m.fetchFindings()())
+			m = updated.(Model)
+
+			assert.False(t, m.loading)
+			assert.ErrorIs(t, m.GetError(), tt.err)
+			assert.Contains(t, m.View(), tt.expected)
+		})
+	}
+}
+
+func Test_SelectFinding(t *testing.T) {
+	tests := []struct {
+		name           string
+		keys           []tea.KeyMsg
+		expectedCursor int
+		expectedOffset int
+		expectedFocus  bool
+		expected       string
+	}{
+		{
+			name:           "Success with first finding",
+			expectedCursor: 0,
+			expectedOffset: 0,
+			expected:       "Name no longer reads the field",
+		},
+		{
+			name:           "Success jumping to the second hunk",
+			keys:           []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyEnter}},
+			expectedCursor: 1,
+			expectedOffset: 8,
+			expectedFocus:  true,
+			expected:       `return ""`,
+		},
+		{
+			name:           "Success with file outside the diff",
+			keys:           []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'j'}}, {Type: tea.KeyRunes, Runes: []rune{'j'}}, {Type: tea.KeyRunes, Runes: []rune{'j'}}},
+			expectedCursor: 2,
+			expectedOffset: 0,
+			expected:       "no staged changes for this file",
+		},
+		{
+			name:           "Success staying on the first finding",
+			keys:           []tea.KeyMsg{{Type: tea.KeyUp}},
+			expectedCursor: 0,
+			expectedOffset: 0,
+			expected:       "review 1/3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t)
+
+			for _, msg := range tt.keys {
+				updated, _ := m.Update(msg)
+				m = updated.(Model)
+			}
+
+			assert.Equal(t, tt.expectedCursor, m.cursor)
+			assert.Equal(t, tt.expectedOffset, m.diff.YOffset)
+			assert.Equal(t, tt.expectedFocus, m.diffFocus)
+			assert.Contains(t, m.View(), tt.expected)
+		})
+	}
+}
+
+func Test_Quit(t *testing.T) {
+	m := newTestModel(t)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
@@ -0,0 +1,29 @@
+package review
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ui/commit"
+)
+
+var (
+	selectedStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(commit.ColorPrimary)
+
+	mutedStyle = lipgloss.NewStyle().
+			Foreground(commit.ColorMuted)
+)
+
+// severityStyle returns the color of a finding severity
+func severityStyle(severity string) lipgloss.Style {
+	switch severity {
+	case gpt.SeverityError:
+		return lipgloss.NewStyle().Bold(true).Foreground(commit.ColorDeleted)
+	case gpt.SeverityInfo:
+		return mutedStyle
+	default:
+		return lipgloss.NewStyle().Bold(true).Foreground(commit.ColorModified)
+	}
+}
@@ -0,0 +1,42 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/ui/commit"
+)
+
+// View renders the review UI
+func (m Model) View() string {
+	if !m.ready {
+		return "Initializing…"
+	}
+
+	listWidth := m.width / 3
+	diffWidth := m.width - listWidth - 4
+
+	title := ">_ review"
+	switch {
+	case m.loading:
+		title = m.spinner.View() + " reviewing…"
+	case len(m.findings) > 0:
+		title = fmt.Sprintf(">_ review %d/%d", m.cursor+1, len(m.findings))
+	}
+
+	sections := []string{commit.Title(title), ""}
+
+	if !m.loading {
+		panels := lipgloss.JoinHorizontal(lipgloss.Top,
+			commit.Panel(m.list.View(), listWidth, m.height-10, !m.diffFocus),
+			commit.Panel(m.diff.View(), diffWidth, m.height-10, m.diffFocus),
+		)
+		sections = append(sections, panels, "")
+	}
+
+	sections = append(sections, commit.Help(m.help.View(m.keys)))
+
+	return strings.Join(sections, "\n")
+}
@@ -0,0 +1,8 @@
+package reword
+
+// RegenerateMsg carries the message generated again for a commit
+type RegenerateMsg struct {
+	Hash    string
+	Message string
+	Err     error
+}
@@ -0,0 +1,213 @@
+package reword
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+	"cmt/internal/app/ignore"
+)
+
+// Proposal is a commit whose message fails the linter along with the message generated for it
+type Proposal struct {
+	Commit   git.Commit
+	Problems []string
+	Message  string
+}
+
+// Model represents the Bubble Tea model for reviewing reworded commits one by one
+type Model struct {
+	proposals  []Proposal
+	cursor     int
+	messages   map[string]string
+	keys       KeyMap
+	help       help.Model
+	textarea   textarea.Model
+	spinner    spinner.Model
+	gitClient  git.Client
+	gptClient  gpt.Client
+	ignore     *ignore.Matcher
+	ctx        context.Context
+	editing    bool
+	generating bool
+	err        error
+	accepted   bool
+	width      int
+	height     int
+}
+
+// Input contains the commits to review
+type Input struct {
+	Proposals []Proposal
+	GitClient git.Client
+	GPTClient gpt.Client
+	Ignore    *ignore.Matcher
+	Ctx       context.Context
+	Spinner   spinner.Factory
+}
+
+// Output contains the result after the reword UI exits
+type Output struct {
+	Accepted bool
+	// Messages are the accepted messages by commit hash, skipped commits are left out
+	Messages map[string]string
+}
+
+// NewModel creates a new reword UI model
+func NewModel(input Input) Model {
+	h := help.New()
+	h.ShowAll = false
+
+	ta := textarea.New()
+	ta.Placeholder = "Enter commit message…"
+	ta.CharLimit = 0
+
+	return Model{
+		proposals: input.Proposals,
+		messages:  map[string]string{},
+		keys:      DefaultKeyMap(),
+		help:      h,
+		textarea:  ta,
+		spinner:   input.Spinner(),
+		gitClient: input.GitClient,
+		gptClient: input.GPTClient,
+		ignore:    input.Ignore,
+		ctx:       input.Ctx,
+	}
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Update handles messages and updates the model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.textarea.SetWidth(m.width)
+		m.textarea.SetHeight(m.height - 5)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.handleEditMode(msg)
+		}
+		return m.handleNormalMode(msg)
+
+	case RegenerateMsg:
+		m.generating = false
+		m.err = msg.Err
+		if msg.Err == nil && m.current() != nil && m.current().Commit.Hash == msg.Hash {
+			m.proposals[m.cursor].Message = msg.Message
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// handleNormalMode processes keys while reviewing a proposal
+func (m Model) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Quit) {
+		return m, tea.Quit
+	}
+
+	current := m.current()
+	if current == nil || m.generating {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Accept):
+		m.messages[current.Commit.Hash] = current.Message
+		return m.next()
+
+	case key.Matches(msg, m.keys.Skip):
+		return m.next()
+
+	case key.Matches(msg, m.keys.Edit):
+		m.editing = true
+		m.textarea.SetValue(current.Message)
+		m.textarea.Focus()
+		return m, textarea.Blink
+
+	case key.Matches(msg, m.keys.Regenerate):
+		m.generating = true
+		m.err = nil
+		return m, tea.Batch(m.spinner.Tick, m.regenerateMessage(current.Commit.Hash))
+	}
+
+	return m, nil
+}
+
+// handleEditMode processes keys while editing the proposed message
+func (m Model) handleEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		if message := strings.TrimSpace(m.textarea.Value()); message != "" {
+			m.proposals[m.cursor].Message = message
+		}
+		m.editing = false
+		m.textarea.Blur()
+		return m, nil
+
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// next moves to the following proposal and exits once all of them were reviewed
+func (m Model) next() (tea.Model, tea.Cmd) {
+	m.cursor++
+	m.err = nil
+	if m.cursor >= len(m.proposals) {
+		m.accepted = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// regenerateMessage creates a command generating a new message from the diff of the commit
+func (m Model) regenerateMessage(hash string) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := m.gitClient.CommitDiff(m.ctx, hash)
+		if err != nil {
+			return RegenerateMsg{Hash: hash, Err: err}
+		}
+
+		message, err := m.gptClient.FetchCommitMessage(m.ctx, m.ignore.PromptDiff(diff))
+		return RegenerateMsg{Hash: hash, Message: message, Err: err}
+	}
+}
+
+// current returns the proposal under review, or nil once all of them were reviewed
+func (m Model) current() *Proposal {
+	if m.cursor >= len(m.proposals) {
+		return nil
+	}
+	return &m.proposals[m.cursor]
+}
+
+// GetOutput returns the final output after the program exits
+func (m Model) GetOutput() Output {
+	if !m.accepted {
+		return Output{}
+	}
+	return Output{Accepted: true, Messages: m.messages}
+}
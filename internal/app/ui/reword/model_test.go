@@ -0,0 +1,151 @@
+package reword
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"cmt/internal/app/cli/spinner"
+	"cmt/internal/app/errors"
+	"cmt/internal/app/git"
+	"cmt/internal/app/gpt"
+)
+
+func newTestModel(t *testing.T, gitClient git.Client, gptClient gpt.Client) Model {
+	ctrl := gomock.NewController(t)
+	mockSpinner := spinner.NewMockModel(ctrl)
+	mockSpinner.EXPECT().Tick().Return(nil).AnyTimes()
+	mockSpinner.EXPECT().View().Return("⠋").AnyTimes()
+
+	return NewModel(Input{
+		Proposals: []Proposal{
+			{
+				Commit:   git.Commit{Hash: "aaaaaaa1", Subject: "wip"},
+				Problems: []string{"header is not in the form type(scope): description"},
+				Message:  "feat: Add one",
+			},
+			{
+				Commit:   git.Commit{Hash: "bbbbbbb2", Subject: "fixed stuff"},
+				Problems: []string{"header is not in the form type(scope): description"},
+				Message:  "fix: Handle empty input",
+			},
+		},
+		GitClient: gitClient,
+		GPTClient: gptClient,
+		Ctx:       context.Background(),
+		Spinner:   func() spinner.Model { return mockSpinner },
+	})
+}
+
+func press(t *testing.T, m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	updated, cmd := m.Update(msg)
+	result, ok := updated.(Model)
+	assert.True(t, ok)
+	return result, cmd
+}
+
+func keyMsg(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func Test_Review(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []tea.KeyMsg
+		expected Output
+	}{
+		{
+			name:     "Success accepting every proposal",
+			keys:     []tea.KeyMsg{keyMsg('a'), keyMsg('a')},
+			expected: Output{Accepted: true, Messages: map[string]string{"aaaaaaa1": "feat: Add one", "bbbbbbb2": "fix: Handle empty input"}},
+		},
+		{
+			name:     "Success skipping a proposal",
+			keys:     []tea.KeyMsg{keyMsg('s'), keyMsg('a')},
+			expected: Output{Accepted: true, Messages: map[string]string{"bbbbbbb2": "fix: Handle empty input"}},
+		},
+		{
+			name:     "Success with edited proposal",
+			keys:     []tea.KeyMsg{keyMsg('e'), keyMsg('x'), {Type: tea.KeyEsc}, keyMsg('a'), keyMsg('s')},
+			expected: Output{Accepted: true, Messages: map[string]string{"aaaaaaa1": "feat: Add onex"}},
+		},
+		{
+			name:     "Failure when quitting before the end",
+			keys:     []tea.KeyMsg{keyMsg('a'), keyMsg('q')},
+			expected: Output{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, nil, nil)
+
+			for _, msg := range tt.keys {
+				m, _ = press(t, m, msg)
+			}
+
+			assert.Equal(t, tt.expected, m.GetOutput())
+		})
+	}
+}
+
+func Test_Regenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*git.MockClient, *gpt.MockClient)
+		expected string
+		err      error
+	}{
+		{
+			name: "Success with new message",
+			setup: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa1").Return("diff", nil)
+				mockGPT.EXPECT().FetchCommitMessage(gomock.Any(), "diff").Return("feat: Add the first file", nil)
+			},
+			expected: "feat: Add the first file",
+		},
+		{
+			name: "Failure with diff error",
+			setup: func(mockGit *git.MockClient, mockGPT *gpt.MockClient) {
+				mockGit.EXPECT().CommitDiff(gomock.Any(), "aaaaaaa1").Return("", errors.ErrFailedToLoadGitDiff)
+			},
+			expected: "feat: Add one",
+			err:      errors.ErrFailedToLoadGitDiff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockGit := git.NewMockClient(ctrl)
+			mockGPT := gpt.NewMockClient(ctrl)
+			tt.setup(mockGit, mockGPT)
+
+			m := newTestModel(t, mockGit, mockGPT)
+			m, _ = press(t, m, keyMsg('r'))
+			assert.True(t, m.generating)
+			assert.Contains(t, m.View(), "loading")
+
+			updated, _ := m.Update(m.regenerateMessage("aaaaaaa1")())
+			m = updated.(Model)
+
+			assert.False(t, m.generating)
+			assert.Equal(t, tt.expected, m.proposals[0].Message)
+			assert.ErrorIs(t, m.err, tt.err)
+		})
+	}
+}
+
+func Test_View(t *testing.T) {
+	m := newTestModel(t, nil, nil)
+
+	view := m.View()
+
+	assert.Contains(t, view, "reword 1/2 aaaaaaa")
+	assert.Contains(t, view, "wip")
+	assert.Contains(t, view, "header is not in the form type(scope): description")
+	assert.Contains(t, view, "feat: Add one")
+}
@@ -0,0 +1,30 @@
+package reword
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/ui/commit"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(commit.ColorPrimary).
+			Padding(1, 2, 0, 2)
+
+	panelStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(commit.ColorBorder).
+			Padding(0, 1)
+
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(commit.ColorBorder)
+
+	problemStyle = lipgloss.NewStyle().
+			Foreground(commit.ColorDeleted)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(commit.ColorBorder).
+			Padding(0, 2)
+)
@@ -0,0 +1,53 @@
+package reword
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"cmt/internal/app/errors"
+)
+
+// View renders the reword UI
+func (m Model) View() string {
+	current := m.current()
+	if current == nil {
+		return ""
+	}
+
+	if m.editing {
+		return strings.Join([]string{titleStyle.Render(">_ edit " + current.Commit.ShortHash()), "", m.textarea.View()}, "\n")
+	}
+
+	title := fmt.Sprintf(">_ reword %d/%d %s", m.cursor+1, len(m.proposals), current.Commit.ShortHash())
+	if m.generating {
+		title = m.spinner.View() + " loading…"
+	}
+
+	var body []string
+	body = append(body, sectionStyle.Render("Current message"))
+	body = append(body, strings.TrimSpace(current.Commit.Subject+"\n\n"+current.Commit.Body))
+	body = append(body, "")
+	for _, problem := range current.Problems {
+		body = append(body, problemStyle.Render("✗ "+problem))
+	}
+	body = append(body, "")
+	body = append(body, sectionStyle.Render("Proposed message"))
+	body = append(body, current.Message)
+	if m.err != nil {
+		body = append(body, "", problemStyle.Render(errors.Format(m.err)))
+	}
+
+	panel := panelStyle
+	if m.width > 0 {
+		panel = panel.Width(m.width - 2)
+	}
+
+	return strings.Join([]string{
+		titleStyle.Render(title),
+		"",
+		panel.Render(lipgloss.JoinVertical(lipgloss.Left, body...)),
+		helpStyle.Render(m.help.View(m.keys)),
+	}, "\n")
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -18,6 +21,19 @@ const (
 	DefaultRetryCount  = 3
 	DefaultLogLevel    = "info"
 
+	DefaultDiffMaxFileLines = 500
+
+	TicketPlacementPrefix = "prefix"
+	TicketPlacementScope  = "scope"
+	TicketPlacementFooter = "footer"
+
+	SecretsModeAbort  = "abort"
+	SecretsModeRedact = "redact"
+	SecretsModeOff    = "off"
+
+	GitBackendCLI    = "cli"
+	GitBackendNative = "native"
+
 	AppName        = "cmt"
 	AppDescription = "command line utility to generate conversational commits using OpenAI's GPT models"
 
@@ -27,18 +43,44 @@ const (
 // Config represents the application configuration
 type Config struct {
 	Model struct {
-		Name        string  `yaml:"name"`
-		MaxTokens   int     `yaml:"max_tokens"`
-		Temperature float64 `yaml:"temperature"`
-	} `yaml:"model"`
+		Name        string  `yaml:"name" mapstructure:"name"`
+		MaxTokens   int     `yaml:"max_tokens" mapstructure:"max_tokens"`
+		Temperature float64 `yaml:"temperature" mapstructure:"temperature"`
+	} `yaml:"model" mapstructure:"model"`
 	API struct {
-		RetryCount int           `yaml:"retry_count"`
-		Timeout    time.Duration `yaml:"timeout"`
-	} `yaml:"api"`
+		RetryCount int           `yaml:"retry_count" mapstructure:"retry_count"`
+		Timeout    time.Duration `yaml:"timeout" mapstructure:"timeout"`
+		BaseURL    string        `yaml:"base_url" mapstructure:"base_url"`
+		KeyCommand string        `yaml:"key_command" mapstructure:"key_command"`
+	} `yaml:"api" mapstructure:"api"`
 	Logging struct {
-		Level string `yaml:"level"`
-	}
-	Editor string `yaml:"editor"`
+		Level string `yaml:"level" mapstructure:"level"`
+	} `yaml:"logging" mapstructure:"logging"`
+	Ticket struct {
+		Pattern   string `yaml:"pattern" mapstructure:"pattern"`
+		Placement string `yaml:"placement" mapstructure:"placement"`
+	} `yaml:"ticket" mapstructure:"ticket"`
+	Diff struct {
+		Exclude      []string `yaml:"exclude" mapstructure:"exclude"`
+		MaxFileLines int      `yaml:"max_file_lines" mapstructure:"max_file_lines"`
+	} `yaml:"diff" mapstructure:"diff"`
+	Secrets struct {
+		Mode string `yaml:"mode" mapstructure:"mode"`
+	} `yaml:"secrets" mapstructure:"secrets"`
+	Git struct {
+		Backend string `yaml:"backend" mapstructure:"backend"`
+	} `yaml:"git" mapstructure:"git"`
+	Editor string `yaml:"editor" mapstructure:"editor"`
+
+	Profile      string                    `yaml:"profile" mapstructure:"profile"`
+	Profiles     map[string]map[string]any `yaml:"profiles" mapstructure:"profiles"`
+	ProfileRules []ProfileRule             `yaml:"profile_rules" mapstructure:"profile_rules"`
+
+	Packages []Package `yaml:"packages" mapstructure:"packages"`
+
+	origins      map[string]Origin
+	rejected     map[string]Origin
+	repoProfiles map[string]bool
 }
 
 // DefaultConfig returns the default configuration
@@ -54,35 +96,145 @@ func DefaultConfig() *Config {
 
 	cfg.Logging.Level = DefaultLogLevel
 
+	cfg.Ticket.Placement = TicketPlacementPrefix
+
+	cfg.Diff.MaxFileLines = DefaultDiffMaxFileLines
+
+	cfg.Secrets.Mode = SecretsModeAbort
+
+	cfg.Git.Backend = GitBackendCLI
+
 	return cfg
 }
 
-// Load loads the configuration from file and environment variables
-func Load() (*Config, error) {
+// Load resolves the configuration and validates it
+func Load(flags map[string]string) (*Config, error) {
+	cfg, err := Resolve(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Resolve merges, in increasing precedence, the defaults, the global config
+// file, the repository config file, CMT_* environment variables and the given
+// command line flags without validating the result
+func Resolve(flags map[string]string) (*Config, error) {
 	cfg := DefaultConfig()
+	cfg.origins = make(map[string]Origin)
+	cfg.rejected = make(map[string]Origin)
+	cfg.repoProfiles = make(map[string]bool)
 
 	v := viper.New()
-	v.SetConfigName("cmt")
-	v.SetConfigType("yaml")
-	v.AddConfigPath(".")
 
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, errors.ErrFailedToReadConfig
+	files := []struct {
+		source Source
+		path   string
+	}{
+		{source: SourceGlobal, path: GlobalPath()},
+		{source: SourceRepo, path: RepoPath()},
+	}
+
+	profiles := make(map[string]any)
+
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+
+		layer, err := readFile(file.path)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.merge(v, layer.AllSettings(), file.source, file.path)
+
+		if layerProfiles, ok := layer.Get("profiles").(map[string]any); ok {
+			for name, settings := range layerProfiles {
+				profiles[name] = settings
+				cfg.repoProfiles[name] = file.source == SourceRepo
+			}
+		}
+		if layer.IsSet("profile_rules") {
+			v.Set("profile_rules", layer.Get("profile_rules"))
+		}
+		if layer.IsSet("packages") {
+			v.Set("packages", layer.Get("packages"))
+		}
+	}
+
+	v.Set("profiles", profiles)
+
+	for _, key := range Keys() {
+		if value, ok := os.LookupEnv(EnvName(key)); ok {
+			v.Set(key, value)
+			cfg.origins[key] = Origin{Source: SourceEnv, Location: EnvName(key)}
+		}
+	}
+
+	for _, key := range Keys() {
+		if value, ok := flags[key]; ok {
+			v.Set(key, value)
+			cfg.origins[key] = Origin{Source: SourceFlag, Location: FlagName(key)}
 		}
 	}
 
 	if err := v.Unmarshal(cfg); err != nil {
-		return nil, errors.ErrFailedToParseConfig
+		return nil, fmt.Errorf("%w: %v", errors.ErrFailedToParseConfig, err)
 	}
 
-	if err := cfg.validate(); err != nil {
-		return nil, err
+	if cfg.applyProfile(v) {
+		if err := v.Unmarshal(cfg); err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrFailedToParseConfig, err)
+		}
 	}
 
 	return cfg, nil
 }
 
+// readFile reads a single YAML config file, returning an empty layer if it does not exist
+func readFile(path string) (*viper.Viper, error) {
+	layer := viper.New()
+	layer.SetConfigType("yaml")
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return layer, nil
+	}
+
+	layer.SetConfigFile(path)
+	if err := layer.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errors.ErrFailedToReadConfig, path, err)
+	}
+
+	return layer, nil
+}
+
+// merge copies the known keys of a config file layer into v and records their
+// origin, setting aside trusted keys found in the repository config
+func (c *Config) merge(v *viper.Viper, settings map[string]any, source Source, path string) {
+	for _, key := range Keys() {
+		value, ok := lookup(settings, key)
+		if !ok {
+			continue
+		}
+
+		if source == SourceRepo && IsTrustedKey(key) {
+			if fmt.Sprint(value) != "" {
+				c.rejected[key] = Origin{Source: source, Location: path}
+			}
+			continue
+		}
+
+		v.Set(key, value)
+		c.origins[key] = Origin{Source: source, Location: path}
+	}
+}
+
 // GetAPIToken returns the OpenAI API token
 func GetAPIToken() (string, error) {
 	token := os.Getenv("OPENAI_API_KEY")
@@ -93,23 +245,144 @@ func GetAPIToken() (string, error) {
 	return token, nil
 }
 
-// validate validates the configuration values
-func (c *Config) validate() error {
+// FieldError describes an invalid value of a single configuration key
+type FieldError struct {
+	Key string
+	Err error
+}
+
+// Error returns the error message prefixed with the offending key
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors returns the invalid keys reported by Validate
+func FieldErrors(err error) []*FieldError {
+	var result []*FieldError
+
+	var fieldErr *FieldError
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			result = append(result, FieldErrors(e)...)
+		}
+	} else if errors.As(err, &fieldErr) {
+		result = append(result, fieldErr)
+	}
+
+	return result
+}
+
+// Validate validates the configuration values and reports every invalid key
+func (c *Config) Validate() error {
+	var errs []error
+
+	invalid := func(key string, err error) {
+		errs = append(errs, &FieldError{Key: key, Err: err})
+	}
+
 	if c.Model.Temperature < 0 || c.Model.Temperature > 2 {
-		return fmt.Errorf("%w: must be between 0 and 2, got %.2f", errors.ErrInvalidTemperature, c.Model.Temperature)
+		invalid("model.temperature", fmt.Errorf("%w: must be between 0 and 2, got %.2f", errors.ErrInvalidTemperature, c.Model.Temperature))
 	}
 
 	if c.Model.MaxTokens <= 0 {
-		return fmt.Errorf("%w: must be positive, got %d", errors.ErrInvalidMaxTokens, c.Model.MaxTokens)
+		invalid("model.max_tokens", fmt.Errorf("%w: must be positive, got %d", errors.ErrInvalidMaxTokens, c.Model.MaxTokens))
 	}
 
 	if c.API.Timeout <= 0 {
-		return fmt.Errorf("%w: must be positive, got %v", errors.ErrInvalidTimeout, c.API.Timeout)
+		invalid("api.timeout", fmt.Errorf("%w: must be positive, got %v", errors.ErrInvalidTimeout, c.API.Timeout))
 	}
 
 	if c.API.RetryCount < 0 {
-		return fmt.Errorf("%w: must be non-negative, got %d", errors.ErrInvalidRetryCount, c.API.RetryCount)
+		invalid("api.retry_count", fmt.Errorf("%w: must be non-negative, got %d", errors.ErrInvalidRetryCount, c.API.RetryCount))
+	}
+
+	if c.Diff.MaxFileLines < 0 {
+		invalid("diff.max_file_lines", fmt.Errorf("%w: must be non-negative, got %d", errors.ErrInvalidMaxFileLines, c.Diff.MaxFileLines))
+	}
+
+	if _, err := regexp.Compile(c.Ticket.Pattern); err != nil {
+		invalid("ticket.pattern", fmt.Errorf("%w: %v", errors.ErrInvalidTicketPattern, err))
+	}
+
+	switch c.Secrets.Mode {
+	case SecretsModeAbort, SecretsModeRedact, SecretsModeOff:
+	default:
+		invalid("secrets.mode", fmt.Errorf("%w: must be one of abort, redact or off, got %q", errors.ErrInvalidSecretsMode, c.Secrets.Mode))
+	}
+
+	switch c.Git.Backend {
+	case GitBackendCLI, GitBackendNative:
+	default:
+		invalid("git.backend", fmt.Errorf("%w: must be one of cli or native, got %q", errors.ErrInvalidGitBackend, c.Git.Backend))
+	}
+
+	if c.Profile != "" {
+		if _, ok := c.profile(c.Profile); !ok {
+			invalid("profile", fmt.Errorf("%w: %q is not defined in profiles", errors.ErrUnknownProfile, c.Profile))
+		}
+	}
+
+	rejected := make([]string, 0, len(c.rejected))
+	for key := range c.rejected {
+		rejected = append(rejected, key)
+	}
+	sort.Strings(rejected)
+
+	for _, key := range rejected {
+		invalid(key, fmt.Errorf("%w: set in %s, use the global config, %s or %s instead", errors.ErrUntrustedConfigKey, c.rejected[key], EnvName(key), FlagName(key)))
+	}
+
+	for _, name := range c.ProfileNames() {
+		var unknown, untrusted []string
+		for key := range flatten(c.Profiles[name], "") {
+			switch {
+			case !isProfileKey(key):
+				unknown = append(unknown, key)
+			case c.repoProfiles[name] && IsTrustedKey(key):
+				untrusted = append(untrusted, key)
+			}
+		}
+		sort.Strings(unknown)
+		sort.Strings(untrusted)
+
+		for _, key := range unknown {
+			invalid("profiles."+name, fmt.Errorf("%w: %s", errors.ErrUnknownConfigKey, key))
+		}
+		for _, key := range untrusted {
+			invalid("profiles."+name, fmt.Errorf("%w: %s in a profile of %s", errors.ErrUntrustedConfigKey, key, ConfigFileName))
+		}
+	}
+
+	for i, rule := range c.ProfileRules {
+		if _, ok := c.profile(rule.Profile); !ok {
+			invalid(fmt.Sprintf("profile_rules[%d]", i), fmt.Errorf("%w: %q is not defined in profiles", errors.ErrUnknownProfile, rule.Profile))
+		}
+	}
+
+	names := make(map[string]bool)
+	for i, p := range c.Packages {
+		key := fmt.Sprintf("packages[%d]", i)
+		switch {
+		case p.Name == "" || strings.ContainsAny(p.Name, " ~^:?*[\\"):
+			invalid(key, fmt.Errorf("%w: name must be a valid tag prefix, got %q", errors.ErrInvalidPackage, p.Name))
+		case names[p.Name]:
+			invalid(key, fmt.Errorf("%w: %q is defined more than once", errors.ErrInvalidPackage, p.Name))
+		case len(p.Paths) == 0:
+			invalid(key, fmt.Errorf("%w: %q has no paths", errors.ErrInvalidPackage, p.Name))
+		}
+		names[p.Name] = true
+	}
+
+	switch c.Ticket.Placement {
+	case TicketPlacementPrefix, TicketPlacementScope, TicketPlacementFooter:
+	default:
+		invalid("ticket.placement", fmt.Errorf("%w: must be one of prefix, scope or footer, got %q", errors.ErrInvalidTicketPlacement, c.Ticket.Placement))
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -30,12 +30,14 @@ func Test_DefaultConfig(t *testing.T) {
 	assert.Equal(t, DefaultRetryCount, cfg.API.RetryCount)
 	assert.Equal(t, DefaultTimeout, cfg.API.Timeout)
 	assert.Equal(t, DefaultLogLevel, cfg.Logging.Level)
+	assert.Equal(t, TicketPlacementPrefix, cfg.Ticket.Placement)
+	assert.Equal(t, SecretsModeAbort, cfg.Secrets.Mode)
 }
 
 func Test_Load(t *testing.T) {
 	t.Setenv("OPENAI_API_KEY", "test-token")
 
-	cfg, err := Load()
+	cfg, err := Load(nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, cfg)
@@ -62,7 +64,7 @@ func Test_Load_WithInvalidConfig(t *testing.T) {
 		}
 	}()
 
-	cfg, err := Load()
+	cfg, err := Load(nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, cfg)
@@ -85,7 +87,7 @@ func Test_Load_WithBadYAML(t *testing.T) {
 		}
 	}()
 
-	cfg, err := Load()
+	cfg, err := Load(nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, cfg)
@@ -164,6 +166,36 @@ func Test_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Success with ticket pattern and footer placement",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Ticket.Pattern = `(?i)([A-Z]+-\d+)`
+				cfg.Ticket.Placement = TicketPlacementFooter
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "Failure with invalid ticket pattern",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Ticket.Pattern = `([A-Z]+`
+				return cfg
+			},
+			expectError: true,
+			errorMsg:    "invalid ticket pattern",
+		},
+		{
+			name: "Failure with invalid ticket placement",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Ticket.Placement = "header"
+				return cfg
+			},
+			expectError: true,
+			errorMsg:    "invalid ticket placement",
+		},
 		{
 			name: "Failure with negative temperature",
 			setupConfig: func() *Config {
@@ -234,12 +266,42 @@ func Test_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid retry_count",
 		},
+		{
+			name: "Failure with invalid secrets mode",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Secrets.Mode = "warn"
+				return cfg
+			},
+			expectError: true,
+			errorMsg:    "invalid secrets mode",
+		},
+		{
+			name: "Failure with negative max file lines",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Diff.MaxFileLines = -1
+				return cfg
+			},
+			expectError: true,
+			errorMsg:    "invalid max_file_lines",
+		},
+		{
+			name: "Failure with unknown git backend",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Git.Backend = "libgit2"
+				return cfg
+			},
+			expectError: true,
+			errorMsg:    "invalid git backend",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.setupConfig()
-			err := cfg.validate()
+			err := cfg.Validate()
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -0,0 +1,358 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"cmt/internal/app/errors"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringsType  = reflect.TypeOf([]string(nil))
+)
+
+// Get returns the resolved value of a configuration key
+func (c *Config) Get(key string) (string, error) {
+	f, ok := c.field(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errors.ErrUnknownConfigKey, key)
+	}
+
+	return format(f.value), nil
+}
+
+// Set parses raw according to the type of the key and assigns it
+func (c *Config) Set(key, raw string) error {
+	f, ok := c.field(key)
+	if !ok {
+		return fmt.Errorf("%w: %s", errors.ErrUnknownConfigKey, key)
+	}
+
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case f.value.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s expects a duration such as 30s, got %q", errors.ErrInvalidConfigValue, key, raw)
+		}
+		f.value.SetInt(int64(d))
+	case f.value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %s expects an integer, got %q", errors.ErrInvalidConfigValue, key, raw)
+		}
+		f.value.SetInt(int64(n))
+	case f.value.Type() == stringsType:
+		var values []string
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		f.value.Set(reflect.ValueOf(values))
+	case f.value.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s expects a number, got %q", errors.ErrInvalidConfigValue, key, raw)
+		}
+		f.value.SetFloat(n)
+	default:
+		f.value.SetString(raw)
+	}
+
+	return nil
+}
+
+// Origin returns where the value of a key was loaded from
+func (c *Config) Origin(key string) Origin {
+	if origin, ok := c.origins[key]; ok {
+		return origin
+	}
+	return Origin{Source: SourceDefault}
+}
+
+// Encode renders the configuration as YAML
+func (c *Config) Encode() ([]byte, error) {
+	settings := make(map[string]any)
+	for _, f := range fields(reflect.ValueOf(c).Elem(), "") {
+		setNested(settings, f.key, yamlValue(f.value))
+	}
+
+	return yaml.Marshal(settings)
+}
+
+// WriteFile writes the configuration to path, creating parent directories as needed
+func WriteFile(path string, cfg *Config) error {
+	data, err := cfg.Encode()
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToWriteConfig, path, err)
+	}
+
+	return writeFile(path, data)
+}
+
+// SetValue validates a single key against the settings of the YAML file at
+// path and stores it there, keeping the other keys of the file untouched
+func SetValue(path, key, raw string) error {
+	cfg, err := fileConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Set(key, raw); err != nil {
+		return err
+	}
+
+	for _, fieldErr := range FieldErrors(cfg.Validate()) {
+		if fieldErr.Key == key {
+			return fieldErr
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToReadConfig, path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToReadConfig, path, err)
+	}
+
+	f, _ := cfg.field(key)
+	data, err = setYAML(data, &doc, strings.Split(key, "."), yamlValue(f.value))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToWriteConfig, path, err)
+	}
+
+	return writeFile(path, data)
+}
+
+// setYAML returns data with the dotted key set to value, rewriting only the
+// lines of that key so comments, order and indentation of the rest stay as
+// they were
+func setYAML(data []byte, doc *yaml.Node, parts []string, value any) ([]byte, error) {
+	lines := strings.SplitAfter(string(data), "\n")
+	if n := len(lines); lines[n-1] == "" {
+		lines = lines[:n-1]
+	} else {
+		lines[n-1] += "\n"
+	}
+
+	if len(doc.Content) == 0 {
+		block, err := renderYAML(parts, value, 0, defaultIndent)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strings.Join(lines, "") + block), nil
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode || mapping.Style&yaml.FlowStyle != 0 {
+		return nil, fmt.Errorf("top level is not a block mapping")
+	}
+	unit := indentUnit(mapping)
+
+	for i, part := range parts {
+		var keyNode, valueNode *yaml.Node
+		for j := 0; j+1 < len(mapping.Content); j += 2 {
+			if mapping.Content[j].Value == part {
+				keyNode, valueNode = mapping.Content[j], mapping.Content[j+1]
+				break
+			}
+		}
+
+		if keyNode == nil {
+			indent := 0
+			if len(mapping.Content) > 0 {
+				indent = mapping.Content[0].Column - 1
+			}
+			block, err := renderYAML(parts[i:], value, indent, unit)
+			if err != nil {
+				return nil, err
+			}
+			return splice(lines, lastLine(mapping), lastLine(mapping), block), nil
+		}
+
+		if i < len(parts)-1 && valueNode.Kind == yaml.MappingNode && valueNode.Style&yaml.FlowStyle == 0 && len(valueNode.Content) > 0 {
+			mapping = valueNode
+			continue
+		}
+
+		block, err := renderYAML(parts[i:], value, keyNode.Column-1, unit)
+		if err != nil {
+			return nil, err
+		}
+		if comment := valueNode.LineComment; comment != "" && strings.Count(block, "\n") == 1 {
+			block = strings.TrimSuffix(block, "\n") + " " + comment + "\n"
+		}
+		return splice(lines, keyNode.Line-1, lastLine(valueNode), block), nil
+	}
+
+	return data, nil
+}
+
+// defaultIndent is the indentation of new files, matching Encode
+const defaultIndent = 4
+
+// splice replaces lines from (zero based) up to to (exclusive) with block
+func splice(lines []string, from, to int, block string) []byte {
+	var b strings.Builder
+	for _, line := range lines[:from] {
+		b.WriteString(line)
+	}
+	b.WriteString(block)
+	for _, line := range lines[to:] {
+		b.WriteString(line)
+	}
+	return []byte(b.String())
+}
+
+// renderYAML renders value nested under the keys of parts as a block indented
+// by indent spaces, nesting further levels by unit spaces
+func renderYAML(parts []string, value any, indent, unit int) (string, error) {
+	for i := len(parts) - 1; i >= 0; i-- {
+		value = map[string]any{parts[i]: value}
+	}
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(unit)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line != "" {
+			b.WriteString(strings.Repeat(" ", indent) + line)
+		}
+	}
+	return b.String(), nil
+}
+
+// lastLine returns the last line, one based, taken by a node and its children
+func lastLine(n *yaml.Node) int {
+	last := n.Line
+	if n.Kind == yaml.ScalarNode && n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		last += strings.Count(strings.TrimRight(n.Value, "\n"), "\n") + 1
+	}
+	for _, child := range n.Content {
+		last = max(last, lastLine(child))
+	}
+	return last
+}
+
+// indentUnit returns the indentation of the first nested block mapping of a
+// file, or defaultIndent when there is none
+func indentUnit(mapping *yaml.Node) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if value.Kind != yaml.MappingNode || value.Style&yaml.FlowStyle != 0 || len(value.Content) == 0 {
+			continue
+		}
+		if unit := value.Content[0].Column - key.Column; unit > 0 {
+			return unit
+		}
+	}
+	return defaultIndent
+}
+
+// fileConfig returns the defaults merged with the settings of the config file
+// at path, knowing the profiles defined in it and in the global and repository
+// config files
+func fileConfig(path string) (*Config, error) {
+	layer, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	if err := layer.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errors.ErrFailedToParseConfig, path, err)
+	}
+
+	cfg.Profiles = make(map[string]map[string]any)
+	for _, p := range []string{GlobalPath(), RepoPath(), path} {
+		if p == "" {
+			continue
+		}
+
+		layer, err := readFile(p)
+		if err != nil {
+			return nil, err
+		}
+
+		profiles, _ := layer.Get("profiles").(map[string]any)
+		for name, settings := range profiles {
+			cfg.Profiles[name], _ = settings.(map[string]any)
+		}
+	}
+
+	return cfg, nil
+}
+
+// field returns the leaf value addressed by a dotted key
+func (c *Config) field(key string) (field, bool) {
+	for _, f := range fields(reflect.ValueOf(c).Elem(), "") {
+		if f.key == key {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// writeFile writes data to path, creating parent directories as needed
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToWriteConfig, path, err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("%w: %s: %v", errors.ErrFailedToWriteConfig, path, err)
+	}
+
+	return nil
+}
+
+// yamlValue converts a config value to its YAML representation
+func yamlValue(v reflect.Value) any {
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String()
+	case stringsType:
+		if v.Len() == 0 {
+			return []string{}
+		}
+	}
+	return v.Interface()
+}
+
+// setNested assigns value to a dotted key in a nested settings map
+func setNested(settings map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+
+	current := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			current[part] = next
+		}
+		current = next
+	}
+
+	current[parts[len(parts)-1]] = value
+}
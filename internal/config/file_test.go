@@ -0,0 +1,272 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_Config_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "Success with string",
+			key:      "model.name",
+			expected: DefaultModelName,
+		},
+		{
+			name:     "Success with duration",
+			key:      "api.timeout",
+			expected: "1m0s",
+		},
+		{
+			name:          "Failure with unknown key",
+			key:           "model.unknown",
+			expectedError: errors.ErrUnknownConfigKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := DefaultConfig().Get(tt.key)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_Config_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		value         string
+		validate      func(t *testing.T, cfg *Config)
+		expectedError error
+	}{
+		{
+			name:  "Success with string",
+			key:   "editor",
+			value: "vim",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "vim", cfg.Editor)
+			},
+		},
+		{
+			name:  "Success with integer",
+			key:   "model.max_tokens",
+			value: "1000",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 1000, cfg.Model.MaxTokens)
+			},
+		},
+		{
+			name:  "Success with float",
+			key:   "model.temperature",
+			value: "0.3",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 0.3, cfg.Model.Temperature)
+			},
+		},
+		{
+			name:  "Success with duration",
+			key:   "api.timeout",
+			value: "15s",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 15*time.Second, cfg.API.Timeout)
+			},
+		},
+		{
+			name:  "Success with list",
+			key:   "diff.exclude",
+			value: "go.sum, *.pb.go,",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"go.sum", "*.pb.go"}, cfg.Diff.Exclude)
+			},
+		},
+		{
+			name:          "Failure with invalid integer",
+			key:           "model.max_tokens",
+			value:         "many",
+			expectedError: errors.ErrInvalidConfigValue,
+		},
+		{
+			name:          "Failure with invalid duration",
+			key:           "api.timeout",
+			value:         "15",
+			expectedError: errors.ErrInvalidConfigValue,
+		},
+		{
+			name:          "Failure with unknown key",
+			key:           "unknown",
+			value:         "x",
+			expectedError: errors.ErrUnknownConfigKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			err := cfg.Set(tt.key, tt.value)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func Test_SetValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      string
+		key           string
+		value         string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "Success with new file",
+			key:      "model.name",
+			value:    "gpt-4",
+			expected: "model:\n    name: gpt-4\n",
+		},
+		{
+			name:     "Success keeping other keys",
+			existing: "editor: vim\nmodel:\n  max_tokens: 100\n",
+			key:      "api.timeout",
+			value:    "30s",
+			expected: "editor: vim\nmodel:\n  max_tokens: 100\napi:\n  timeout: 30s\n",
+		},
+		{
+			name:     "Success keeping comments and order",
+			existing: "# cmt settings\nmodel:\n  # cheaper model\n  name: gpt-4 # pinned\n  max_tokens: 100\ndiff:\n  exclude:\n    - go.sum\n\n# editor for the TUI\neditor: vim",
+			key:      "model.name",
+			value:    "gpt-4.1",
+			expected: "# cmt settings\nmodel:\n  # cheaper model\n  name: gpt-4.1 # pinned\n  max_tokens: 100\ndiff:\n  exclude:\n    - go.sum\n\n# editor for the TUI\neditor: vim\n",
+		},
+		{
+			name:     "Success with new key in existing section",
+			existing: "model:\n  name: gpt-4\n\n# editor for the TUI\neditor: vim\n",
+			key:      "model.max_tokens",
+			value:    "100",
+			expected: "model:\n  name: gpt-4\n  max_tokens: 100\n\n# editor for the TUI\neditor: vim\n",
+		},
+		{
+			name:     "Success replacing a list",
+			existing: "diff:\n  exclude:\n    - go.sum\n    - \"*.pb.go\"\n  max_file_lines: 100\n",
+			key:      "diff.exclude",
+			value:    "vendor/",
+			expected: "diff:\n  exclude:\n    - vendor/\n  max_file_lines: 100\n",
+		},
+		{
+			name:     "Success with profile defined in the file",
+			existing: "profiles:\n  fast:\n    model:\n      name: gpt-4.1-nano\n",
+			key:      "profile",
+			value:    "fast",
+			expected: "profiles:\n  fast:\n    model:\n      name: gpt-4.1-nano\nprofile: fast\n",
+		},
+		{
+			name:     "Success with other invalid key in the file",
+			existing: "secrets:\n  mode: unknown\n",
+			key:      "editor",
+			value:    "vim",
+			expected: "secrets:\n  mode: unknown\neditor: vim\n",
+		},
+		{
+			name:          "Failure with invalid value",
+			key:           "model.temperature",
+			value:         "3",
+			expectedError: errors.ErrInvalidTemperature,
+		},
+		{
+			name:          "Failure with undefined profile",
+			existing:      "profiles:\n  fast:\n    model:\n      name: gpt-4.1-nano\n",
+			key:           "profile",
+			value:         "slow",
+			expectedError: errors.ErrUnknownProfile,
+		},
+		{
+			name:          "Failure with malformed file",
+			existing:      "model: [broken",
+			key:           "editor",
+			value:         "vim",
+			expectedError: errors.ErrFailedToReadConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			chdirTemp(t)
+
+			path := filepath.Join(t.TempDir(), "nested", ConfigFileName)
+			if tt.existing != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(tt.existing), 0o600))
+			}
+
+			err := SetValue(path, tt.key, tt.value)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
+
+func Test_WriteFile(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	dir := chdirTemp(t)
+
+	cfg := DefaultConfig()
+	cfg.Model.Name = "gpt-4"
+	cfg.API.Timeout = 10 * time.Second
+
+	require.NoError(t, WriteFile(filepath.Join(dir, ConfigFileName), cfg))
+
+	loaded, err := Load(nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4", loaded.Model.Name)
+	assert.Equal(t, 10*time.Second, loaded.API.Timeout)
+	assert.Equal(t, SourceRepo, loaded.Origin("model.name").Source)
+}
+
+func Test_FieldErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Model.Temperature = 5
+	cfg.API.RetryCount = -1
+
+	fieldErrs := FieldErrors(cfg.Validate())
+
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "model.temperature", fieldErrs[0].Key)
+	assert.ErrorIs(t, fieldErrs[0], errors.ErrInvalidTemperature)
+	assert.Equal(t, "api.retry_count", fieldErrs[1].Key)
+	assert.Empty(t, FieldErrors(nil))
+}
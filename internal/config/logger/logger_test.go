@@ -129,7 +129,7 @@ func Test_NewLogger(t *testing.T) {
 func Test_Logger_Debug(t *testing.T) {
 	cfg := &config.Config{
 		Logging: struct {
-			Level string `yaml:"level"`
+			Level string `yaml:"level" mapstructure:"level"`
 		}{
 			Level: DebugLevel,
 		},
@@ -144,7 +144,7 @@ func Test_Logger_Debug(t *testing.T) {
 func Test_Logger_Info(t *testing.T) {
 	cfg := &config.Config{
 		Logging: struct {
-			Level string `yaml:"level"`
+			Level string `yaml:"level" mapstructure:"level"`
 		}{
 			Level: InfoLevel,
 		},
@@ -159,7 +159,7 @@ func Test_Logger_Info(t *testing.T) {
 func Test_Logger_Warn(t *testing.T) {
 	cfg := &config.Config{
 		Logging: struct {
-			Level string `yaml:"level"`
+			Level string `yaml:"level" mapstructure:"level"`
 		}{
 			Level: WarnLevel,
 		},
@@ -174,7 +174,7 @@ func Test_Logger_Warn(t *testing.T) {
 func Test_Logger_Error(t *testing.T) {
 	cfg := &config.Config{
 		Logging: struct {
-			Level string `yaml:"level"`
+			Level string `yaml:"level" mapstructure:"level"`
 		}{
 			Level: ErrorLevel,
 		},
@@ -245,7 +245,7 @@ func Test_GetLogLevel(t *testing.T) {
 func Test_NewLogger_WithBuffer(t *testing.T) {
 	cfg := &config.Config{
 		Logging: struct {
-			Level string `yaml:"level"`
+			Level string `yaml:"level" mapstructure:"level"`
 		}{
 			Level: InfoLevel,
 		},
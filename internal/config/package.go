@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// Package is a part of a monorepo with its own changelog and release tags
+type Package struct {
+	Name      string   `yaml:"name" mapstructure:"name"`
+	Paths     []string `yaml:"paths" mapstructure:"paths"`
+	Changelog string   `yaml:"changelog" mapstructure:"changelog"`
+}
+
+// TagPrefix returns the prefix of the package release tags, such as api/ for api/v1.2.0
+func (p Package) TagPrefix() string {
+	if p.Name == "" {
+		return ""
+	}
+	return p.Name + "/"
+}
+
+// Pathspecs returns the package paths as git pathspecs relative to the repository root
+func (p Package) Pathspecs() []string {
+	specs := make([]string, 0, len(p.Paths))
+	for _, value := range p.Paths {
+		specs = append(specs, ":(top,glob)"+strings.Trim(value, "/"))
+	}
+	return specs
+}
+
+// ChangelogFile returns the repository-relative changelog of the package,
+// defaulting to CHANGELOG.md in the directory of its first path
+func (p Package) ChangelogFile() string {
+	if p.Changelog != "" {
+		return p.Changelog
+	}
+	if len(p.Paths) == 0 {
+		return ""
+	}
+
+	dir := strings.Trim(p.Paths[0], "/")
+	if i := strings.IndexAny(dir, "*?["); i >= 0 {
+		// keep the directories before the first wildcard
+		dir = dir[:strings.LastIndex(dir[:i], "/")+1]
+	}
+
+	return path.Join(dir, "CHANGELOG.md")
+}
+
+// Package returns the package with the given name
+func (c *Config) Package(name string) (Package, bool) {
+	for _, p := range c.Packages {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Package{}, false
+}
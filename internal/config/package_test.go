@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/app/errors"
+)
+
+func Test_Package(t *testing.T) {
+	tests := []struct {
+		name      string
+		pkg       Package
+		prefix    string
+		pathspecs []string
+		changelog string
+	}{
+		{
+			name:      "Success with directory glob",
+			pkg:       Package{Name: "api", Paths: []string{"services/api/**", "/libs/proto/"}},
+			prefix:    "api/",
+			pathspecs: []string{":(top,glob)services/api/**", ":(top,glob)libs/proto"},
+			changelog: "services/api/CHANGELOG.md",
+		},
+		{
+			name:      "Success with explicit changelog",
+			pkg:       Package{Name: "web", Paths: []string{"web"}, Changelog: "docs/WEB.md"},
+			prefix:    "web/",
+			pathspecs: []string{":(top,glob)web"},
+			changelog: "docs/WEB.md",
+		},
+		{
+			name:      "Success with wildcard at the root",
+			pkg:       Package{Name: "docs", Paths: []string{"*.md"}},
+			prefix:    "docs/",
+			pathspecs: []string{":(top,glob)*.md"},
+			changelog: "CHANGELOG.md",
+		},
+		{
+			name:      "Success with whole repository",
+			pkg:       Package{},
+			prefix:    "",
+			pathspecs: []string{},
+			changelog: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.prefix, tt.pkg.TagPrefix())
+			assert.Equal(t, tt.pathspecs, tt.pkg.Pathspecs())
+			assert.Equal(t, tt.changelog, tt.pkg.ChangelogFile())
+		})
+	}
+}
+
+func Test_Load_Packages(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := chdirTemp(t)
+
+	content := `packages:
+  - name: api
+    paths: ["services/api/**"]
+  - name: web
+    paths: ["web"]
+    changelog: web/CHANGES.md
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(content), 0o600))
+
+	cfg, err := Load(nil)
+	require.NoError(t, err)
+
+	pkg, ok := cfg.Package("web")
+	assert.True(t, ok)
+	assert.Equal(t, Package{Name: "web", Paths: []string{"web"}, Changelog: "web/CHANGES.md"}, pkg)
+
+	_, ok = cfg.Package("cli")
+	assert.False(t, ok)
+}
+
+func Test_Validate_Packages(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []Package
+		key      string
+	}{
+		{name: "Failure without name", packages: []Package{{Paths: []string{"api"}}}, key: "packages[0]"},
+		{name: "Failure with name not usable in tags", packages: []Package{{Name: "my api", Paths: []string{"api"}}}, key: "packages[0]"},
+		{name: "Failure with duplicate name", packages: []Package{{Name: "api", Paths: []string{"api"}}, {Name: "api", Paths: []string{"v2"}}}, key: "packages[1]"},
+		{name: "Failure without paths", packages: []Package{{Name: "api"}}, key: "packages[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Packages = tt.packages
+
+			fieldErrs := FieldErrors(cfg.Validate())
+
+			require.Len(t, fieldErrs, 1)
+			assert.Equal(t, tt.key, fieldErrs[0].Key)
+			assert.ErrorIs(t, fieldErrs[0], errors.ErrInvalidPackage)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ProfileRule selects a profile when the current branch and directory match
+type ProfileRule struct {
+	Branch  string `yaml:"branch" mapstructure:"branch"`
+	Path    string `yaml:"path" mapstructure:"path"`
+	Profile string `yaml:"profile" mapstructure:"profile"`
+}
+
+// String returns a human readable representation of the rule conditions
+func (r ProfileRule) String() string {
+	var conditions []string
+	if r.Branch != "" {
+		conditions = append(conditions, "branch "+r.Branch)
+	}
+	if r.Path != "" {
+		conditions = append(conditions, "path "+r.Path)
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// Matches reports whether the rule applies to the given branch and repository-relative directory
+func (r ProfileRule) Matches(branch, dir string) bool {
+	if r.Branch == "" && r.Path == "" {
+		return false
+	}
+
+	if r.Branch != "" {
+		if ok, _ := path.Match(r.Branch, branch); !ok {
+			return false
+		}
+	}
+
+	if r.Path != "" {
+		pattern := strings.Trim(r.Path, "/")
+		ok, _ := path.Match(pattern, dir)
+		if !ok && dir != pattern && !strings.HasPrefix(dir, pattern+"/") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ProfileNames returns the names of the defined profiles in sorted order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profile returns the settings of a profile by case-insensitive name
+func (c *Config) profile(name string) (map[string]any, bool) {
+	settings, ok := c.Profiles[strings.ToLower(name)]
+	return settings, ok
+}
+
+// applyProfile selects the active profile and copies its settings into v for
+// every key not overridden by the environment or a flag, reporting whether
+// any setting was applied
+func (c *Config) applyProfile(v *viper.Viper) bool {
+	if source := c.Origin("profile").Source; source != SourceEnv && source != SourceFlag {
+		branch, dir := currentBranch(), currentDir()
+		for _, rule := range c.ProfileRules {
+			if rule.Matches(branch, dir) {
+				c.Profile = rule.Profile
+				c.origins["profile"] = Origin{Source: SourceRule, Location: rule.String()}
+				v.Set("profile", rule.Profile)
+				break
+			}
+		}
+	}
+
+	settings, ok := c.profile(c.Profile)
+	if c.Profile == "" || !ok {
+		return false
+	}
+
+	repoProfile := c.repoProfiles[strings.ToLower(c.Profile)]
+
+	for key, value := range flatten(settings, "") {
+		if !isProfileKey(key) || (repoProfile && IsTrustedKey(key)) {
+			continue
+		}
+		if source := c.Origin(key).Source; source == SourceEnv || source == SourceFlag {
+			continue
+		}
+
+		v.Set(key, value)
+		c.origins[key] = Origin{Source: SourceProfile, Location: strings.ToLower(c.Profile)}
+	}
+
+	return true
+}
+
+// isProfileKey reports whether a key may be set by a profile
+func isProfileKey(key string) bool {
+	if key == "profile" {
+		return false
+	}
+
+	for _, k := range Keys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten converts nested settings into a map keyed by dotted keys
+func flatten(settings map[string]any, prefix string) map[string]any {
+	result := make(map[string]any)
+
+	for k, value := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			for nk, nv := range flatten(nested, key) {
+				result[nk] = nv
+			}
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// currentBranch returns the checked out branch or an empty string
+func currentBranch() string {
+	out, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err == nil {
+		return strings.TrimSpace(string(out))
+	}
+
+	// without the git binary, read the symbolic ref from .git/HEAD
+	head, err := os.ReadFile(filepath.Join(RepoRoot(), ".git", "HEAD"))
+	if err != nil {
+		return ""
+	}
+
+	ref, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: refs/heads/")
+	if !ok {
+		return ""
+	}
+	return ref
+}
+
+// currentDir returns the working directory relative to the repository root
+func currentDir() string {
+	root := RepoRoot()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(root, wd)
+	if err != nil || rel == "." {
+		return ""
+	}
+
+	return filepath.ToSlash(rel)
+}
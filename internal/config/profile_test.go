@@ -0,0 +1,260 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/app/errors"
+)
+
+const testProfiles = `model:
+  name: repo-model
+profiles:
+  fast:
+    model:
+      name: gpt-4.1-nano
+  careful:
+    model:
+      name: gpt-4.1
+      temperature: 0.2
+    api:
+      timeout: 120s
+profile_rules:
+  - branch: release/*
+    profile: careful
+  - path: docs
+    profile: fast
+`
+
+func Test_ProfileRule_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     ProfileRule
+		branch   string
+		dir      string
+		expected bool
+	}{
+		{
+			name:     "Success with branch glob",
+			rule:     ProfileRule{Branch: "release/*", Profile: "careful"},
+			branch:   "release/1.2",
+			expected: true,
+		},
+		{
+			name:     "Success with nested path",
+			rule:     ProfileRule{Path: "docs/", Profile: "fast"},
+			dir:      "docs/guides",
+			expected: true,
+		},
+		{
+			name:     "Success with branch and path",
+			rule:     ProfileRule{Branch: "main", Path: "docs", Profile: "fast"},
+			branch:   "main",
+			dir:      "docs",
+			expected: true,
+		},
+		{
+			name:     "Failure with other branch",
+			rule:     ProfileRule{Branch: "release/*", Profile: "careful"},
+			branch:   "feature/x",
+			expected: false,
+		},
+		{
+			name:     "Failure with path sharing prefix",
+			rule:     ProfileRule{Path: "docs", Profile: "fast"},
+			dir:      "docsite",
+			expected: false,
+		},
+		{
+			name:     "Failure without conditions",
+			rule:     ProfileRule{Profile: "fast"},
+			branch:   "main",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Matches(tt.branch, tt.dir))
+		})
+	}
+}
+
+func Test_Load_Profiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		flags    map[string]string
+		validate func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "Success without active profile",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "repo-model", cfg.Model.Name)
+				assert.Equal(t, []string{"careful", "fast"}, cfg.ProfileNames())
+				assert.Len(t, cfg.ProfileRules, 2)
+			},
+		},
+		{
+			name:  "Success with profile flag",
+			flags: map[string]string{"profile": "careful"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "gpt-4.1", cfg.Model.Name)
+				assert.Equal(t, 0.2, cfg.Model.Temperature)
+				assert.Equal(t, 120*time.Second, cfg.API.Timeout)
+				assert.Equal(t, Origin{Source: SourceProfile, Location: "careful"}, cfg.Origin("model.name"))
+				assert.Equal(t, DefaultMaxTokens, cfg.Model.MaxTokens)
+			},
+		},
+		{
+			name: "Success with profile env and env override",
+			env:  map[string]string{"CMT_PROFILE": "careful", "CMT_MODEL_TEMPERATURE": "0.5"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "gpt-4.1", cfg.Model.Name)
+				assert.Equal(t, 0.5, cfg.Model.Temperature)
+				assert.Equal(t, SourceEnv, cfg.Origin("model.temperature").Source)
+			},
+		},
+		{
+			name:  "Success with flag overriding profile",
+			flags: map[string]string{"profile": "fast", "model.name": "flag-model"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "flag-model", cfg.Model.Name)
+				assert.Equal(t, SourceFlag, cfg.Origin("model.name").Source)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			dir := chdirTemp(t)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(testProfiles), 0o600))
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load(tt.flags)
+
+			require.NoError(t, err)
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func Test_Load_ProfileRules(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	tests := []struct {
+		name            string
+		branch          string
+		subdir          string
+		expectedProfile string
+		expectedModel   string
+	}{
+		{
+			name:            "Success with branch rule",
+			branch:          "release/1.0",
+			expectedProfile: "careful",
+			expectedModel:   "gpt-4.1",
+		},
+		{
+			name:            "Success with path rule",
+			branch:          "main",
+			subdir:          "docs/guides",
+			expectedProfile: "fast",
+			expectedModel:   "gpt-4.1-nano",
+		},
+		{
+			name:          "Success without matching rule",
+			branch:        "main",
+			subdir:        "src",
+			expectedModel: "repo-model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			dir := chdirTemp(t)
+
+			out, err := exec.Command("git", "init", "-q", "-b", tt.branch, dir).CombinedOutput()
+			require.NoError(t, err, string(out))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(testProfiles), 0o600))
+
+			if tt.subdir != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, tt.subdir), 0o755))
+				require.NoError(t, os.Chdir(filepath.Join(dir, tt.subdir)))
+			}
+
+			cfg, err := Load(nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedProfile, cfg.Profile)
+			assert.Equal(t, tt.expectedModel, cfg.Model.Name)
+			if tt.expectedProfile != "" {
+				assert.Equal(t, SourceRule, cfg.Origin("profile").Source)
+			}
+		})
+	}
+}
+
+func Test_Validate_Profiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupConfig   func() *Config
+		expectedKey   string
+		expectedError error
+	}{
+		{
+			name: "Failure with unknown active profile",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Profile = "missing"
+				return cfg
+			},
+			expectedKey:   "profile",
+			expectedError: errors.ErrUnknownProfile,
+		},
+		{
+			name: "Failure with unknown key in profile",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.Profiles = map[string]map[string]any{
+					"fast": {"model": map[string]any{"nme": "x"}},
+				}
+				return cfg
+			},
+			expectedKey:   "profiles.fast",
+			expectedError: errors.ErrUnknownConfigKey,
+		},
+		{
+			name: "Failure with rule referencing unknown profile",
+			setupConfig: func() *Config {
+				cfg := DefaultConfig()
+				cfg.ProfileRules = []ProfileRule{{Branch: "main", Profile: "missing"}}
+				return cfg
+			},
+			expectedKey:   "profile_rules[0]",
+			expectedError: errors.ErrUnknownProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErrs := FieldErrors(tt.setupConfig().Validate())
+
+			require.Len(t, fieldErrs, 1)
+			assert.Equal(t, tt.expectedKey, fieldErrs[0].Key)
+			assert.ErrorIs(t, fieldErrs[0], tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const (
+	// ConfigFileName is the name of the repository config file
+	ConfigFileName = "cmt.yaml"
+	// EnvPrefix is the prefix of environment variables overriding config keys
+	EnvPrefix = "CMT_"
+)
+
+// Source identifies the layer a configuration value was loaded from
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceGlobal  Source = "global"
+	SourceRepo    Source = "repo"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+	SourceProfile Source = "profile"
+	SourceRule    Source = "rule"
+)
+
+// Origin describes where a configuration value came from
+type Origin struct {
+	Source   Source
+	Location string
+}
+
+// String returns a human readable representation of the origin
+func (o Origin) String() string {
+	if o.Location == "" {
+		return string(o.Source)
+	}
+	return fmt.Sprintf("%s (%s)", o.Source, o.Location)
+}
+
+// Flags holds the config keys overridden on the command line
+type Flags map[string]string
+
+// Setting is a single resolved configuration value and its origin
+type Setting struct {
+	Key    string
+	Value  string
+	Origin Origin
+}
+
+// flagKeys maps command line flags to the config keys they override
+var flagKeys = map[string]string{
+	"--model":       "model.name",
+	"--max-tokens":  "model.max_tokens",
+	"--temperature": "model.temperature",
+	"--retry-count": "api.retry_count",
+	"--timeout":     "api.timeout",
+	"--log-level":   "logging.level",
+	"--editor":      "editor",
+	"--profile":     "profile",
+}
+
+// trustedKeys lists the keys that run commands on the user's machine or decide
+// where the API token and diffs are sent, which a cloned repository must not be
+// able to set through its cmt.yaml or profiles
+var trustedKeys = []string{
+	"api.base_url",
+	"api.key_command",
+	"editor",
+}
+
+// IsTrustedKey reports whether a key is only accepted from the global config
+// file, the environment and flags
+func IsTrustedKey(key string) bool {
+	for _, k := range trustedKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns all configuration keys in declaration order
+func Keys() []string {
+	var keys []string
+	for _, f := range fields(reflect.ValueOf(DefaultConfig()).Elem(), "") {
+		keys = append(keys, f.key)
+	}
+	return keys
+}
+
+// EnvName returns the environment variable overriding the given key
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// FlagName returns the command line flag overriding the given key
+func FlagName(key string) string {
+	for flag, k := range flagKeys {
+		if k == key {
+			return flag
+		}
+	}
+	return ""
+}
+
+// GlobalPath returns the path of the user-wide config file
+func GlobalPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, AppName, "config.yaml")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".config", AppName, "config.yaml")
+}
+
+// RepoPath returns the path of the config file at the root of the current repository
+func RepoPath() string {
+	return filepath.Join(RepoRoot(), ConfigFileName)
+}
+
+// RepoRoot returns the root of the current repository, falling back to the
+// working directory outside of a repository
+func RepoRoot() string {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err == nil {
+		return strings.TrimSpace(string(out))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+
+	// without the git binary, look for the closest directory holding .git
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return wd
+		}
+	}
+}
+
+// ParseFlags extracts config flags from args, returning the overrides keyed by
+// config key and the remaining arguments
+func ParseFlags(args []string) (map[string]string, []string) {
+	flags := make(map[string]string)
+	remaining := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+
+		key, ok := flagKeys[name]
+		if !ok {
+			remaining = append(remaining, args[i])
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				remaining = append(remaining, args[i])
+				continue
+			}
+			i++
+			value = args[i]
+		}
+
+		flags[key] = value
+	}
+
+	return flags, remaining
+}
+
+// Settings returns every configuration value along with its origin
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+
+	for _, f := range fields(reflect.ValueOf(c).Elem(), "") {
+		settings = append(settings, Setting{
+			Key:    f.key,
+			Value:  format(f.value),
+			Origin: c.Origin(f.key),
+		})
+	}
+
+	return settings
+}
+
+// format renders a config value for display
+func format(v reflect.Value) string {
+	if v.Type() == stringsType {
+		return strings.Join(v.Interface().([]string), ",")
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// field is a leaf value of the config struct addressed by its dotted key
+type field struct {
+	key   string
+	value reflect.Value
+}
+
+// fields walks the config struct and returns its scalar values keyed by mapstructure tags
+func fields(v reflect.Value, prefix string) []field {
+	var result []field
+
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("mapstructure")
+		if tag == "" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		switch v.Field(i).Kind() {
+		case reflect.Struct:
+			result = append(result, fields(v.Field(i), key)...)
+			continue
+		case reflect.Map:
+			continue
+		case reflect.Slice:
+			if v.Field(i).Type() != stringsType {
+				continue
+			}
+		}
+
+		result = append(result, field{key: key, value: v.Field(i)})
+	}
+
+	return result
+}
+
+// lookup returns the value of a dotted key in a nested settings map
+func lookup(settings map[string]any, key string) (any, bool) {
+	parts := strings.Split(key, ".")
+
+	var current any = settings
+	for _, part := range parts {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
@@ -0,0 +1,363 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cmt/internal/app/errors"
+)
+
+// chdirTemp switches into a fresh temporary directory for the duration of the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalWd); err != nil {
+			t.Errorf("failed to restore directory: %v", err)
+		}
+	})
+	return tmpDir
+}
+
+func Test_Load_Hierarchy(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   string
+		repo     string
+		env      map[string]string
+		flags    map[string]string
+		validate func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "Success with defaults",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, DefaultModelName, cfg.Model.Name)
+				assert.Equal(t, Origin{Source: SourceDefault}, originOf(cfg, "model.name"))
+			},
+		},
+		{
+			name:   "Success with global file",
+			global: "model:\n  name: global-model\n  max_tokens: 100\n",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "global-model", cfg.Model.Name)
+				assert.Equal(t, 100, cfg.Model.MaxTokens)
+				assert.Equal(t, SourceGlobal, originOf(cfg, "model.max_tokens").Source)
+			},
+		},
+		{
+			name:   "Success with repo file overriding global",
+			global: "model:\n  name: global-model\n  max_tokens: 100\n",
+			repo:   "model:\n  name: repo-model\napi:\n  timeout: 30s\n",
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "repo-model", cfg.Model.Name)
+				assert.Equal(t, 100, cfg.Model.MaxTokens)
+				assert.Equal(t, 30*time.Second, cfg.API.Timeout)
+				assert.Equal(t, SourceRepo, originOf(cfg, "model.name").Source)
+				assert.Equal(t, SourceGlobal, originOf(cfg, "model.max_tokens").Source)
+			},
+		},
+		{
+			name: "Success with env overriding repo",
+			repo: "model:\n  name: repo-model\n",
+			env:  map[string]string{"CMT_MODEL_NAME": "env-model", "CMT_MODEL_TEMPERATURE": "0.2"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "env-model", cfg.Model.Name)
+				assert.Equal(t, 0.2, cfg.Model.Temperature)
+				assert.Equal(t, Origin{Source: SourceEnv, Location: "CMT_MODEL_NAME"}, originOf(cfg, "model.name"))
+			},
+		},
+		{
+			name: "Success with list from repo file and env",
+			repo: "diff:\n  exclude:\n    - go.sum\n",
+			env:  map[string]string{"CMT_EDITOR": "nano"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"go.sum"}, cfg.Diff.Exclude)
+				assert.Equal(t, "nano", cfg.Editor)
+			},
+		},
+		{
+			name: "Success with list from env",
+			env:  map[string]string{"CMT_DIFF_EXCLUDE": "go.sum,vendor/"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"go.sum", "vendor/"}, cfg.Diff.Exclude)
+				value, err := cfg.Get("diff.exclude")
+				assert.NoError(t, err)
+				assert.Equal(t, "go.sum,vendor/", value)
+			},
+		},
+		{
+			name:  "Success with flags overriding env",
+			env:   map[string]string{"CMT_MODEL_NAME": "env-model"},
+			flags: map[string]string{"model.name": "flag-model", "api.timeout": "5s"},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "flag-model", cfg.Model.Name)
+				assert.Equal(t, 5*time.Second, cfg.API.Timeout)
+				assert.Equal(t, Origin{Source: SourceFlag, Location: "--model"}, originOf(cfg, "model.name"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xdg := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdg)
+			dir := chdirTemp(t)
+
+			if tt.global != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(xdg, AppName), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(xdg, AppName, "config.yaml"), []byte(tt.global), 0o600))
+			}
+			if tt.repo != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(tt.repo), 0o600))
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load(tt.flags)
+
+			require.NoError(t, err)
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func Test_Load_TrustedKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   string
+		repo     string
+		env      map[string]string
+		flags    map[string]string
+		key      string
+		expected string
+		err      string
+	}{
+		{
+			name:     "Success with editor from global file",
+			global:   "editor: vim\n",
+			key:      "editor",
+			expected: "vim",
+		},
+		{
+			name:     "Success with editor from env",
+			repo:     "model:\n  name: repo-model\n",
+			env:      map[string]string{"CMT_EDITOR": "nano"},
+			key:      "editor",
+			expected: "nano",
+		},
+		{
+			name:     "Success with editor from flag",
+			flags:    map[string]string{"editor": "code --wait"},
+			key:      "editor",
+			expected: "code --wait",
+		},
+		{
+			name:     "Success with empty editor in repo file",
+			global:   "editor: vim\n",
+			repo:     "editor: \"\"\n",
+			key:      "editor",
+			expected: "vim",
+		},
+		{
+			name:     "Success with editor in global profile",
+			global:   "profiles:\n  work:\n    editor: vim\n",
+			flags:    map[string]string{"profile": "work"},
+			key:      "editor",
+			expected: "vim",
+		},
+		{
+			name:     "Success with base url from global profile",
+			global:   "profiles:\n  local:\n    api:\n      base_url: http://localhost:11434/v1\n",
+			repo:     "profile: local\n",
+			key:      "api.base_url",
+			expected: "http://localhost:11434/v1",
+		},
+		{
+			name:     "Success with base url from env",
+			env:      map[string]string{"CMT_API_BASE_URL": "http://localhost:11434/v1"},
+			key:      "api.base_url",
+			expected: "http://localhost:11434/v1",
+		},
+		{
+			name: "Failure with base url in repo file",
+			repo: "api:\n  base_url: https://run.sh.example.com/v1\n",
+			key:  "api.base_url",
+			err:  "api.base_url: not allowed in the repository config",
+		},
+		{
+			name:   "Failure with base url in repo profile overriding a global one",
+			global: "profiles:\n  local:\n    api:\n      base_url: http://localhost:11434/v1\n",
+			repo:   "profiles:\n  local:\n    api:\n      base_url: https://run.sh.example.com/v1\n",
+			flags:  map[string]string{"profile": "local"},
+			key:    "api.base_url",
+			err:    "profiles.local: not allowed in the repository config: api.base_url",
+		},
+		{
+			name:     "Success with key command from global file",
+			global:   "api:\n  key_command: pass show openai\n",
+			repo:     "model:\n  name: repo-model\n",
+			key:      "api.key_command",
+			expected: "pass show openai",
+		},
+		{
+			name: "Failure with key command in repo file",
+			repo: "api:\n  key_command: ./run.sh\n",
+			key:  "api.key_command",
+			err:  "api.key_command: not allowed in the repository config",
+		},
+		{
+			name: "Failure with key command in repo profile",
+			repo: "profile: ci\nprofiles:\n  ci:\n    api:\n      key_command: ./run.sh\n",
+			key:  "api.key_command",
+			err:  "profiles.ci: not allowed in the repository config: api.key_command",
+		},
+		{
+			name:   "Failure with editor in repo file",
+			global: "editor: vim\n",
+			repo:   "editor: ./run.sh\n",
+			key:    "editor",
+			err:    "editor: not allowed in the repository config",
+		},
+		{
+			name:  "Failure with editor in repo profile",
+			repo:  "profiles:\n  work:\n    editor: ./run.sh\n",
+			flags: map[string]string{"profile": "work"},
+			key:   "editor",
+			err:   "profiles.work: not allowed in the repository config: editor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xdg := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdg)
+			dir := chdirTemp(t)
+
+			if tt.global != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(xdg, AppName), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(xdg, AppName, "config.yaml"), []byte(tt.global), 0o600))
+			}
+			if tt.repo != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(tt.repo), 0o600))
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load(tt.flags)
+
+			if tt.err != "" {
+				assert.ErrorIs(t, err, errors.ErrUntrustedConfigKey)
+				assert.ErrorContains(t, err, tt.err)
+
+				resolved, err := Resolve(tt.flags)
+				require.NoError(t, err)
+				value, _ := resolved.Get(tt.key)
+				assert.NotContains(t, value, "run.sh")
+				return
+			}
+			require.NoError(t, err)
+			value, err := cfg.Get(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_Load_WithBadGlobalFile(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	chdirTemp(t)
+
+	path := filepath.Join(xdg, AppName, "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("model: [broken"), 0o600))
+
+	cfg, err := Load(nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+	assert.Nil(t, cfg)
+}
+
+func Test_ParseFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedFlags map[string]string
+		expectedArgs  []string
+	}{
+		{
+			name:          "Success with separate values",
+			args:          []string{"--model", "gpt-4", "changelog", "--timeout", "10s"},
+			expectedFlags: map[string]string{"model.name": "gpt-4", "api.timeout": "10s"},
+			expectedArgs:  []string{"changelog"},
+		},
+		{
+			name:          "Success with inline values",
+			args:          []string{"--temperature=0.1", "--prefix", "TASK-1"},
+			expectedFlags: map[string]string{"model.temperature": "0.1"},
+			expectedArgs:  []string{"--prefix", "TASK-1"},
+		},
+		{
+			name:          "Failure with missing value",
+			args:          []string{"--editor"},
+			expectedFlags: map[string]string{},
+			expectedArgs:  []string{"--editor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, args := ParseFlags(tt.args)
+
+			assert.Equal(t, tt.expectedFlags, flags)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func Test_Keys(t *testing.T) {
+	keys := Keys()
+
+	assert.Contains(t, keys, "model.name")
+	assert.Contains(t, keys, "api.timeout")
+	assert.Contains(t, keys, "logging.level")
+	assert.Contains(t, keys, "editor")
+
+	for _, key := range flagKeys {
+		assert.Contains(t, keys, key)
+	}
+}
+
+func Test_EnvName(t *testing.T) {
+	assert.Equal(t, "CMT_MODEL_MAX_TOKENS", EnvName("model.max_tokens"))
+	assert.Equal(t, "CMT_EDITOR", EnvName("editor"))
+}
+
+func Test_Settings(t *testing.T) {
+	settings := DefaultConfig().Settings()
+
+	assert.Len(t, settings, len(Keys()))
+	assert.Equal(t, Setting{Key: "model.name", Value: DefaultModelName, Origin: Origin{Source: SourceDefault}}, settings[0])
+}
+
+// originOf returns the origin of a key from the config settings
+func originOf(cfg *Config, key string) Origin {
+	for _, s := range cfg.Settings() {
+		if s.Key == key {
+			return s.Origin
+		}
+	}
+	return Origin{}
+}